@@ -18,8 +18,8 @@ CREATE TABLE users (
   address TEXT,
   hash_password VARCHAR(100),
   role user_type,
-  created_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP,
-  updated_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP
+  created_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
 
 
@@ -27,8 +27,8 @@ CREATE TABLE trainers (
   id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
   phone_number VARCHAR(13) UNIQUE,
   user_id uuid UNIQUE,
-  created_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP,
-  updated_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP,
+  created_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP,
   FOREIGN KEY ("user_id") REFERENCES "users" ("id")
 );
 
@@ -36,7 +36,37 @@ CREATE TABLE specializations (
   id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
   trainer_id uuid NOT NULL,
   name VARCHAR(100),
-  FOREIGN KEY ("trainer_id") REFERENCES "trainers" ("id")
+  UNIQUE ("trainer_id", "name"),
+  FOREIGN KEY ("trainer_id") REFERENCES "trainers" ("id") ON DELETE CASCADE
+);
+
+CREATE TABLE trainer_availabilities (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  trainer_id uuid NOT NULL,
+  day_of_week SMALLINT NOT NULL CHECK (day_of_week BETWEEN 0 AND 6),
+  start_time TIME NOT NULL,
+  end_time TIME NOT NULL,
+  created_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP,
+  CHECK (start_time < end_time),
+  FOREIGN KEY ("trainer_id") REFERENCES "trainers" ("id") ON DELETE CASCADE
+);
+
+CREATE TABLE trainer_time_offs (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  trainer_id uuid NOT NULL,
+  start_date DATE NOT NULL,
+  end_date DATE NOT NULL,
+  reason TEXT,
+  created_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP,
+  CHECK (start_date <= end_date),
+  FOREIGN KEY ("trainer_id") REFERENCES "trainers" ("id") ON DELETE CASCADE
+);
+
+CREATE TABLE trainer_rates (
+  trainer_id uuid PRIMARY KEY,
+  rate_per_session NUMERIC(12, 2) NOT NULL CHECK (rate_per_session >= 0),
+  updated_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY ("trainer_id") REFERENCES "trainers" ("id") ON DELETE CASCADE
 );
 
 CREATE TABLE participants (
@@ -51,29 +81,104 @@ CREATE TABLE participants (
   FOREIGN KEY ("user_id") REFERENCES "users" ("id")
 );
 
+CREATE TABLE cohorts (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  name VARCHAR(100) NOT NULL UNIQUE,
+  track participant_type,
+  -- NULL means unlimited, enrollments beyond it go onto the waitlist
+  capacity INT CHECK (capacity > 0),
+  created_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE cohort_enrollments (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  cohort_id uuid NOT NULL,
+  participant_id uuid NOT NULL,
+  enrolled_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE ("cohort_id", "participant_id"),
+  FOREIGN KEY ("cohort_id") REFERENCES "cohorts" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("participant_id") REFERENCES "participants" ("id") ON DELETE CASCADE
+);
+
+-- the lowest position is promoted first when a seat frees up
+CREATE TABLE cohort_waitlist (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  cohort_id uuid NOT NULL,
+  participant_id uuid NOT NULL,
+  position INT NOT NULL,
+  joined_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE ("cohort_id", "participant_id"),
+  UNIQUE ("cohort_id", "position"),
+  FOREIGN KEY ("cohort_id") REFERENCES "cohorts" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("participant_id") REFERENCES "participants" ("id") ON DELETE CASCADE
+);
+
+-- curriculum: a course is split into ordered modules, each made of ordered lessons
+CREATE TABLE courses (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  title VARCHAR(150) NOT NULL UNIQUE,
+  description TEXT,
+  track participant_type,
+  created_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP(0),
+  updated_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP(0)
+);
+
+CREATE TABLE course_modules (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  course_id uuid NOT NULL,
+  title VARCHAR(150) NOT NULL,
+  description TEXT,
+  position INT NOT NULL CHECK (position > 0),
+  created_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP(0),
+  updated_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP(0),
+  UNIQUE ("course_id", "position") DEFERRABLE INITIALLY DEFERRED,
+  FOREIGN KEY ("course_id") REFERENCES "courses" ("id") ON DELETE CASCADE
+);
+
+CREATE TABLE lessons (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  module_id uuid NOT NULL,
+  title VARCHAR(150) NOT NULL,
+  objectives TEXT[] NOT NULL DEFAULT '{}',
+  duration_minutes INT NOT NULL CHECK (duration_minutes > 0),
+  position INT NOT NULL CHECK (position > 0),
+  created_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP(0),
+  updated_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP(0),
+  UNIQUE ("module_id", "position") DEFERRABLE INITIALLY DEFERRED,
+  FOREIGN KEY ("module_id") REFERENCES "course_modules" ("id") ON DELETE CASCADE
+);
+
+-- a schedule is one session: either a cohort's session or, for data created
+-- before cohorts existed, a single participant's row (see migrations/)
 CREATE TABLE schedules (
   id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
   activity VARCHAR(45),
-  date DATE DEFAULT CURRENT_DATE,
+  date DATE NOT NULL DEFAULT CURRENT_DATE,
   trainer_id uuid NOT NULL,
-  participant_id uuid NOT NULL,
-  created_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP,
-  updated_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP,
+  participant_id uuid,
+  cohort_id uuid,
+  lesson_id uuid,
+  created_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CHECK (participant_id IS NOT NULL OR cohort_id IS NOT NULL),
   FOREIGN KEY ("trainer_id") REFERENCES "trainers" ("id"),
-  FOREIGN KEY ("participant_id") REFERENCES "participants" ("id")
+  FOREIGN KEY ("participant_id") REFERENCES "participants" ("id"),
+  FOREIGN KEY ("cohort_id") REFERENCES "cohorts" ("id"),
+  FOREIGN KEY ("lesson_id") REFERENCES "lessons" ("id")
 );
 
 CREATE TABLE absences (
   id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
-  date DATE DEFAULT CURRENT_DATE,
+  date DATE NOT NULL DEFAULT CURRENT_DATE,
   information TEXT,
   absence_status absent_type,
   absence_time TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP,
   participant_id uuid NOT NULL,
   trainer_id uuid NOT NULL,
   schedule_id uuid NOT NULL,
-  created_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP,
-  updated_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP,
+  created_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP,
   FOREIGN KEY ("participant_id") REFERENCES "participants" ("id"),
   FOREIGN KEY ("trainer_id") REFERENCES "trainers" ("id"),
   FOREIGN KEY ("schedule_id") REFERENCES "schedules" ("id")
@@ -87,22 +192,365 @@ CREATE TABLE questions (
   participant_id uuid NOT NULL,
   trainer_id uuid NOT NULL,
   schedule_id uuid NOT NULL,
-  created_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP,
-  updated_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP,
+  is_anonymous BOOLEAN DEFAULT FALSE,
+  created_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP,
   FOREIGN KEY ("participant_id") REFERENCES "participants" ("id"),
   FOREIGN KEY ("trainer_id") REFERENCES "trainers" ("id"),
   FOREIGN KEY ("schedule_id") REFERENCES "schedules" ("id")
 );
 
+CREATE TABLE question_votes (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  question_id uuid NOT NULL,
+  participant_id uuid NOT NULL,
+  created_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE ("question_id", "participant_id"),
+  FOREIGN KEY ("question_id") REFERENCES "questions" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("participant_id") REFERENCES "participants" ("id")
+);
+
+CREATE TYPE attachment_type AS ENUM ('question', 'answer');
+
+CREATE TABLE question_attachments (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  question_id uuid NOT NULL,
+  kind attachment_type NOT NULL,
+  original_name VARCHAR(255),
+  storage_key VARCHAR(255) NOT NULL,
+  size_bytes BIGINT,
+  mime_type VARCHAR(100),
+  uploaded_by uuid NOT NULL,
+  created_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY ("question_id") REFERENCES "questions" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("uploaded_by") REFERENCES "users" ("id")
+);
+
+CREATE TABLE session_feedbacks (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  schedule_id uuid NOT NULL,
+  trainer_id uuid NOT NULL,
+  participant_id uuid NOT NULL,
+  rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+  comment TEXT,
+  created_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE ("schedule_id", "participant_id"),
+  FOREIGN KEY ("schedule_id") REFERENCES "schedules" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("trainer_id") REFERENCES "trainers" ("id"),
+  FOREIGN KEY ("participant_id") REFERENCES "participants" ("id")
+);
+
+CREATE TYPE review_status AS ENUM ('Pending', 'Approved', 'Rejected');
+
+CREATE TYPE verification_verdict AS ENUM ('match', 'mismatch', 'no_metadata');
+
 CREATE TABLE schedule_images (
   id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
   schedule_id uuid NOT NULL,
   file_name VARCHAR(100),
+  storage_key VARCHAR(255),
+  size_bytes BIGINT,
+  mime_type VARCHAR(100),
+  checksum CHAR(64),
+  source_checksum CHAR(64) UNIQUE,
+  thumbnail_key VARCHAR(255),
+  caption TEXT,
+  captured_at TIMESTAMPTZ(0),
+  gps_latitude DOUBLE PRECISION,
+  gps_longitude DOUBLE PRECISION,
+  verification verification_verdict DEFAULT 'no_metadata',
+  verification_note TEXT,
+  review_status review_status DEFAULT 'Pending',
+  review_reason TEXT,
+  reviewed_by uuid,
+  reviewed_at TIMESTAMPTZ(0),
+  created_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP(0),
+  updated_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP(0),
+  FOREIGN KEY ("schedule_id") REFERENCES "schedules" ("id"),
+  FOREIGN KEY ("reviewed_by") REFERENCES "users" ("id")
+);
+
+CREATE TYPE assessment_question_kind AS ENUM ('multiple_choice', 'short_answer');
+
+CREATE TYPE attempt_status AS ENUM ('Submitted', 'Graded');
+
+CREATE TABLE assessments (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  schedule_id uuid NOT NULL,
+  trainer_id uuid NOT NULL,
+  title VARCHAR(150) NOT NULL,
+  description TEXT,
+  opens_at TIMESTAMPTZ(0) NOT NULL,
+  closes_at TIMESTAMPTZ(0) NOT NULL CHECK (closes_at > opens_at),
   created_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP(0),
   updated_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP(0),
-  FOREIGN KEY ("schedule_id") REFERENCES "schedules" ("id")
+  FOREIGN KEY ("schedule_id") REFERENCES "schedules" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("trainer_id") REFERENCES "trainers" ("id")
+);
+
+CREATE TABLE assessment_questions (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  assessment_id uuid NOT NULL,
+  position INT NOT NULL,
+  kind assessment_question_kind NOT NULL,
+  prompt TEXT NOT NULL,
+  options TEXT[],
+  correct_option INT,
+  points NUMERIC(6, 2) NOT NULL DEFAULT 1 CHECK (points > 0),
+  UNIQUE ("assessment_id", "position"),
+  FOREIGN KEY ("assessment_id") REFERENCES "assessments" ("id") ON DELETE CASCADE
+);
+
+CREATE TABLE assessment_attempts (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  assessment_id uuid NOT NULL,
+  participant_id uuid NOT NULL,
+  status attempt_status DEFAULT 'Submitted',
+  score NUMERIC(8, 2),
+  max_score NUMERIC(8, 2) NOT NULL,
+  submitted_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP(0),
+  UNIQUE ("assessment_id", "participant_id"),
+  FOREIGN KEY ("assessment_id") REFERENCES "assessments" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("participant_id") REFERENCES "participants" ("id") ON DELETE CASCADE
+);
+
+-- points stay NULL until the answer is graded, automatically or by the trainer
+CREATE TABLE assessment_answers (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  attempt_id uuid NOT NULL,
+  question_id uuid NOT NULL,
+  selected_option INT,
+  answer_text TEXT,
+  points NUMERIC(6, 2),
+  feedback TEXT,
+  graded_by uuid,
+  graded_at TIMESTAMPTZ(0),
+  UNIQUE ("attempt_id", "question_id"),
+  FOREIGN KEY ("attempt_id") REFERENCES "assessment_attempts" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("question_id") REFERENCES "assessment_questions" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("graded_by") REFERENCES "users" ("id")
+);
+
+-- an assignment belongs to a single session or to a whole cohort
+CREATE TABLE assignments (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  schedule_id uuid,
+  cohort_id uuid,
+  trainer_id uuid NOT NULL,
+  title VARCHAR(150) NOT NULL,
+  instructions TEXT,
+  due_at TIMESTAMPTZ(0) NOT NULL,
+  created_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP(0),
+  updated_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP(0),
+  CHECK ((schedule_id IS NULL) <> (cohort_id IS NULL)),
+  FOREIGN KEY ("schedule_id") REFERENCES "schedules" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("cohort_id") REFERENCES "cohorts" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("trainer_id") REFERENCES "trainers" ("id")
+);
+
+CREATE TABLE assignment_rubric_criteria (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  assignment_id uuid NOT NULL,
+  position INT NOT NULL,
+  name VARCHAR(100) NOT NULL,
+  max_points NUMERIC(6, 2) NOT NULL CHECK (max_points > 0),
+  UNIQUE ("assignment_id", "position"),
+  FOREIGN KEY ("assignment_id") REFERENCES "assignments" ("id") ON DELETE CASCADE
 );
 
+CREATE TABLE assignment_submissions (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  assignment_id uuid NOT NULL,
+  participant_id uuid NOT NULL,
+  text_content TEXT,
+  file_name VARCHAR(255),
+  storage_key VARCHAR(255),
+  size_bytes BIGINT,
+  mime_type VARCHAR(100),
+  is_late BOOLEAN NOT NULL DEFAULT FALSE,
+  status attempt_status DEFAULT 'Submitted',
+  score NUMERIC(8, 2),
+  feedback TEXT,
+  graded_by uuid,
+  graded_at TIMESTAMPTZ(0),
+  submitted_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP(0),
+  UNIQUE ("assignment_id", "participant_id"),
+  FOREIGN KEY ("assignment_id") REFERENCES "assignments" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("participant_id") REFERENCES "participants" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("graded_by") REFERENCES "users" ("id")
+);
+
+CREATE TABLE submission_rubric_scores (
+  submission_id uuid NOT NULL,
+  criterion_id uuid NOT NULL,
+  points NUMERIC(6, 2) NOT NULL,
+  comment TEXT,
+  PRIMARY KEY ("submission_id", "criterion_id"),
+  FOREIGN KEY ("submission_id") REFERENCES "assignment_submissions" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("criterion_id") REFERENCES "assignment_rubric_criteria" ("id") ON DELETE CASCADE
+);
+
+CREATE TABLE promotion_criteria (
+  id INT DEFAULT 1 PRIMARY KEY CHECK (id = 1),
+  min_attendance_rate NUMERIC(5, 2) NOT NULL DEFAULT 80,
+  min_assessment_score NUMERIC(5, 2) NOT NULL DEFAULT 0,
+  min_sessions_completed INT NOT NULL DEFAULT 10,
+  updated_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP(0)
+);
+
+INSERT INTO promotion_criteria (id) VALUES (1);
+
+CREATE TABLE promotion_requests (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  participant_id uuid NOT NULL,
+  from_track participant_type NOT NULL,
+  to_track participant_type NOT NULL,
+  attendance_rate NUMERIC(5, 2) NOT NULL,
+  assessment_score NUMERIC(5, 2),
+  sessions_completed INT NOT NULL,
+  status review_status DEFAULT 'Pending',
+  reason TEXT,
+  reviewed_by uuid,
+  reviewed_at TIMESTAMPTZ(0),
+  created_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP(0),
+  FOREIGN KEY ("participant_id") REFERENCES "participants" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("reviewed_by") REFERENCES "users" ("id")
+);
+
+-- a participant waits in the approval queue at most once
+CREATE UNIQUE INDEX promotion_requests_pending ON promotion_requests (participant_id) WHERE status = 'Pending';
+
+CREATE TABLE participant_track_history (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  participant_id uuid NOT NULL,
+  from_track participant_type,
+  to_track participant_type NOT NULL,
+  source VARCHAR(20) NOT NULL,
+  promotion_id uuid,
+  changed_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP(0),
+  FOREIGN KEY ("participant_id") REFERENCES "participants" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("promotion_id") REFERENCES "promotion_requests" ("id")
+);
+
+-- names and dates are copied at issue time so a certificate keeps verifying the same way
+CREATE TABLE certificates (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  code VARCHAR(24) NOT NULL UNIQUE,
+  participant_id uuid NOT NULL,
+  cohort_id uuid NOT NULL,
+  participant_name VARCHAR(100) NOT NULL,
+  track participant_type,
+  cohort_name VARCHAR(100) NOT NULL,
+  trainer_names TEXT NOT NULL,
+  start_date DATE NOT NULL,
+  end_date DATE NOT NULL,
+  attendance_rate NUMERIC(5, 2) NOT NULL,
+  score NUMERIC(5, 2),
+  issued_by uuid,
+  issued_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP(0),
+  UNIQUE ("participant_id", "cohort_id"),
+  FOREIGN KEY ("participant_id") REFERENCES "participants" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("cohort_id") REFERENCES "cohorts" ("id"),
+  FOREIGN KEY ("issued_by") REFERENCES "users" ("id")
+);
+
+CREATE TYPE registration_status AS ENUM ('Unverified', 'Pending', 'Approved', 'Rejected');
+
+-- self-registered participants wait here; the users and participants rows are only created on approval
+CREATE TABLE registrations (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  name VARCHAR(100) NOT NULL,
+  email VARCHAR(100) NOT NULL,
+  username VARCHAR(100) NOT NULL,
+  address TEXT,
+  hash_password VARCHAR(100) NOT NULL,
+  date_of_birth DATE,
+  place_of_birth VARCHAR(100),
+  last_education VARCHAR(100),
+  status registration_status NOT NULL DEFAULT 'Unverified',
+  token_hash CHAR(64),
+  token_expires_at TIMESTAMPTZ(0),
+  verified_at TIMESTAMPTZ(0),
+  track participant_type,
+  reason TEXT,
+  user_id uuid,
+  reviewed_by uuid,
+  reviewed_at TIMESTAMPTZ(0),
+  created_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP(0),
+  updated_at TIMESTAMPTZ(0) NOT NULL DEFAULT CURRENT_TIMESTAMP(0),
+  FOREIGN KEY ("user_id") REFERENCES "users" ("id"),
+  FOREIGN KEY ("reviewed_by") REFERENCES "users" ("id")
+);
+
+CREATE UNIQUE INDEX registrations_open_email ON registrations (email) WHERE status IN ('Unverified', 'Pending');
+
+CREATE UNIQUE INDEX registrations_open_username ON registrations (username) WHERE status IN ('Unverified', 'Pending');
+
+CREATE UNIQUE INDEX registrations_token_hash ON registrations (token_hash);
+
+CREATE TYPE material_kind AS ENUM ('pdf', 'slides', 'link');
+
+-- a material hangs off one schedule or one lesson; each new file or link becomes its next version
+CREATE TABLE materials (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  schedule_id uuid,
+  lesson_id uuid,
+  title VARCHAR(150) NOT NULL,
+  description TEXT,
+  kind material_kind NOT NULL,
+  current_version INT NOT NULL DEFAULT 1,
+  uploaded_by uuid NOT NULL,
+  created_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP(0),
+  updated_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP(0),
+  CHECK ((schedule_id IS NULL) <> (lesson_id IS NULL)),
+  FOREIGN KEY ("schedule_id") REFERENCES "schedules" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("lesson_id") REFERENCES "lessons" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("uploaded_by") REFERENCES "users" ("id")
+);
+
+CREATE TABLE material_versions (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  material_id uuid NOT NULL,
+  version INT NOT NULL,
+  kind material_kind NOT NULL,
+  file_name VARCHAR(255),
+  storage_key VARCHAR(255),
+  size_bytes BIGINT,
+  mime_type VARCHAR(100),
+  checksum CHAR(64),
+  url TEXT,
+  note TEXT,
+  uploaded_by uuid NOT NULL,
+  created_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP(0),
+  UNIQUE ("material_id", "version"),
+  CHECK ((storage_key IS NULL) <> (url IS NULL)),
+  FOREIGN KEY ("material_id") REFERENCES "materials" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("uploaded_by") REFERENCES "users" ("id")
+);
+
+CREATE TABLE material_downloads (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  material_id uuid NOT NULL,
+  version_id uuid NOT NULL,
+  user_id uuid NOT NULL,
+  downloaded_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP(0),
+  FOREIGN KEY ("material_id") REFERENCES "materials" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("version_id") REFERENCES "material_versions" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+-- the default order of the list endpoints, newest first with the id breaking ties
+CREATE INDEX users_created_at_id_idx ON users (created_at DESC, id DESC);
+CREATE INDEX trainers_created_at_id_idx ON trainers (created_at DESC, id DESC);
+CREATE INDEX schedules_created_at_id_idx ON schedules (created_at DESC, id DESC);
+CREATE INDEX absences_created_at_id_idx ON absences (created_at DESC, id DESC);
+CREATE INDEX questions_created_at_id_idx ON questions (created_at DESC, id DESC);
+CREATE INDEX session_feedbacks_trainer_created_at_id_idx ON session_feedbacks (trainer_id, created_at DESC, id DESC);
+CREATE INDEX registrations_created_at_id_idx ON registrations (created_at, id);
+CREATE INDEX promotion_requests_created_at_id_idx ON promotion_requests (created_at DESC, id DESC);
+CREATE INDEX cohorts_created_at_id_idx ON cohorts (created_at DESC, id DESC);
+CREATE INDEX schedule_images_created_at_id_idx ON schedule_images (created_at DESC, id DESC);
+
 INSERT INTO
   users(name, email, username, address, hash_password, role)
 VALUES
@@ -0,0 +1,13 @@
+-- Moves question attachments onto the file storage. file_name held the path under the working
+-- directory, e.g. "assets/attachments/...", which is the key of the same file for the local
+-- driver rooted at "assets". With the s3 driver, copy assets/attachments into the bucket first.
+--
+-- Run once with: psql -d instructor_led_db -f assets/migrations/028_question_attachment_storage.sql
+
+BEGIN;
+
+ALTER TABLE question_attachments RENAME COLUMN file_name TO storage_key;
+UPDATE question_attachments SET storage_key = substr(storage_key, length('assets/') + 1) WHERE storage_key LIKE 'assets/%';
+ALTER TABLE question_attachments ADD COLUMN IF NOT EXISTS size_bytes BIGINT, ADD COLUMN IF NOT EXISTS mime_type VARCHAR(100);
+
+COMMIT;
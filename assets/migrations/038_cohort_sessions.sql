@@ -0,0 +1,108 @@
+-- Moves an existing database from one schedule row per participant to one
+-- schedule row per session that belongs to a cohort.
+--
+-- Every (trainer, activity) pair becomes a legacy cohort whose enrollments are
+-- the participants that had rows for it. Rows sharing date, trainer and
+-- activity are collapsed into the oldest one, which becomes the cohort's
+-- session; attendance, questions, images and feedback are moved onto it.
+--
+-- Run once with: psql -d instructor_led_db -f assets/migrations/038_cohort_sessions.sql
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS cohorts (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  name VARCHAR(100) NOT NULL UNIQUE,
+  track participant_type,
+  created_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS cohort_enrollments (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  cohort_id uuid NOT NULL,
+  participant_id uuid NOT NULL,
+  enrolled_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE ("cohort_id", "participant_id"),
+  FOREIGN KEY ("cohort_id") REFERENCES "cohorts" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("participant_id") REFERENCES "participants" ("id") ON DELETE CASCADE
+);
+
+ALTER TABLE schedules ALTER COLUMN participant_id DROP NOT NULL;
+ALTER TABLE schedules ADD COLUMN IF NOT EXISTS cohort_id uuid REFERENCES cohorts (id);
+
+-- 1. one legacy cohort per trainer and activity
+CREATE TEMP TABLE legacy_cohorts AS
+SELECT d.trainer_id, d.activity, uuid_generate_v4() AS cohort_id
+FROM (SELECT DISTINCT trainer_id, activity FROM schedules WHERE cohort_id IS NULL) d;
+
+INSERT INTO cohorts (id, name)
+SELECT l.cohort_id, LEFT(COALESCE(l.activity, 'Session') || ' - ' || u.name, 88) || ' (' || LEFT(l.trainer_id::text, 8) || ')'
+FROM legacy_cohorts l
+JOIN trainers t ON t.id = l.trainer_id
+JOIN users u ON u.id = t.user_id;
+
+-- 2. enroll the participants of each legacy cohort
+INSERT INTO cohort_enrollments (cohort_id, participant_id)
+SELECT DISTINCT l.cohort_id, s.participant_id
+FROM schedules s
+JOIN legacy_cohorts l ON l.trainer_id = s.trainer_id AND l.activity IS NOT DISTINCT FROM s.activity
+WHERE s.cohort_id IS NULL AND s.participant_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+-- the track is only known when every participant of the cohort shares it
+UPDATE cohorts c
+SET track = x.track
+FROM (
+  SELECT e.cohort_id, MIN(p.role::text)::participant_type AS track
+  FROM cohort_enrollments e
+  JOIN participants p ON p.id = e.participant_id
+  JOIN legacy_cohorts l ON l.cohort_id = e.cohort_id
+  GROUP BY e.cohort_id
+  HAVING COUNT(DISTINCT p.role) = 1
+) x
+WHERE c.id = x.cohort_id;
+
+-- 3. pick the row each session collapses into
+CREATE TEMP TABLE session_map AS
+SELECT
+  s.id AS schedule_id,
+  first_value(s.id) OVER (PARTITION BY s.date, s.trainer_id, s.activity ORDER BY s.created_at, s.id) AS session_id,
+  l.cohort_id
+FROM schedules s
+JOIN legacy_cohorts l ON l.trainer_id = s.trainer_id AND l.activity IS NOT DISTINCT FROM s.activity
+WHERE s.cohort_id IS NULL;
+
+-- 4. move everything that hangs off a schedule onto its session
+UPDATE absences a SET schedule_id = m.session_id
+FROM session_map m WHERE a.schedule_id = m.schedule_id AND m.schedule_id <> m.session_id;
+
+UPDATE questions q SET schedule_id = m.session_id
+FROM session_map m WHERE q.schedule_id = m.schedule_id AND m.schedule_id <> m.session_id;
+
+UPDATE schedule_images i SET schedule_id = m.session_id
+FROM session_map m WHERE i.schedule_id = m.schedule_id AND m.schedule_id <> m.session_id;
+
+-- a participant keeps only their first feedback per session
+DELETE FROM session_feedbacks f
+USING (
+  SELECT k.id, row_number() OVER (PARTITION BY m.session_id, k.participant_id ORDER BY k.created_at, k.id) AS rn
+  FROM session_feedbacks k
+  JOIN session_map m ON m.schedule_id = k.schedule_id
+) d
+WHERE f.id = d.id AND d.rn > 1;
+
+UPDATE session_feedbacks f SET schedule_id = m.session_id
+FROM session_map m WHERE f.schedule_id = m.schedule_id AND m.schedule_id <> m.session_id;
+
+-- 5. the kept rows become cohort sessions, the rest go away
+UPDATE schedules s SET cohort_id = m.cohort_id, participant_id = NULL
+FROM session_map m WHERE s.id = m.session_id;
+
+DELETE FROM schedules s
+USING session_map m
+WHERE s.id = m.schedule_id AND m.schedule_id <> m.session_id;
+
+ALTER TABLE schedules ADD CONSTRAINT schedules_participant_or_cohort CHECK (participant_id IS NOT NULL OR cohort_id IS NOT NULL);
+
+COMMIT;
@@ -0,0 +1,44 @@
+-- Adds the curriculum tables and lets a schedule point to the lesson it delivers.
+-- Existing schedules keep their free-form activity and have no lesson until one is assigned.
+--
+-- Run once with: psql -d instructor_led_db -f assets/migrations/044_curriculum.sql
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS courses (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  title VARCHAR(150) NOT NULL UNIQUE,
+  description TEXT,
+  track participant_type,
+  created_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP(0),
+  updated_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP(0)
+);
+
+CREATE TABLE IF NOT EXISTS course_modules (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  course_id uuid NOT NULL,
+  title VARCHAR(150) NOT NULL,
+  description TEXT,
+  position INT NOT NULL CHECK (position > 0),
+  created_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP(0),
+  updated_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP(0),
+  UNIQUE ("course_id", "position") DEFERRABLE INITIALLY DEFERRED,
+  FOREIGN KEY ("course_id") REFERENCES "courses" ("id") ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS lessons (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  module_id uuid NOT NULL,
+  title VARCHAR(150) NOT NULL,
+  objectives TEXT[] NOT NULL DEFAULT '{}',
+  duration_minutes INT NOT NULL CHECK (duration_minutes > 0),
+  position INT NOT NULL CHECK (position > 0),
+  created_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP(0),
+  updated_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP(0),
+  UNIQUE ("module_id", "position") DEFERRABLE INITIALLY DEFERRED,
+  FOREIGN KEY ("module_id") REFERENCES "course_modules" ("id") ON DELETE CASCADE
+);
+
+ALTER TABLE schedules ADD COLUMN IF NOT EXISTS lesson_id uuid REFERENCES lessons (id);
+
+COMMIT;
@@ -0,0 +1,22 @@
+-- Gives cohorts an optional capacity and adds the waitlist that enrollments beyond it go onto.
+-- Existing cohorts have no capacity, so nobody already enrolled is moved.
+--
+-- Run once with: psql -d instructor_led_db -f assets/migrations/046_cohort_waitlist.sql
+
+BEGIN;
+
+ALTER TABLE cohorts ADD COLUMN IF NOT EXISTS capacity INT CHECK (capacity > 0);
+
+CREATE TABLE IF NOT EXISTS cohort_waitlist (
+  id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+  cohort_id uuid NOT NULL,
+  participant_id uuid NOT NULL,
+  position INT NOT NULL,
+  joined_at TIMESTAMPTZ(0) DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE ("cohort_id", "participant_id"),
+  UNIQUE ("cohort_id", "position"),
+  FOREIGN KEY ("cohort_id") REFERENCES "cohorts" ("id") ON DELETE CASCADE,
+  FOREIGN KEY ("participant_id") REFERENCES "participants" ("id") ON DELETE CASCADE
+);
+
+COMMIT;
@@ -0,0 +1,61 @@
+-- Indexes the default order of the list endpoints, newest first with the id breaking ties, so
+-- both offset pages and cursor pages read an index range instead of sorting the whole table.
+-- The columns the lists sort on become NOT NULL: a cursor can't point past a null, so rows
+-- missing a timestamp or date are backfilled from the closest value they do have.
+--
+-- Run once with: psql -d instructor_led_db -f assets/migrations/050_list_keyset_indexes.sql
+
+BEGIN;
+
+UPDATE users SET created_at = COALESCE(updated_at, CURRENT_TIMESTAMP) WHERE created_at IS NULL;
+UPDATE users SET updated_at = created_at WHERE updated_at IS NULL;
+ALTER TABLE users ALTER COLUMN created_at SET NOT NULL, ALTER COLUMN updated_at SET NOT NULL;
+
+UPDATE trainers SET created_at = COALESCE(updated_at, CURRENT_TIMESTAMP) WHERE created_at IS NULL;
+UPDATE trainers SET updated_at = created_at WHERE updated_at IS NULL;
+ALTER TABLE trainers ALTER COLUMN created_at SET NOT NULL, ALTER COLUMN updated_at SET NOT NULL;
+
+UPDATE schedules SET created_at = COALESCE(updated_at, CURRENT_TIMESTAMP) WHERE created_at IS NULL;
+UPDATE schedules SET updated_at = created_at WHERE updated_at IS NULL;
+UPDATE schedules SET date = created_at::date WHERE date IS NULL;
+ALTER TABLE schedules ALTER COLUMN created_at SET NOT NULL, ALTER COLUMN updated_at SET NOT NULL, ALTER COLUMN date SET NOT NULL;
+
+UPDATE absences SET created_at = COALESCE(updated_at, absence_time, CURRENT_TIMESTAMP) WHERE created_at IS NULL;
+UPDATE absences SET updated_at = created_at WHERE updated_at IS NULL;
+UPDATE absences SET date = COALESCE(absence_time, created_at)::date WHERE date IS NULL;
+ALTER TABLE absences ALTER COLUMN created_at SET NOT NULL, ALTER COLUMN updated_at SET NOT NULL, ALTER COLUMN date SET NOT NULL;
+
+UPDATE questions SET created_at = COALESCE(updated_at, CURRENT_TIMESTAMP) WHERE created_at IS NULL;
+UPDATE questions SET updated_at = created_at WHERE updated_at IS NULL;
+ALTER TABLE questions ALTER COLUMN created_at SET NOT NULL, ALTER COLUMN updated_at SET NOT NULL;
+
+UPDATE session_feedbacks SET created_at = CURRENT_TIMESTAMP WHERE created_at IS NULL;
+ALTER TABLE session_feedbacks ALTER COLUMN created_at SET NOT NULL;
+
+UPDATE registrations SET created_at = COALESCE(updated_at, verified_at, CURRENT_TIMESTAMP) WHERE created_at IS NULL;
+UPDATE registrations SET updated_at = created_at WHERE updated_at IS NULL;
+ALTER TABLE registrations ALTER COLUMN created_at SET NOT NULL, ALTER COLUMN updated_at SET NOT NULL;
+
+UPDATE promotion_requests SET created_at = COALESCE(reviewed_at, CURRENT_TIMESTAMP) WHERE created_at IS NULL;
+ALTER TABLE promotion_requests ALTER COLUMN created_at SET NOT NULL;
+
+UPDATE cohorts SET created_at = COALESCE(updated_at, CURRENT_TIMESTAMP) WHERE created_at IS NULL;
+UPDATE cohorts SET updated_at = created_at WHERE updated_at IS NULL;
+ALTER TABLE cohorts ALTER COLUMN created_at SET NOT NULL, ALTER COLUMN updated_at SET NOT NULL;
+
+UPDATE schedule_images SET created_at = COALESCE(updated_at, CURRENT_TIMESTAMP) WHERE created_at IS NULL;
+UPDATE schedule_images SET updated_at = created_at WHERE updated_at IS NULL;
+ALTER TABLE schedule_images ALTER COLUMN created_at SET NOT NULL, ALTER COLUMN updated_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS users_created_at_id_idx ON users (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS trainers_created_at_id_idx ON trainers (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS schedules_created_at_id_idx ON schedules (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS absences_created_at_id_idx ON absences (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS questions_created_at_id_idx ON questions (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS session_feedbacks_trainer_created_at_id_idx ON session_feedbacks (trainer_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS registrations_created_at_id_idx ON registrations (created_at, id);
+CREATE INDEX IF NOT EXISTS promotion_requests_created_at_id_idx ON promotion_requests (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS cohorts_created_at_id_idx ON cohorts (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS schedule_images_created_at_id_idx ON schedule_images (created_at DESC, id DESC);
+
+COMMIT;
@@ -0,0 +1,42 @@
+// Command openapi prints the OpenAPI spec of the API, or writes it to -out. It exits with an error
+// when a registered route isn't documented or a documented route isn't registered, so CI can
+// keep the spec and the controllers in sync.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"instructor-led-app/delivery"
+	"instructor-led-app/delivery/docs"
+	"log"
+	"os"
+
+	"github.com/gin-gonic/gin"
+)
+
+func main() {
+	out := flag.String("out", "", "file to write the spec to, stdout when empty")
+	check := flag.Bool("check", false, "only check that the spec matches the routes")
+	flag.Parse()
+
+	gin.SetMode(gin.ReleaseMode)
+	doc, err := docs.Build(delivery.Routes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *check {
+		return
+	}
+
+	spec, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *out == "" {
+		os.Stdout.Write(append(spec, '\n'))
+		return
+	}
+	if err := os.WriteFile(*out, append(spec, '\n'), 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -6,6 +6,10 @@ const (
 	AdminGroup              = "/admin"
 	TrainerGroup            = "/trainers"
 	ParticipantsGroup       = "/participants"
+	AuthLogin               = "/auth/login"
+	OpenAPISpec             = "/openapi.json"
+	APIDocs                 = "/docs"
+	APIDocsAsset            = "/docs/assets/:name"
 	QuestionGetList         = "/questions"
 	QuestionGetById         = "/questions/:id"
 	QuestionPost            = "/questions"
@@ -13,6 +17,11 @@ const (
 	QuestionTrainer         = "/questions/trainer"
 	QuestionGetByTrainerId  = "/questions/trainer/"
 	UpdateQuestionByTrainer = "/questions/trainer"
+	QuestionVote            = "/questions/:id/vote"
+	QuestionBySchedule      = "/questions/schedule/:id"
+	QuestionAttachments     = "/questions/:id/attachments"
+	AnswerAttachments       = "/questions/:id/answer/attachments"
+	AttachmentDownload      = "/questions/attachments/:id/download"
 
 	MasterDataUsers              = "/master-data/users"
 	MasterDataUsersCsv           = "/master-data/users/csv"
@@ -25,16 +34,102 @@ const (
 	MasterDataParticipantsRole   = "/master-data/participants/role"
 	MasterDataParticipantByID    = "/master-data/participants/:id"
 
+	Specializations    = "/specializations"
+	SpecializationByID = "/specializations/:id"
+	TrainerSuggestions = "/trainer-suggestions"
+
+	Availability      = "/availability"
+	AvailabilityByID  = "/availability/:id"
+	TimeOff           = "/time-off"
+	TimeOffByID       = "/time-off/:id"
+	AutoAssign        = "/auto-assign"
+	AutoAssignPreview = "/auto-assign/preview"
+
+	ReportTrainerWorkload    = "/reports/trainer-workload"
+	ReportTrainerWorkloadCsv = "/reports/trainer-workload/csv"
+	TrainerRates             = "/trainer-rates"
+	TrainerRateByTrainerID   = "/trainer-rates/:id"
+	ReportTrainerRatings     = "/reports/trainer-ratings"
+
+	Feedback = "/feedback"
+
+	Cohorts           = "/cohorts"
+	CohortByID        = "/cohorts/:id"
+	CohortEnrollments = "/cohorts/:id/enrollments"
+	CohortEnrollment  = "/cohorts/:id/enrollments/:participantId"
+	CohortWaitlist    = "/cohorts/:id/waitlist"
+	CohortWithdrawal  = "/cohorts/:id/enrollment"
+
+	PromotionCriteria   = "/promotion-criteria"
+	ParticipantProgress = "/progress"
+	ProgressReport      = "/participant-progress"
+	Promotions          = "/promotions"
+	PromotionEvaluate   = "/promotions/evaluate"
+	PromotionReview     = "/promotions/:id/review"
+	TrackHistory        = "/participants/:id/track-history"
+
+	Assessments        = "/assessments"
+	AssessmentByID     = "/assessments/:id"
+	AssessmentAttempts = "/assessments/:id/attempts"
+	AttemptByID        = "/assessment-attempts/:id"
+	AttemptAnswerGrade = "/assessment-attempts/:id/answers/:answerId/grade"
+	AssessmentResults  = "/assessment-results"
+
+	Assignments           = "/assignments"
+	AssignmentByID        = "/assignments/:id"
+	AssignmentSubmissions = "/assignments/:id/submissions"
+	SubmissionByID        = "/assignment-submissions/:id"
+	SubmissionFile        = "/assignment-submissions/:id/file"
+	SubmissionGrade       = "/assignment-submissions/:id/grade"
+	CohortGradebook       = "/cohorts/:id/gradebook"
+
+	CohortCertificates  = "/cohorts/:id/certificates"
+	CertificateEligible = "/cohorts/:id/certificates/eligibility"
+	Certificates        = "/certificates"
+	CertificatePdf      = "/certificates/:id/pdf"
+	CertificateVerify   = "/certificates/verify/:code"
+
+	Registrations      = "/registrations"
+	RegistrationVerify = "/registrations/verify"
+	RegistrationResend = "/registrations/resend-verification"
+	RegistrationReview = "/registrations/:id/review"
+
+	Courses               = "/courses"
+	CourseByID            = "/courses/:id"
+	CourseModules         = "/courses/:id/modules"
+	CourseModuleOrder     = "/courses/:id/modules/order"
+	CourseModuleByID      = "/course-modules/:id"
+	ModuleLessons         = "/course-modules/:id/lessons"
+	LessonOrder           = "/course-modules/:id/lessons/order"
+	LessonByID            = "/lessons/:id"
+	ScheduleLesson        = "/schedule/:id/lesson"
+	Curriculum            = "/curriculum"
+	ParticipantCurriculum = "/participants/:id/curriculum"
+	Materials             = "/materials"
+	MaterialByID          = "/materials/:id"
+	MaterialVersions      = "/materials/:id/versions"
+	MaterialDownload      = "/materials/:id/download"
+	MaterialDownloads     = "/materials/:id/downloads"
+
 	AbsenceByTrainerScheduleId  = "/absence/trainer/"
 	AbsenceParticipantByTrainer = "/absence/trainer/"
 
 	UploadActivityProof = "/upload-activity-proof"
 
+	//activity proof review
+	ActivityProofs         = "/activity-proofs"
+	ActivityProofByID      = "/activity-proofs/:id"
+	ActivityProofReview    = "/activity-proofs/:id/review"
+	ActivityProofImage     = "/activity-proofs/:id/image"
+	ActivityProofThumbnail = "/activity-proofs/:id/thumbnail"
+	ActivityProofMissing   = "/activity-proofs/missing"
+
 	//schedule
 	SchedulePost   = "/schedule/"
 	ScheduleList   = "/schedule/"
 	ScheduleById   = "/schedule/:id"
-	deleteSchedule = "/schedule/:date"
+	ScheduleEvents = "/schedule/:id/events"
+	ScheduleByDate = "/schedule/:date"
 
 	//fitur participants
 	ScheduleByParticipantId = "/partisipant/schedule"
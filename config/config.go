@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -30,10 +31,73 @@ type TokenConfig struct {
 	JwtExpiresTime   time.Duration
 }
 
+type StorageConfig struct {
+	StorageDriver string
+	LocalDir      string
+	S3Endpoint    string
+	S3Region      string
+	S3Bucket      string
+	S3AccessKey   string
+	S3SecretKey   string
+}
+
+type SessionConfig struct {
+	SessionStartTime string
+	SessionEndTime   string
+	VenueLatitude    float64
+	VenueLongitude   float64
+	VenueRadius      float64
+	VenueConfigured  bool
+}
+
+// Window returns when the session held on date starts and ends, in server local time.
+func (s SessionConfig) Window(date time.Time) (time.Time, time.Time, error) {
+	start, err := time.Parse("15:04", s.SessionStartTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid SESSION_START_TIME: %v", err)
+	}
+	end, err := time.Parse("15:04", s.SessionEndTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid SESSION_END_TIME: %v", err)
+	}
+
+	y, m, d := date.Date()
+	return time.Date(y, m, d, start.Hour(), start.Minute(), 0, 0, time.Local),
+		time.Date(y, m, d, end.Hour(), end.Minute(), 0, 0, time.Local), nil
+}
+
+type HonorariumConfig struct {
+	SessionRate float64
+}
+
+// CertificateConfig gates certificate issuing; a zero MinScore skips the score check.
+type CertificateConfig struct {
+	MinAttendanceRate float64
+	MinScore          float64
+	VerifyBaseURL     string
+}
+
+type MailConfig struct {
+	MailDriver string
+	MailFrom   string
+}
+
+// RegistrationConfig sets where verification links point to and how long they stay valid.
+type RegistrationConfig struct {
+	VerifyURL string
+	TokenTTL  time.Duration
+}
+
 type Config struct {
 	DBConfig
 	ApiConfig
 	TokenConfig
+	StorageConfig
+	SessionConfig
+	HonorariumConfig
+	CertificateConfig
+	MailConfig
+	RegistrationConfig
 }
 
 func (c *Config) ConfigConfiguration() error {
@@ -61,6 +125,65 @@ func (c *Config) ConfigConfiguration() error {
 		JwtExpiresTime:   time.Duration(tokenExpire) * time.Minute,
 	}
 
+	c.StorageConfig = StorageConfig{
+		StorageDriver: os.Getenv("STORAGE_DRIVER"),
+		LocalDir:      os.Getenv("STORAGE_LOCAL_DIR"),
+		S3Endpoint:    os.Getenv("S3_ENDPOINT"),
+		S3Region:      os.Getenv("S3_REGION"),
+		S3Bucket:      os.Getenv("S3_BUCKET"),
+		S3AccessKey:   os.Getenv("S3_ACCESS_KEY"),
+		S3SecretKey:   os.Getenv("S3_SECRET_KEY"),
+	}
+
+	c.SessionConfig = SessionConfig{
+		SessionStartTime: os.Getenv("SESSION_START_TIME"),
+		SessionEndTime:   os.Getenv("SESSION_END_TIME"),
+	}
+	if c.SessionStartTime == "" || c.SessionEndTime == "" {
+		c.SessionStartTime, c.SessionEndTime = "19:30", "20:30"
+	}
+
+	latitude, latErr := strconv.ParseFloat(os.Getenv("VENUE_LATITUDE"), 64)
+	longitude, lonErr := strconv.ParseFloat(os.Getenv("VENUE_LONGITUDE"), 64)
+	if latErr == nil && lonErr == nil {
+		c.VenueLatitude, c.VenueLongitude, c.VenueConfigured = latitude, longitude, true
+	}
+	c.VenueRadius, _ = strconv.ParseFloat(os.Getenv("VENUE_RADIUS_METERS"), 64)
+	if c.VenueRadius <= 0 {
+		c.VenueRadius = 500
+	}
+
+	sessionRate, _ := strconv.ParseFloat(os.Getenv("HONORARIUM_SESSION_RATE"), 64)
+	c.HonorariumConfig = HonorariumConfig{SessionRate: sessionRate}
+
+	minAttendance, err := strconv.ParseFloat(os.Getenv("CERTIFICATE_MIN_ATTENDANCE_RATE"), 64)
+	if err != nil {
+		minAttendance = 80
+	}
+	minScore, _ := strconv.ParseFloat(os.Getenv("CERTIFICATE_MIN_SCORE"), 64)
+	c.CertificateConfig = CertificateConfig{
+		MinAttendanceRate: minAttendance,
+		MinScore:          minScore,
+		VerifyBaseURL:     strings.TrimSuffix(os.Getenv("CERTIFICATE_VERIFY_BASE_URL"), "/"),
+	}
+
+	c.MailConfig = MailConfig{
+		MailDriver: os.Getenv("MAIL_DRIVER"),
+		MailFrom:   os.Getenv("MAIL_FROM"),
+	}
+	if c.MailFrom == "" {
+		c.MailFrom = "no-reply@instructor-led.local"
+	}
+
+	tokenTTL, _ := strconv.Atoi(os.Getenv("REGISTRATION_TOKEN_TTL"))
+	if tokenTTL <= 0 {
+		tokenTTL = 24
+	}
+	c.RegistrationConfig = RegistrationConfig{
+		VerifyURL: os.Getenv("REGISTRATION_VERIFY_URL"),
+		TokenTTL:  time.Duration(tokenTTL) * time.Hour,
+	}
+
 	if c.Host == "" || c.Port == "" || c.User == "" || c.Name == "" || c.Driver == "" || c.ApiPort == "" ||
 		c.IssuerName == "" || c.JwtExpiresTime < 0 || len(c.JwtSignatureKey) == 0 {
 		return fmt.Errorf("missing required environment")
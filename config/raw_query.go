@@ -2,17 +2,666 @@ package config
 
 const (
 	InsertTrainer      = `INSERT INTO trainers (phone_number, user_id) VALUES ($1, $2) RETURNING id, created_at, updated_at`
-	ListTrainers       = `SELECT id, phone_number, user_id, created_at, updated_at FROM trainers ORDER BY created_at DESC LIMIT $1 OFFSET $2`
+	ListTrainers       = `SELECT id, phone_number, user_id, created_at, updated_at FROM trainers`
 	GetTrainerByID     = `SELECT id,phone_number,user_id, created_at, updated_at FROM trainers WHERE id= $1`
 	GetTrainerByUserID = `SELECT id,phone_number,user_id, created_at, updated_at FROM trainers WHERE user_id= $1`
 	DeleteTrainerByID  = `DELETE FROM trainers WHERE id = $1`
 	UpdateTrainerByID  = `UPDATE trainers
 	SET phone_number = $1, user_id = $2, updated_at = $3
 	WHERE id = $4 RETURNING id,phone_number, user_id`
+
+	// trainer availability
+	InsertAvailabilitySlot = `
+	INSERT INTO trainer_availabilities (trainer_id, day_of_week, start_time, end_time) VALUES ($1, $2, $3, $4)
+	RETURNING id, trainer_id, day_of_week, to_char(start_time, 'HH24:MI'), to_char(end_time, 'HH24:MI'), created_at`
+	ListAvailabilitySlots = `
+	SELECT id, trainer_id, day_of_week, to_char(start_time, 'HH24:MI'), to_char(end_time, 'HH24:MI'), created_at
+	FROM trainer_availabilities WHERE ($1 = '' OR trainer_id::text = $1) ORDER BY trainer_id, day_of_week, start_time`
+	GetAvailabilitySlot = `
+	SELECT id, trainer_id, day_of_week, to_char(start_time, 'HH24:MI'), to_char(end_time, 'HH24:MI'), created_at
+	FROM trainer_availabilities WHERE id = $1`
+	DeleteAvailabilitySlot = `DELETE FROM trainer_availabilities WHERE id = $1`
+	InsertTimeOff          = `
+	INSERT INTO trainer_time_offs (trainer_id, start_date, end_date, reason) VALUES ($1, $2, $3, $4)
+	RETURNING id, trainer_id, start_date, end_date, COALESCE(reason, ''), created_at`
+	ListTimeOffs = `
+	SELECT id, trainer_id, start_date, end_date, COALESCE(reason, ''), created_at
+	FROM trainer_time_offs WHERE ($1 = '' OR trainer_id::text = $1) AND end_date >= $2 ORDER BY start_date`
+	GetTimeOff = `
+	SELECT id, trainer_id, start_date, end_date, COALESCE(reason, ''), created_at
+	FROM trainer_time_offs WHERE id = $1`
+	DeleteTimeOff  = `DELETE FROM trainer_time_offs WHERE id = $1`
+	ListTrainerIDs = `SELECT id FROM trainers ORDER BY id`
+
+	// trainer workload report
+	TrainerWorkloadReport = `
+	SELECT
+		t.id,
+		u.name,
+		r.rate_per_session,
+		(SELECT COUNT(DISTINCT s.date) FROM schedules s
+			WHERE s.trainer_id = t.id AND s.date BETWEEN $1 AND $2),
+		(SELECT COUNT(DISTINCT s.date) FROM schedules s JOIN schedule_images i ON i.schedule_id = s.id
+			WHERE s.trainer_id = t.id AND s.date BETWEEN $1 AND $2 AND i.review_status = 'Approved'),
+		(SELECT COUNT(DISTINCT s.date) FROM schedules s JOIN absences a ON a.schedule_id = s.id
+			WHERE s.trainer_id = t.id AND s.date BETWEEN $1 AND $2 AND a.absence_status IS NOT NULL),
+		(SELECT COUNT(*) FROM schedules s JOIN absences a ON a.schedule_id = s.id
+			WHERE s.trainer_id = t.id AND s.date BETWEEN $1 AND $2 AND a.absence_status IS NOT NULL),
+		(SELECT COUNT(*) FROM schedules s JOIN questions q ON q.schedule_id = s.id
+			WHERE s.trainer_id = t.id AND s.date BETWEEN $1 AND $2 AND q.status = 'Finished')
+	FROM
+		trainers t
+		JOIN users u ON u.id = t.user_id
+		LEFT JOIN trainer_rates r ON r.trainer_id = t.id
+	WHERE
+		($3 = '' OR t.id::text = $3)
+	ORDER BY
+		u.name`
+	ListTrainerRates  = `SELECT r.trainer_id, u.name, r.rate_per_session, r.updated_at FROM trainer_rates r JOIN trainers t ON t.id = r.trainer_id JOIN users u ON u.id = t.user_id ORDER BY u.name`
+	UpsertTrainerRate = `
+	INSERT INTO trainer_rates (trainer_id, rate_per_session, updated_at) VALUES ($1, $2, $3)
+	ON CONFLICT (trainer_id) DO UPDATE SET rate_per_session = EXCLUDED.rate_per_session, updated_at = EXCLUDED.updated_at`
+	DeleteTrainerRate = `DELETE FROM trainer_rates WHERE trainer_id = $1`
+
+	// session feedback
+	InsertFeedback = `
+	INSERT INTO session_feedbacks (schedule_id, trainer_id, participant_id, rating, comment) VALUES ($1, $2, $3, $4, $5)
+	RETURNING id, schedule_id, trainer_id, participant_id, rating, COALESCE(comment, ''), created_at`
+	CountFeedbackByParticipant = `SELECT COUNT(*) FROM session_feedbacks WHERE schedule_id = $1 AND participant_id = $2`
+	ListTrainerFeedback        = `
+	SELECT
+		f.id, s.activity, s.date, f.rating, COALESCE(f.comment, ''), f.created_at
+	FROM
+		session_feedbacks f
+		JOIN schedules s ON s.id = f.schedule_id
+`
+	TrainerRatingSummary = `
+	SELECT
+		f.trainer_id, u.name, date_trunc($1, s.date::timestamp)::date AS period, COUNT(*), AVG(f.rating)::float8,
+		MIN(f.rating), MAX(f.rating)
+	FROM
+		session_feedbacks f
+		JOIN schedules s ON s.id = f.schedule_id
+		JOIN trainers t ON t.id = f.trainer_id
+		JOIN users u ON u.id = t.user_id
+	WHERE
+		s.date BETWEEN $2 AND $3
+		AND ($4 = '' OR f.trainer_id::text = $4)
+	GROUP BY
+		f.trainer_id, u.name, period
+	ORDER BY
+		u.name, period`
+
+	// assessments
+	InsertAssessment = `
+	INSERT INTO assessments (schedule_id, trainer_id, title, description, opens_at, closes_at)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id, created_at, updated_at`
+	InsertAssessmentQuestion = `
+	INSERT INTO assessment_questions (assessment_id, position, kind, prompt, options, correct_option, points)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	RETURNING id`
+	selectAssessment = `
+	SELECT
+		id, schedule_id, trainer_id, title, COALESCE(description, ''), opens_at, closes_at, created_at, updated_at
+	FROM
+		assessments`
+	ListAssessmentsBySchedule = selectAssessment + ` WHERE schedule_id = $1 ORDER BY opens_at`
+	GetAssessmentByID         = selectAssessment + ` WHERE id = $1`
+	ListAssessmentQuestions   = `
+	SELECT id, assessment_id, position, kind, prompt, options, correct_option, points
+	FROM assessment_questions
+	WHERE assessment_id = $1
+	ORDER BY position`
+	DeleteAssessmentByID      = `DELETE FROM assessments WHERE id = $1`
+	CountAttemptsByAssessment = `SELECT COUNT(*) FROM assessment_attempts WHERE assessment_id = $1`
+	CountAttemptByParticipant = `SELECT COUNT(*) FROM assessment_attempts WHERE assessment_id = $1 AND participant_id = $2`
+	InsertAssessmentAttempt   = `
+	INSERT INTO assessment_attempts (assessment_id, participant_id, status, score, max_score, submitted_at)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id`
+	InsertAssessmentAnswer = `
+	INSERT INTO assessment_answers (attempt_id, question_id, selected_option, answer_text, points, graded_at)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id`
+	selectAssessmentAttempt = `
+	SELECT
+		t.id, t.assessment_id, t.participant_id, u.name, t.status, t.score, t.max_score, t.submitted_at
+	FROM
+		assessment_attempts t
+		JOIN participants p ON p.id = t.participant_id
+		JOIN users u ON u.id = p.user_id`
+	ListAttemptsByAssessment  = selectAssessmentAttempt + ` WHERE t.assessment_id = $1 ORDER BY u.name`
+	ListAttemptsByParticipant = selectAssessmentAttempt + ` WHERE t.participant_id = $1 ORDER BY t.submitted_at DESC`
+	GetAttemptByID            = selectAssessmentAttempt + ` WHERE t.id = $1`
+	ListAttemptAnswers        = `
+	SELECT id, attempt_id, question_id, selected_option, COALESCE(answer_text, ''), points, COALESCE(feedback, ''), graded_at
+	FROM assessment_answers
+	WHERE attempt_id = $1`
+	GradeAssessmentAnswer = `
+	UPDATE assessment_answers SET points = $3, feedback = $4, graded_by = $5, graded_at = $6
+	WHERE id = $2 AND attempt_id = $1`
+	// an attempt is graded once none of its answers is waiting for points
+	RefreshAttemptScore = `
+	UPDATE assessment_attempts t
+	SET
+		score = (SELECT COALESCE(SUM(a.points), 0) FROM assessment_answers a WHERE a.attempt_id = t.id),
+		status = CASE WHEN EXISTS (SELECT 1 FROM assessment_answers a WHERE a.attempt_id = t.id AND a.points IS NULL)
+			THEN 'Submitted'::attempt_status ELSE 'Graded'::attempt_status END
+	WHERE t.id = $1`
+
+	// assignments
+	InsertAssignment = `
+	INSERT INTO assignments (schedule_id, cohort_id, trainer_id, title, instructions, due_at)
+	VALUES (NULLIF($1, '')::uuid, NULLIF($2, '')::uuid, $3, $4, $5, $6)
+	RETURNING id, created_at, updated_at`
+	InsertRubricCriterion = `
+	INSERT INTO assignment_rubric_criteria (assignment_id, position, name, max_points)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id`
+	selectAssignment = `
+	SELECT
+		a.id, COALESCE(a.schedule_id::text, ''), COALESCE(a.cohort_id::text, ''), a.trainer_id, a.title, COALESCE(a.instructions, ''),
+		a.due_at, a.created_at, a.updated_at
+	FROM
+		assignments a`
+	ListAssignments = selectAssignment + `
+	WHERE ($1 = '' OR a.schedule_id::text = $1) AND ($2 = '' OR a.cohort_id::text = $2)
+	ORDER BY a.due_at`
+	// cohort work includes the assignments given in the cohort's sessions
+	ListAssignmentsByCohort = selectAssignment + `
+	WHERE a.cohort_id = $1 OR a.schedule_id IN (SELECT id FROM schedules WHERE cohort_id = $1)
+	ORDER BY a.due_at`
+	ListAssignmentsByParticipant = selectAssignment + `
+	WHERE a.cohort_id IN (SELECT cohort_id FROM cohort_enrollments WHERE participant_id = $1)
+		OR a.schedule_id IN (
+			SELECT s.id FROM schedules s
+			WHERE s.participant_id = $1 OR s.cohort_id IN (SELECT cohort_id FROM cohort_enrollments WHERE participant_id = $1))
+	ORDER BY a.due_at`
+	GetAssignmentByID  = selectAssignment + ` WHERE a.id = $1`
+	ListRubricCriteria = `
+	SELECT id, position, name, max_points
+	FROM assignment_rubric_criteria
+	WHERE assignment_id = $1
+	ORDER BY position`
+	DeleteAssignmentByID = `DELETE FROM assignments WHERE id = $1`
+	CountSubmissions     = `SELECT COUNT(*) FROM assignment_submissions WHERE assignment_id = $1`
+	// resubmitting is allowed until the work is graded
+	UpsertSubmission = `
+	INSERT INTO assignment_submissions (assignment_id, participant_id, text_content, file_name, storage_key, size_bytes, mime_type, is_late, submitted_at)
+	VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, NULLIF($7, ''), $8, $9)
+	ON CONFLICT (assignment_id, participant_id) DO UPDATE SET
+		text_content = EXCLUDED.text_content, file_name = EXCLUDED.file_name, storage_key = EXCLUDED.storage_key,
+		size_bytes = EXCLUDED.size_bytes, mime_type = EXCLUDED.mime_type, is_late = EXCLUDED.is_late, submitted_at = EXCLUDED.submitted_at
+	WHERE assignment_submissions.status = 'Submitted'
+	RETURNING id`
+	selectSubmission = `
+	SELECT
+		m.id, m.assignment_id, m.participant_id, u.name, COALESCE(m.text_content, ''), COALESCE(m.file_name, ''), COALESCE(m.storage_key, ''),
+		COALESCE(m.size_bytes, 0), COALESCE(m.mime_type, ''), m.is_late, m.status, m.score, COALESCE(m.feedback, ''), m.graded_at, m.submitted_at
+	FROM
+		assignment_submissions m
+		JOIN participants p ON p.id = m.participant_id
+		JOIN users u ON u.id = p.user_id`
+	ListSubmissionsByAssignment = selectSubmission + ` WHERE m.assignment_id = $1 ORDER BY u.name`
+	ListSubmissionsByCohort     = selectSubmission + `
+	WHERE m.assignment_id IN (
+		SELECT a.id FROM assignments a
+		WHERE a.cohort_id = $1 OR a.schedule_id IN (SELECT id FROM schedules WHERE cohort_id = $1))`
+	GetSubmissionByID          = selectSubmission + ` WHERE m.id = $1`
+	GetSubmissionByParticipant = selectSubmission + ` WHERE m.assignment_id = $1 AND m.participant_id = $2`
+	ListSubmissionScores       = `SELECT criterion_id, points, COALESCE(comment, '') FROM submission_rubric_scores WHERE submission_id = $1`
+	UpsertSubmissionScore      = `
+	INSERT INTO submission_rubric_scores (submission_id, criterion_id, points, comment)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (submission_id, criterion_id) DO UPDATE SET points = EXCLUDED.points, comment = EXCLUDED.comment`
+	GradeSubmission = `
+	UPDATE assignment_submissions
+	SET status = 'Graded', score = $2, feedback = $3, graded_by = $4, graded_at = $5
+	WHERE id = $1`
+	CountTrainerCohortSessions = `SELECT COUNT(*) FROM schedules WHERE cohort_id = $1 AND trainer_id = $2`
+	CountCohortEnrollment      = `SELECT COUNT(*) FROM cohort_enrollments WHERE cohort_id = $1 AND participant_id = $2`
+
+	// certificates
+	CohortSessionSummary = `
+	SELECT
+		MIN(s.date), MAX(s.date), COALESCE(string_agg(DISTINCT u.name, ', '), '')
+	FROM
+		schedules s
+		JOIN trainers t ON t.id = s.trainer_id
+		JOIN users u ON u.id = t.user_id
+	WHERE
+		s.cohort_id = $1`
+	// the score averages graded assessments of the cohort's sessions and graded cohort assignments, as percentages
+	CertificateEligibility = `
+	SELECT
+		e.participant_id, u.name, COALESCE(p.role::text, ''),
+		COUNT(a.id) FILTER (WHERE a.absence_status = 'Present'),
+		COUNT(a.id) FILTER (WHERE a.absence_status IS NOT NULL),
+		(SELECT AVG(x.pct)::float8 FROM (
+			SELECT t.score / t.max_score * 100 AS pct
+			FROM assessment_attempts t
+				JOIN assessments q ON q.id = t.assessment_id
+				JOIN schedules sc ON sc.id = q.schedule_id
+			WHERE sc.cohort_id = $1 AND t.participant_id = e.participant_id AND t.status = 'Graded' AND t.max_score > 0
+			UNION ALL
+			SELECT m.score / r.total * 100
+			FROM assignment_submissions m
+				JOIN assignments g ON g.id = m.assignment_id
+				JOIN (SELECT assignment_id, SUM(max_points) AS total FROM assignment_rubric_criteria GROUP BY assignment_id) r ON r.assignment_id = g.id
+			WHERE (g.cohort_id = $1 OR g.schedule_id IN (SELECT id FROM schedules WHERE cohort_id = $1))
+				AND m.participant_id = e.participant_id AND m.status = 'Graded'
+		) x),
+		EXISTS (SELECT 1 FROM certificates c WHERE c.cohort_id = $1 AND c.participant_id = e.participant_id)
+	FROM
+		cohort_enrollments e
+		JOIN participants p ON p.id = e.participant_id
+		JOIN users u ON u.id = p.user_id
+		LEFT JOIN absences a ON a.participant_id = e.participant_id AND a.schedule_id IN (SELECT id FROM schedules WHERE cohort_id = $1)
+	WHERE
+		e.cohort_id = $1
+	GROUP BY
+		e.participant_id, u.name, p.role
+	ORDER BY
+		u.name`
+	InsertCertificate = `
+	INSERT INTO certificates (code, participant_id, cohort_id, participant_name, track, cohort_name, trainer_names, start_date, end_date, attendance_rate, score, issued_by)
+	VALUES ($1, $2, $3, $4, NULLIF($5, '')::participant_type, $6, $7, $8, $9, $10, $11, $12)
+	RETURNING id, issued_at`
+	selectCertificate = `
+	SELECT
+		id, code, participant_id, cohort_id, participant_name, COALESCE(track::text, ''), cohort_name, trainer_names,
+		start_date, end_date, attendance_rate, score, issued_at
+	FROM
+		certificates`
+	ListCertificates              = selectCertificate + ` WHERE ($1 = '' OR cohort_id::text = $1) ORDER BY issued_at DESC`
+	ListCertificatesByParticipant = selectCertificate + ` WHERE participant_id = $1 ORDER BY issued_at DESC`
+	GetCertificateByID            = selectCertificate + ` WHERE id = $1`
+	GetCertificateByCode          = selectCertificate + ` WHERE code = $1`
+
+	// registrations
+	// unverified registrations whose link expired no longer hold on to their email and username
+	DeleteExpiredRegistrations = `
+	DELETE FROM registrations
+	WHERE status = 'Unverified' AND token_expires_at < CURRENT_TIMESTAMP AND (email = $1 OR username = $2)`
+	RegistrationIdentityTaken = `
+	SELECT
+		EXISTS (SELECT 1 FROM users WHERE email = $1 OR username = $2)
+		OR EXISTS (SELECT 1 FROM registrations WHERE status IN ('Unverified', 'Pending') AND (email = $1 OR username = $2))`
+	InsertRegistration = `
+	INSERT INTO registrations (name, email, username, address, hash_password, date_of_birth, place_of_birth, last_education, token_hash, token_expires_at)
+	VALUES ($1, $2, $3, $4, $5, NULLIF($6, '')::date, $7, $8, $9, $10)
+	RETURNING id, status, created_at`
+	selectRegistration = `
+	SELECT
+		id, name, email, username, COALESCE(address, ''), COALESCE(to_char(date_of_birth, 'YYYY-MM-DD'), ''),
+		COALESCE(place_of_birth, ''), COALESCE(last_education, ''), status, verified_at, COALESCE(track::text, ''),
+		COALESCE(reason, ''), COALESCE(user_id::text, ''), COALESCE(reviewed_by::text, ''), reviewed_at, created_at
+	FROM
+		registrations`
+	ListRegistrations        = selectRegistration
+	GetRegistrationByID      = selectRegistration + ` WHERE id = $1`
+	GetOpenRegistration      = selectRegistration + ` WHERE email = $1 AND status = 'Unverified'`
+	RefreshRegistrationToken = `
+	UPDATE registrations SET token_hash = $2, token_expires_at = $3, updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1 AND status = 'Unverified'`
+	VerifyRegistration = `
+	UPDATE registrations
+	SET status = 'Pending', verified_at = CURRENT_TIMESTAMP, token_hash = NULL, token_expires_at = NULL, updated_at = CURRENT_TIMESTAMP
+	WHERE token_hash = $1 AND status = 'Unverified' AND token_expires_at > CURRENT_TIMESTAMP
+	RETURNING id`
+	DecideRegistration = `
+	UPDATE registrations
+	SET status = $2, track = NULLIF($3, '')::participant_type, reason = $4, reviewed_by = $5, reviewed_at = $6, updated_at = $6
+	WHERE id = $1 AND status = 'Pending'`
+	InsertRegisteredUser = `
+	INSERT INTO users (name, email, username, address, hash_password, role)
+	SELECT name, email, username, address, hash_password, 'participant' FROM registrations WHERE id = $1
+	RETURNING id`
+	InsertRegisteredParticipant = `
+	INSERT INTO participants (user_id, date_of_birth, place_of_birth, last_education, role)
+	SELECT $2::uuid, date_of_birth, place_of_birth, last_education, track FROM registrations WHERE id = $1
+	RETURNING id`
+	LinkRegistrationUser = `UPDATE registrations SET user_id = $2 WHERE id = $1`
+
+	// curriculum
+	InsertCourse = `
+	INSERT INTO courses (title, description, track) VALUES ($1, $2, NULLIF($3, '')::participant_type)
+	RETURNING id, created_at, updated_at`
+	selectCourse  = `SELECT id, title, COALESCE(description, ''), COALESCE(track::text, ''), created_at, updated_at FROM courses`
+	ListCourses   = selectCourse + ` WHERE ($1 = '' OR track::text = $1) ORDER BY title`
+	GetCourseByID = selectCourse + ` WHERE id = $1`
+	UpdateCourse  = `UPDATE courses SET title = $2, description = $3, track = NULLIF($4, '')::participant_type, updated_at = $5 WHERE id = $1`
+	DeleteCourse  = `DELETE FROM courses WHERE id = $1`
+	// new modules and lessons go to the end; positions stay 1..n so a reorder only has to permute them
+	InsertCourseModule = `
+	INSERT INTO course_modules (course_id, title, description, position)
+	SELECT $1, $2, $3, COALESCE(MAX(position), 0) + 1 FROM course_modules WHERE course_id = $1
+	RETURNING id, position, created_at, updated_at`
+	selectCourseModule   = `SELECT id, course_id, title, COALESCE(description, ''), position, created_at, updated_at FROM course_modules`
+	ListCourseModules    = selectCourseModule + ` WHERE course_id = $1 ORDER BY position`
+	GetCourseModuleByID  = selectCourseModule + ` WHERE id = $1`
+	UpdateCourseModule   = `UPDATE course_modules SET title = $2, description = $3, updated_at = $4 WHERE id = $1`
+	DeleteCourseModule   = `DELETE FROM course_modules WHERE id = $1 RETURNING course_id, position`
+	CloseCourseModuleGap = `UPDATE course_modules SET position = position - 1 WHERE course_id = $1 AND position > $2`
+	MoveCourseModule     = `UPDATE course_modules SET position = $3, updated_at = CURRENT_TIMESTAMP WHERE course_id = $1 AND id = $2`
+	InsertLesson         = `
+	INSERT INTO lessons (module_id, title, objectives, duration_minutes, position)
+	SELECT $1, $2, $3::text[], $4::int, COALESCE(MAX(position), 0) + 1 FROM lessons WHERE module_id = $1
+	RETURNING id, position, created_at, updated_at`
+	selectLesson = `
+	SELECT
+		l.id, l.module_id, l.title, l.objectives, l.duration_minutes, l.position, l.created_at, l.updated_at
+	FROM
+		lessons l`
+	ListLessonsByModule      = selectLesson + ` WHERE l.module_id = $1 ORDER BY l.position`
+	ListLessonsByCourse      = selectLesson + ` JOIN course_modules m ON m.id = l.module_id WHERE m.course_id = $1 ORDER BY m.position, l.position`
+	GetLessonByID            = selectLesson + ` WHERE l.id = $1`
+	UpdateLesson             = `UPDATE lessons SET title = $2, objectives = $3, duration_minutes = $4, updated_at = $5 WHERE id = $1`
+	DeleteLesson             = `DELETE FROM lessons WHERE id = $1 RETURNING module_id, position`
+	CloseLessonGap           = `UPDATE lessons SET position = position - 1 WHERE module_id = $1 AND position > $2`
+	MoveLesson               = `UPDATE lessons SET position = $3, updated_at = CURRENT_TIMESTAMP WHERE module_id = $1 AND id = $2`
+	CountCurriculumSchedules = `
+	SELECT
+		COUNT(*)
+	FROM
+		schedules s
+		JOIN lessons l ON l.id = s.lesson_id
+		JOIN course_modules m ON m.id = l.module_id
+	WHERE
+		($1 = '' OR m.course_id::text = $1) AND ($2 = '' OR m.id::text = $2) AND ($3 = '' OR l.id::text = $3)`
+	UpdateScheduleLesson = `UPDATE schedules SET lesson_id = NULLIF($2, '')::uuid, updated_at = $3 WHERE id = $1`
+	// a participant follows the courses of their track, courses without a track, and any course
+	// whose lessons are delivered in their sessions; a lesson is covered once they attended it
+	CurriculumCoverage = `
+	WITH sessions AS (
+		SELECT s.id, s.date, s.lesson_id
+		FROM schedules s
+		WHERE s.lesson_id IS NOT NULL
+			AND (s.participant_id = $1 OR s.cohort_id IN (SELECT cohort_id FROM cohort_enrollments WHERE participant_id = $1))
+	)
+	SELECT
+		c.id, c.title, m.id, m.title, m.position, l.id, l.title, l.position, l.duration_minutes,
+		(SELECT MAX(x.date) FROM sessions x JOIN absences a ON a.schedule_id = x.id
+			WHERE x.lesson_id = l.id AND a.participant_id = $1 AND a.absence_status = 'Present'),
+		(SELECT MIN(x.date) FROM sessions x WHERE x.lesson_id = l.id AND x.date >= CURRENT_DATE)
+	FROM
+		courses c
+		JOIN course_modules m ON m.course_id = c.id
+		JOIN lessons l ON l.module_id = m.id
+	WHERE
+		c.track IS NULL OR c.track::text = $2
+		OR c.id IN (SELECT m2.course_id FROM sessions x JOIN lessons l2 ON l2.id = x.lesson_id JOIN course_modules m2 ON m2.id = l2.module_id)
+	ORDER BY
+		c.title, m.position, l.position`
+
+	// materials
+	InsertMaterial = `
+	INSERT INTO materials (schedule_id, lesson_id, title, description, kind, uploaded_by)
+	VALUES (NULLIF($1, '')::uuid, NULLIF($2, '')::uuid, $3, $4, $5, $6)
+	RETURNING id, current_version, created_at, updated_at`
+	InsertMaterialVersion = `
+	INSERT INTO material_versions (material_id, version, kind, file_name, storage_key, size_bytes, mime_type, checksum, url, note, uploaded_by)
+	VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6::bigint, 0), NULLIF($7, ''), NULLIF($8, ''), NULLIF($9, ''), $10, $11)
+	RETURNING id, created_at`
+	BumpMaterialVersion = `
+	UPDATE materials SET current_version = current_version + 1, kind = $2, updated_at = $3 WHERE id = $1
+	RETURNING current_version`
+	// a material is listed with the file or link of its current version
+	materialColumns = `
+		m.id, COALESCE(m.schedule_id::text, ''), COALESCE(m.lesson_id::text, ''), m.title, COALESCE(m.description, ''), m.kind, m.current_version,
+		COALESCE(v.file_name, ''), COALESCE(v.size_bytes, 0), COALESCE(v.mime_type, ''), COALESCE(v.url, ''), m.uploaded_by,
+		(SELECT COUNT(*) FROM material_downloads d WHERE d.material_id = m.id), m.created_at, m.updated_at`
+	selectMaterial = `SELECT` + materialColumns + `
+	FROM
+		materials m
+		JOIN material_versions v ON v.material_id = m.id AND v.version = m.current_version`
+	ListMaterials         = selectMaterial + ` WHERE ($1 = '' OR m.schedule_id::text = $1) AND ($2 = '' OR m.lesson_id::text = $2) ORDER BY m.created_at`
+	GetMaterialByID       = selectMaterial + ` WHERE m.id = $1`
+	UpdateMaterial        = `UPDATE materials SET title = $2, description = $3, updated_at = $4 WHERE id = $1`
+	DeleteMaterial        = `DELETE FROM materials WHERE id = $1`
+	selectMaterialVersion = `
+	SELECT
+		id, material_id, version, kind, COALESCE(file_name, ''), COALESCE(storage_key, ''), COALESCE(size_bytes, 0), COALESCE(mime_type, ''),
+		COALESCE(checksum, ''), COALESCE(url, ''), COALESCE(note, ''), uploaded_by, created_at
+	FROM
+		material_versions`
+	ListMaterialVersions       = selectMaterialVersion + ` WHERE material_id = $1 ORDER BY version DESC`
+	GetMaterialVersion         = selectMaterialVersion + ` WHERE material_id = $1 AND version = $2`
+	InsertMaterialDownload     = `INSERT INTO material_downloads (material_id, version_id, user_id) VALUES ($1, $2, $3)`
+	MaterialDownloadsByVersion = `
+	SELECT
+		v.version, COUNT(d.id)
+	FROM
+		material_versions v
+		LEFT JOIN material_downloads d ON d.version_id = v.id
+	WHERE
+		v.material_id = $1
+	GROUP BY
+		v.version
+	ORDER BY
+		v.version DESC`
+	MaterialDownloadsByUser = `
+	SELECT
+		u.id, u.name, u.role, COUNT(*), MAX(d.downloaded_at)
+	FROM
+		material_downloads d
+		JOIN users u ON u.id = d.user_id
+	WHERE
+		d.material_id = $1
+	GROUP BY
+		u.id, u.name, u.role
+	ORDER BY
+		MAX(d.downloaded_at) DESC`
+	CountParticipantLessonSessions = `
+	SELECT COUNT(*) FROM schedules
+	WHERE lesson_id = $1 AND (participant_id = $2 OR cohort_id IN (SELECT cohort_id FROM cohort_enrollments WHERE participant_id = $2))`
+	// the materials of a session are its own plus those of the lesson it delivers
+	ListParticipantMaterials = `
+	WITH sessions AS (
+		SELECT s.id, COALESCE(s.activity, '') AS activity, s.date, s.lesson_id
+		FROM schedules s
+		WHERE (s.participant_id = $1 OR s.cohort_id IN (SELECT cohort_id FROM cohort_enrollments WHERE participant_id = $1))
+			AND ($2 = '' OR ($2 = 'upcoming' AND s.date >= CURRENT_DATE) OR ($2 = 'past' AND s.date < CURRENT_DATE))
+	)
+	SELECT
+		x.id, x.activity, x.date,` + materialColumns + `
+	FROM
+		sessions x
+		JOIN materials m ON m.schedule_id = x.id OR m.lesson_id = x.lesson_id
+		JOIN material_versions v ON v.material_id = m.id AND v.version = m.current_version
+	ORDER BY
+		x.date, x.id, m.created_at`
+
+	// promotions
+	GetPromotionCriteria    = `SELECT min_attendance_rate, min_assessment_score, min_sessions_completed, updated_at FROM promotion_criteria WHERE id = 1`
+	UpdatePromotionCriteria = `
+	UPDATE promotion_criteria
+	SET min_attendance_rate = $1, min_assessment_score = $2, min_sessions_completed = $3, updated_at = $4
+	WHERE id = 1
+	RETURNING min_attendance_rate, min_assessment_score, min_sessions_completed, updated_at`
+	// attended and recorded count only sessions whose attendance was taken
+	ListParticipantProgress = `
+	SELECT
+		p.id, u.name, COALESCE(p.role::text, ''),
+		COUNT(a.id) FILTER (WHERE a.absence_status = 'Present'),
+		COUNT(a.id) FILTER (WHERE a.absence_status IS NOT NULL),
+		(SELECT AVG(t.score / t.max_score * 100)::float8 FROM assessment_attempts t
+			WHERE t.participant_id = p.id AND t.status = 'Graded' AND t.max_score > 0)
+	FROM
+		participants p
+		JOIN users u ON u.id = p.user_id
+		LEFT JOIN absences a ON a.participant_id = p.id
+	WHERE
+		($1 = '' OR p.id::text = $1) AND ($2 = '' OR p.role::text = $2)
+	GROUP BY
+		p.id, u.name, p.role
+	ORDER BY
+		u.name`
+	// a rejected participant is only queued again after attending more sessions
+	CountOpenPromotion = `
+	SELECT COUNT(*) FROM promotion_requests
+	WHERE participant_id = $1 AND (status = 'Pending' OR (status = 'Rejected' AND sessions_completed >= $2))`
+	InsertPromotionRequest = `
+	INSERT INTO promotion_requests (participant_id, from_track, to_track, attendance_rate, assessment_score, sessions_completed)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id, status, created_at`
+	selectPromotionRequest = `
+	SELECT
+		r.id, r.participant_id, u.name, r.from_track, r.to_track, r.attendance_rate, r.assessment_score, r.sessions_completed,
+		r.status, COALESCE(r.reason, ''), COALESCE(r.reviewed_by::text, ''), r.reviewed_at, r.created_at
+	FROM
+		promotion_requests r
+		JOIN participants p ON p.id = r.participant_id
+		JOIN users u ON u.id = p.user_id`
+	ListPromotionRequests   = selectPromotionRequest
+	GetPromotionRequestByID = selectPromotionRequest + ` WHERE r.id = $1`
+	DecidePromotionRequest  = `
+	UPDATE promotion_requests SET status = $2, reason = $3, reviewed_by = $4, reviewed_at = $5
+	WHERE id = $1 AND status = 'Pending'
+	RETURNING participant_id, from_track, to_track`
+	InsertTrackHistory = `
+	INSERT INTO participant_track_history (participant_id, from_track, to_track, source, promotion_id)
+	VALUES ($1, $2, $3, $4, $5)`
+	ListTrackHistory = `
+	SELECT id, participant_id, COALESCE(from_track::text, ''), to_track, source, COALESCE(promotion_id::text, ''), changed_at
+	FROM participant_track_history
+	WHERE participant_id = $1
+	ORDER BY changed_at DESC`
+	// a manual role change is recorded only when the track actually changes
+	InsertManualTrackHistory = `
+	INSERT INTO participant_track_history (participant_id, from_track, to_track, source)
+	SELECT id, role, $2, 'manual' FROM participants WHERE id = $1 AND role IS DISTINCT FROM $2::participant_type`
+	DeleteUpcomingTrackAttendance = `
+	DELETE FROM absences a
+	USING schedules s, cohorts c
+	WHERE a.schedule_id = s.id AND s.cohort_id = c.id AND c.track = $2 AND a.participant_id = $1
+		AND s.date >= CURRENT_DATE AND a.absence_status IS NULL`
+	DeleteTrackEnrollments = `
+	DELETE FROM cohort_enrollments e
+	USING cohorts c
+	WHERE e.cohort_id = c.id AND c.track = $2 AND e.participant_id = $1`
+	DeleteTrackWaitlist = `
+	DELETE FROM cohort_waitlist w
+	USING cohorts c
+	WHERE w.cohort_id = c.id AND c.track = $2 AND w.participant_id = $1`
+
+	// cohorts
+	InsertCohort = `INSERT INTO cohorts (name, track, capacity) VALUES ($1, NULLIF($2, '')::participant_type, NULLIF($3, 0)) RETURNING id, created_at, updated_at`
+	ListCohorts  = `
+	SELECT
+		c.id, c.name, COALESCE(c.track::text, ''), COALESCE(c.capacity, 0),
+		(SELECT COUNT(*) FROM cohort_enrollments e WHERE e.cohort_id = c.id),
+		(SELECT COUNT(*) FROM cohort_waitlist w WHERE w.cohort_id = c.id),
+		c.created_at, c.updated_at
+	FROM
+		cohorts c`
+	GetCohortByID = `
+	SELECT
+		c.id, c.name, COALESCE(c.track::text, ''), COALESCE(c.capacity, 0),
+		(SELECT COUNT(*) FROM cohort_enrollments e WHERE e.cohort_id = c.id),
+		(SELECT COUNT(*) FROM cohort_waitlist w WHERE w.cohort_id = c.id),
+		c.created_at, c.updated_at
+	FROM
+		cohorts c
+	WHERE
+		c.id = $1`
+	UpdateCohortByID       = `UPDATE cohorts SET name = $2, track = NULLIF($3, '')::participant_type, capacity = NULLIF($4, 0), updated_at = $5 WHERE id = $1`
+	DeleteCohortByID       = `DELETE FROM cohorts WHERE id = $1`
+	CountSchedulesByCohort = `SELECT COUNT(*) FROM schedules WHERE cohort_id = $1`
+	// locks the cohort so concurrent enrollments can't both take the last seat
+	LockCohortSeats = `
+	SELECT COALESCE(capacity, 0), (SELECT COUNT(*) FROM cohort_enrollments e WHERE e.cohort_id = c.id)
+	FROM cohorts c
+	WHERE c.id = $1
+	FOR UPDATE`
+	InsertCohortEnrollment = `INSERT INTO cohort_enrollments (cohort_id, participant_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	DeleteCohortEnrollment = `DELETE FROM cohort_enrollments WHERE cohort_id = $1 AND participant_id = $2`
+	InsertCohortWaitlist   = `
+	INSERT INTO cohort_waitlist (cohort_id, participant_id, position)
+	SELECT $1::uuid, $2::uuid, COALESCE(MAX(position), 0) + 1 FROM cohort_waitlist WHERE cohort_id = $1
+	ON CONFLICT DO NOTHING`
+	NextCohortWaitlist   = `SELECT participant_id FROM cohort_waitlist WHERE cohort_id = $1 ORDER BY position LIMIT 1`
+	DeleteCohortWaitlist = `DELETE FROM cohort_waitlist WHERE cohort_id = $1 AND participant_id = $2`
+	ListCohortWaitlist   = `
+	SELECT
+		w.id, w.cohort_id, w.participant_id, u.name, COALESCE(p.role::text, ''), ROW_NUMBER() OVER (ORDER BY w.position), w.joined_at
+	FROM
+		cohort_waitlist w
+		JOIN participants p ON p.id = w.participant_id
+		JOIN users u ON u.id = p.user_id
+	WHERE
+		w.cohort_id = $1
+	ORDER BY
+		w.position`
+	// a participant's cohorts, waitlist entries carry their current place in line
+	ListParticipantCohorts = `
+	SELECT c.id, c.name, COALESCE(c.track::text, ''), 'Enrolled', 0, e.enrolled_at
+	FROM cohort_enrollments e JOIN cohorts c ON c.id = e.cohort_id
+	WHERE e.participant_id = $1
+	UNION ALL
+	SELECT c.id, c.name, COALESCE(c.track::text, ''), 'Waitlisted',
+		(SELECT COUNT(*) FROM cohort_waitlist x WHERE x.cohort_id = w.cohort_id AND x.position <= w.position), w.joined_at
+	FROM cohort_waitlist w JOIN cohorts c ON c.id = w.cohort_id
+	WHERE w.participant_id = $1
+	ORDER BY 6 DESC`
+	GetParticipantContact = `SELECT u.name, u.email FROM participants p JOIN users u ON u.id = p.user_id WHERE p.id = $1`
+	ListCohortEnrollments = `
+	SELECT
+		e.id, e.cohort_id, e.participant_id, u.name, COALESCE(p.role::text, ''), e.enrolled_at
+	FROM
+		cohort_enrollments e
+		JOIN participants p ON p.id = e.participant_id
+		JOIN users u ON u.id = p.user_id
+	WHERE
+		e.cohort_id = $1
+	ORDER BY
+		u.name`
+	// attendance rows of a cohort session, one per enrolled participant
+	SeedSessionAttendance = `
+	INSERT INTO absences (date, participant_id, trainer_id, schedule_id)
+	SELECT s.date, e.participant_id, s.trainer_id, s.id
+	FROM schedules s JOIN cohort_enrollments e ON e.cohort_id = s.cohort_id
+	WHERE s.id = $1`
+	SeedParticipantAttendance = `
+	INSERT INTO absences (date, participant_id, trainer_id, schedule_id)
+	SELECT s.date, $2, s.trainer_id, s.id
+	FROM schedules s
+	WHERE s.cohort_id = $1 AND s.date >= CURRENT_DATE
+		AND NOT EXISTS (SELECT 1 FROM absences a WHERE a.schedule_id = s.id AND a.participant_id = $2)`
+	DeleteUpcomingAttendance = `
+	DELETE FROM absences a
+	USING schedules s
+	WHERE a.schedule_id = s.id AND s.cohort_id = $1 AND a.participant_id = $2
+		AND s.date >= CURRENT_DATE AND a.absence_status IS NULL`
+
+	// specializations
+	InsertSpecialization         = `INSERT INTO specializations (trainer_id, name) VALUES ($1, $2) RETURNING id, trainer_id, name`
+	ListSpecializationsByTrainer = `SELECT id, trainer_id, name FROM specializations WHERE ($1 = '' OR trainer_id::text = $1) ORDER BY name`
+	GetSpecializationByID        = `SELECT id, trainer_id, name FROM specializations WHERE id = $1`
+	UpdateSpecializationByID     = `UPDATE specializations SET name = $2 WHERE id = $1 RETURNING id, trainer_id, name`
+	DeleteSpecializationByID     = `DELETE FROM specializations WHERE id = $1`
+	CountSpecializationByName    = `SELECT COUNT(*) FROM specializations WHERE trainer_id = $1 AND LOWER(name) = LOWER($2) AND id::text <> $3`
+	SuggestTrainersByActivity    = `
+	SELECT
+		t.id, u.name, COALESCE(t.phone_number, ''), array_agg(sp.name ORDER BY sp.name), COUNT(*)
+	FROM
+		specializations sp
+		JOIN trainers t ON t.id = sp.trainer_id
+		JOIN users u ON u.id = t.user_id
+	WHERE
+		sp.name <> '' AND (
+			$1 ILIKE '%' || replace(replace(replace(sp.name, '\', '\\'), '%', '\%'), '_', '\_') || '%'
+			OR sp.name ILIKE '%' || replace(replace(replace($1, '\', '\\'), '%', '\%'), '_', '\_') || '%'
+		)
+	GROUP BY
+		t.id, u.name, t.phone_number
+	ORDER BY
+		COUNT(*) DESC, u.name`
 	SelectUserAll  = "SELECT * FROM users LIMIT $1 OFFSET $2"
 	SelectUserByID = "SELECT * FROM users WHERE id = $1"
 	// CRUD User
-	ListUsers                                  = `Select id,name,email,username,address,role,created_at,updated_at FROM users ORDER BY created_at DESC LIMIT $1 OFFSET $2`
+	ListUsers                                  = `SELECT id, name, email, username, address, role, created_at, updated_at FROM users`
 	GetUserByID                                = `SELECT id,name,email,username,address,role,created_at,updated_at FROM users WHERE id = $1`
 	InsertUser                                 = `INSERT INTO users(name,email,username,address,hash_password,role,updated_at) VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING id,created_at`
 	InsertAndGetUserRole                       = `INSERT INTO users (name, email, username, address, hash_password, role, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, created_at, updated_at`
@@ -28,16 +677,39 @@ const (
 	UpdatedUserAll                             = `UPDATE users SET name = $2,email = $3,username = $4,address = $5,hash_password=$6,role =$7  WHERE id = $1`
 	DeleteUserByID                             = `DELETE FROM users WHERE id = $1`
 	SelectTaskList                             = `SELECT id, title, content, author_id, created_at, updated_at FROM tasks ORDER BY created_at DESC LIMIT $1 OFFSET $2`
-	SelectQuestionList                         = `SELECT id, question, status, participant_id,trainer_id,schedule_id, created_at, updated_at FROM questions ORDER BY created_at DESC LIMIT $1 OFFSET $2`
-	SelectQuestionByID                         = `SELECT id, question, status, participant_id,trainer_id,schedule_id, created_at, updated_at FROM questions WHERE id = $1`
+	SelectQuestionList                         = `SELECT id, question, status, participant_id,trainer_id,schedule_id, is_anonymous, created_at, updated_at FROM questions`
+	SelectQuestionByID                         = `SELECT id, question, status, participant_id,trainer_id,schedule_id, is_anonymous, created_at, updated_at FROM questions WHERE id = $1`
 	InsertQuestion                             = `INSERT INTO questions ( question, status, participant_id,trainer_id,schedule_id, updated_at) VALUES ($1, $2, $3, $4,$5,$6) RETURNING id, created_at`
 	InsertQuestionNew                          = `INSERT INTO questions ( question, answer, status, participant_id, trainer_id, schedule_id) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at, updated_at`
-	UpdateQuestion                             = `UPDATE questions SET status = $2 WHERE id = $1`
+	UpdateQuestion                             = `UPDATE questions SET status = $2, updated_at = $3 WHERE id = $1`
 	DeleteQuestion                             = `DELETE FROM questions WHERE id = $1`
 	DeleteSchedule                             = `DELETE FROM Schedule WHERE date = $1`
-	SelectQuestionByTrainerID                  = `SELECT id, question, status, participant_id,trainer_id,schedule_id, created_at, updated_at FROM questions WHERE trainer_id = $1 limit $2 offset $3`
+	SelectQuestionByTrainerID                  = `SELECT id, question, status, participant_id,trainer_id,schedule_id, is_anonymous, created_at, updated_at FROM questions WHERE trainer_id = $1 limit $2 offset $3`
 	SelectQuestionByScheduleIDandParticipantID = `SELECT id, question, status, participant_id FROM questions WHERE schedule_id = $1 AND participant_id = $2 AND status = $3`
 
+	InsertQuestionVote     = `INSERT INTO question_votes (question_id, participant_id) VALUES ($1, $2) ON CONFLICT (question_id, participant_id) DO NOTHING`
+	DeleteQuestionVote     = `DELETE FROM question_votes WHERE question_id = $1 AND participant_id = $2`
+	CountQuestionVotes     = `SELECT COUNT(*) FROM question_votes WHERE question_id = $1`
+	SelectSessionQuestions = `
+	SELECT
+		q.id, q.question, COALESCE(q.answer, ''), q.status, q.participant_id, q.trainer_id, q.schedule_id,
+		q.is_anonymous, COUNT(v.id) AS votes, q.created_at, q.updated_at
+	FROM
+		questions q
+		JOIN schedules s ON s.id = q.schedule_id
+		JOIN schedules ref ON ref.date = s.date AND ref.trainer_id = s.trainer_id
+		LEFT JOIN question_votes v ON v.question_id = q.id
+	WHERE
+		ref.id = $1
+	GROUP BY
+		q.id`
+	OrderQuestionsByVotes = ` ORDER BY votes DESC, q.created_at ASC`
+	OrderQuestionsByTime  = ` ORDER BY q.created_at ASC`
+
+	InsertQuestionAttachment = `INSERT INTO question_attachments (question_id, kind, original_name, storage_key, size_bytes, mime_type, uploaded_by) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, created_at`
+	ListQuestionAttachments  = `SELECT id, question_id, kind, original_name, storage_key, COALESCE(size_bytes, 0), COALESCE(mime_type, ''), uploaded_by, created_at FROM question_attachments WHERE question_id = $1 ORDER BY created_at ASC`
+	GetQuestionAttachment    = `SELECT id, question_id, kind, original_name, storage_key, COALESCE(size_bytes, 0), COALESCE(mime_type, ''), uploaded_by, created_at FROM question_attachments WHERE id = $1`
+
 	UpdateQuestionStatusByTrainer = `
 	UPDATE
 		questions
@@ -145,8 +817,8 @@ const (
 	GetScheduleByParticipanId     = `select s.trainer_id, s.participant_id FROM participants p JOIN schedules s ON p.id = s.participant_id WHERE p.id =$1 ;`
 	SelectParticipantWithSchedule = `SELECT s.id,s.activity, to_char(s.date, 'Day') AS day_of_weeks,s.date,s.trainer_id, s.created_at FROM participants p JOIN schedules s ON p.id = s.participant_id WHERE p.id = $1;`
 	InsertQuestionByParticipants  = `INSERT INTO questions (participant_id, question_text, created_at) VALUES ($1, $2, $3) RETURNING id, trainer_id, schedule_id, ;`
-	CreateQuestionQuery           = `INSERT INTO questions (question, status, participant_id, schedule_id) VALUES ($1, $2, $3, $4) RETURNING id, participant_id, schedule_id, created_at, updated_at`
-	ScheduleIDByParticipantId     = `SELECT id FROM schedules WHERE participant_id = $1;`
+	CreateQuestionQuery           = `INSERT INTO questions (question, status, participant_id, schedule_id, is_anonymous) VALUES ($1, $2, $3, $4, $5) RETURNING id, participant_id, schedule_id, created_at, updated_at`
+	ScheduleIDByParticipantId     = `SELECT id FROM schedules WHERE participant_id = $1 OR cohort_id IN (SELECT cohort_id FROM cohort_enrollments WHERE participant_id = $1);`
 	// SelectQuestionList = `SELECT id, question, status, participant_id, created_at, updated_at FROM questions ORDER BY created_at DESC`
 	// SelectQuestionByID = `SELECT id, question, status, participant_id, created_at, updated_at FROM questions WHERE id = $1`
 	// InsertQuestion     = `INSERT INTO questions (id, question, status, participant_id, created_at, updated_at) VALUES ,($1, $2, $3, $4, $5, $6)`
@@ -154,32 +826,84 @@ const (
 	// DeleteQuestion     = `DELETE FROM questions WHERE id = $1`
 
 	InsertAbsence               = `INSERT INTO absences (date, participant_id, trainer_id, schedule_id) VALUES ($1, $2, $3, $4)`
-	ListAbsence                 = `SELECT id, date, information, absence_status, absence_time, participant_id, created_at, updated_at FROM absences ORDER BY created_at desc limit $1 offset $2`
-	ListAbsencebyDate           = `SELECT id, date, information, absence_status, absence_time, participant_id, created_at, updated_at FROM absences WHERE date >= $1 AND date <= $2 ORDER BY created_at asc limit $3 offset $4`
+	ListAbsence                 = `SELECT id, date, information, absence_status, absence_time, participant_id, created_at, updated_at FROM absences`
 	GetAbsencesById             = `SELECT id, date, information, absence_status, absence_time, created_at, updated_at FROM absences WHERE participant_id = $1 ORDER BY created_at desc`
 	GetAbsencesByScheduleId     = `SELECT id, date, participant_id, trainer_id, schedule_id FROM absences WHERE schedule_id = $1`
 	DeleteByParticipantId       = `DELETE FROM absences WHERE participant_id = $1`
-	InsertSchedule              = `INSERT INTO schedules (activity, date, trainer_id, participant_id) VALUES ($1, $2, $3, $4) RETURNING id, created_at, updated_at`
-	ListSchedule                = `SELECT id, activity, date, trainer_id, participant_id, created_at, updated_at FROM schedules ORDER BY created_at desc limit $1 offset $2`
-	ListScheduleByDate          = `SELECT id, activity, date, trainer_id, participant_id, created_at, updated_at FROM schedules WHERE date >= $1 AND date <= $2 ORDER BY created_at desc limit $3 offset $4`
-	ListScheduleByTrainerId     = `SELECT id, activity, date, trainer_id, participant_id, created_at, updated_at FROM schedules WHERE trainer_id = $1 limit $2 offset $3`
-	ListScheduleByParticipantId = `SELECT id, activity, date, trainer_id, participant_id, created_at, updated_at FROM schedules WHERE participant_id = $1 limit $2 offset $3`
+	InsertSchedule              = `INSERT INTO schedules (activity, date, trainer_id, participant_id, cohort_id, lesson_id) VALUES ($1, $2, $3, NULLIF($4, '')::uuid, NULLIF($5, '')::uuid, NULLIF($6, '')::uuid) RETURNING id, created_at, updated_at`
+	ListSchedule                = `SELECT id, activity, date, trainer_id, COALESCE(participant_id::text, ''), COALESCE(cohort_id::text, ''), COALESCE(lesson_id::text, ''), created_at, updated_at FROM schedules`
+	ListScheduleByTrainerId     = `SELECT id, activity, date, trainer_id, COALESCE(participant_id::text, ''), COALESCE(cohort_id::text, ''), COALESCE(lesson_id::text, ''), created_at, updated_at FROM schedules WHERE trainer_id = $1 limit $2 offset $3`
+	ListScheduleByParticipantId = `SELECT id, activity, date, trainer_id, COALESCE(participant_id::text, ''), COALESCE(cohort_id::text, ''), COALESCE(lesson_id::text, ''), created_at, updated_at FROM schedules WHERE participant_id = $1 OR cohort_id IN (SELECT cohort_id FROM cohort_enrollments WHERE participant_id = $1) ORDER BY date DESC limit $2 offset $3`
+	GetScheduleByID             = `SELECT id, activity, date, trainer_id, COALESCE(participant_id::text, ''), COALESCE(cohort_id::text, ''), COALESCE(lesson_id::text, ''), created_at, updated_at FROM schedules WHERE id = $1`
 
 	InsertScheduleImage = `
 	INSERT INTO
-		schedule_images(schedule_id, file_name)
-	VALUES($1, $2)
+		schedule_images(schedule_id, file_name, storage_key, size_bytes, mime_type, checksum, source_checksum, thumbnail_key, caption,
+			captured_at, gps_latitude, gps_longitude, verification, verification_note)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 	RETURNING
 		id,
 		schedule_id,
-		file_name;
+		file_name,
+		storage_key,
+		size_bytes,
+		mime_type,
+		checksum,
+		thumbnail_key,
+		caption,
+		verification,
+		COALESCE(verification_note, ''),
+		created_at;
 	`
-	ScheduleIDByTrainerId           = `SELECT id FROM schedules WHERE trainer_id = $1`
-	ScheduleIdByDay                 = `Select id from schedules WHERE EXTRACT(DOW FROM date) = $1 ORDER BY date asc`
-	DateByDay                       = `Select date from schedules WHERE EXTRACT(DOW FROM date) = $1 ORDER BY date asc`
-	DateByScheduleId                = `Select date from schedules WHERE id = $1`
-	ScheduleIDbyDate                = `Select id from schedules WHERE date = $1`
-	UpdateScheduleByAdmin           = `Update schedules SET trainer_id = $2 WHERE date = $1 Returning id, activity, date, trainer_id, participant_id, updated_at`
+	CountScheduleImageBySourceChecksum = `SELECT COUNT(*) FROM schedule_images WHERE source_checksum = $1`
+
+	selectActivityProof = `
+	SELECT
+		i.id, i.schedule_id, s.trainer_id, s.date, COALESCE(i.file_name, ''), COALESCE(i.storage_key, ''),
+		COALESCE(i.size_bytes, 0), COALESCE(i.mime_type, ''), COALESCE(i.checksum, ''), COALESCE(i.thumbnail_key, ''),
+		COALESCE(i.caption, ''), i.captured_at, i.gps_latitude, i.gps_longitude, i.verification,
+		COALESCE(i.verification_note, ''), i.review_status, COALESCE(i.review_reason, ''), COALESCE(i.reviewed_by::text, ''),
+		i.reviewed_at, i.created_at
+	FROM
+		schedule_images i
+		JOIN schedules s ON s.id = i.schedule_id`
+	ListActivityProofs   = selectActivityProof
+	GetActivityProofByID = selectActivityProof + ` WHERE i.id = $1`
+	ReviewActivityProof  = `UPDATE schedule_images SET review_status = $2, review_reason = $3, reviewed_by = $4, reviewed_at = $5, updated_at = $5 WHERE id = $1`
+	DeleteActivityProof  = `DELETE FROM schedule_images WHERE id = $1`
+	// one row per trainer and day, the list filters and pages over what the subquery picked
+	ListSessionsWithoutProof = `
+	SELECT
+		id, activity, date, trainer_id, participant_id, cohort_id, created_at, updated_at
+	FROM (
+		SELECT DISTINCT ON (s.date, s.trainer_id)
+			s.id, s.activity, s.date, s.trainer_id, COALESCE(s.participant_id::text, '') AS participant_id,
+			COALESCE(s.cohort_id::text, '') AS cohort_id, s.created_at, s.updated_at
+		FROM
+			schedules s
+		WHERE
+			s.date < CURRENT_DATE
+			AND NOT EXISTS (
+				SELECT 1 FROM schedule_images i JOIN schedules p ON p.id = i.schedule_id
+				WHERE p.date = s.date AND p.trainer_id = s.trainer_id
+			)
+		ORDER BY
+			s.date, s.trainer_id, s.created_at, s.id
+	) AS missing`
+	ScheduleIDByTrainerId     = `SELECT id FROM schedules WHERE trainer_id = $1`
+	ScheduleIdByDay           = `Select id from schedules WHERE EXTRACT(DOW FROM date) = $1 ORDER BY date asc`
+	DateByDay                 = `Select date from schedules WHERE EXTRACT(DOW FROM date) = $1 ORDER BY date asc`
+	DateByScheduleId          = `Select date from schedules WHERE id = $1`
+	ScheduleIDbyDate          = `Select id from schedules WHERE date = $1`
+	CountParticipantInSession = `
+	SELECT
+		(SELECT COUNT(*) FROM schedules s JOIN schedules ref ON ref.date = s.date AND ref.trainer_id = s.trainer_id
+			WHERE ref.id = $1 AND s.participant_id = $2)
+		+ (SELECT COUNT(*) FROM schedules ref JOIN cohort_enrollments e ON e.cohort_id = ref.cohort_id
+			WHERE ref.id = $1 AND e.participant_id = $2)`
+	ListSessionsByDateRange         = `SELECT id, date, trainer_id, COALESCE(activity, ''), COALESCE(cohort_id::text, '') FROM schedules WHERE date >= $1 AND date <= $2 ORDER BY date, trainer_id, created_at, id`
+	ReassignSession                 = `UPDATE schedules SET trainer_id = $3, updated_at = $4 WHERE id = $1 AND trainer_id = $2`
+	UpdateScheduleByAdmin           = `Update schedules SET trainer_id = $2 WHERE date = $1 Returning id, activity, date, trainer_id, COALESCE(participant_id::text, ''), COALESCE(cohort_id::text, ''), COALESCE(lesson_id::text, ''), updated_at`
 	UpdateAbsencesByParticipantName = `
 	UPDATE
 		absences
@@ -5,9 +5,9 @@ import (
 	"instructor-led-app/delivery/middleware"
 	"instructor-led-app/entity/dto"
 	"instructor-led-app/shared/common"
+	"instructor-led-app/shared/query"
 	"instructor-led-app/usecase"
 	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -26,13 +26,13 @@ type AbsenceController struct {
 func (a *AbsenceController) createHandler(ctx *gin.Context) {
 	var payload dto.TrainerNameDTO
 	if err := ctx.ShouldBindJSON(&payload); err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		common.SendBindError(ctx, err)
 		return
 	}
 
 	absence, err := a.absenceUC.InsertNewAbsence(payload.Name)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		common.SendError(ctx, err)
 		return
 	}
 
@@ -44,7 +44,7 @@ func (a *AbsenceController) UpdateAbsencesByParticipantName(ctx *gin.Context) {
 	user := ctx.MustGet("userID").(string)
 	trainer, _ := a.trainerUC.FindTrainerByUserId(user)
 	if err := ctx.ShouldBindJSON(&payload); err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		common.SendBindError(ctx, err)
 		return
 	}
 	userId, _ := a.userUC.FindUserIDByName(payload.Name)
@@ -52,7 +52,7 @@ func (a *AbsenceController) UpdateAbsencesByParticipantName(ctx *gin.Context) {
 
 	updateAbsence, err := a.absenceUC.UpdateAbsencesByScheduleId(trainer.ID, participantId.ID, payload)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed update"})
+		common.SendError(ctx, err)
 		return
 	}
 	ctx.JSON(http.StatusOK, gin.H{"data": updateAbsence})
@@ -63,12 +63,12 @@ func (a *AbsenceController) GetAbsencesByScheduleIdHandler(ctx *gin.Context) {
 	user := ctx.MustGet("userID").(string)
 	trainer, err := a.trainerUC.FindTrainerByUserId(user)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusNotFound, "Trainer tidak ada")
+		common.SendError(ctx, err)
 		return
 	}
 	absences, err := a.absenceUC.GetAbsencesByScheduleID(trainer.ID)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusNotFound, "Anda tidak memiliki jadwal hari ini")
+		common.SendError(ctx, err)
 		return
 	}
 	common.SendSingleResponse(ctx, absences, "Ok")
@@ -76,15 +76,17 @@ func (a *AbsenceController) GetAbsencesByScheduleIdHandler(ctx *gin.Context) {
 }
 
 func (a *AbsenceController) listHandler(ctx *gin.Context) {
-	page, _ := strconv.Atoi(ctx.Query("page"))
-	size, _ := strconv.Atoi(ctx.Query("size"))
+	spec, err := query.Parse(ctx.Request.URL.Query())
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
 
 	// Tambahkan variabel untuk startDate dan endDate
 	startDateParam := ctx.Query("startDate")
 	endDateParam := ctx.Query("endDate")
 
 	var startDate, endDate time.Time
-	var err error
 
 	// Parsing startDate
 	if startDateParam != "" {
@@ -104,9 +106,13 @@ func (a *AbsenceController) listHandler(ctx *gin.Context) {
 		}
 	}
 
-	absences, paging, err := a.absenceUC.FindAllAbsence(startDate, endDate, page, size)
+	// startDate dan endDate tetap didukung sebagai filter[date][range]
+	if !startDate.IsZero() && !endDate.IsZero() {
+		spec.Where("date", query.Range, startDateParam, endDateParam)
+	}
+	absences, paging, err := a.absenceUC.FindAllAbsence(spec)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		common.SendError(ctx, err)
 		return
 	}
 	var response []interface{}
@@ -120,7 +126,7 @@ func (a *AbsenceController) GetByIdHandler(ctx *gin.Context) {
 	id := ctx.Param("id")
 	absences, err := a.absenceUC.GetAbsencesByParticipantID(id)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusNotFound, "task with author ID "+id+" not found")
+		common.SendError(ctx, err)
 		return
 	}
 	common.SendSingleResponse(ctx, absences, "Ok")
@@ -131,7 +137,7 @@ func (a *AbsenceController) deleteHandler(ctx *gin.Context) {
 	// Call the DeleteByParticipantId method from the use case
 	err := a.absenceUC.DeleteByParticipantId(id)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		common.SendError(ctx, err)
 		return
 	}
 	// Respond with a success message
@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"instructor-led-app/config"
+	"instructor-led-app/delivery/middleware"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/shared/common"
+	"instructor-led-app/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AssessmentController struct {
+	assessmentUC   usecase.AssessmentUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+func (a *AssessmentController) createHandler(ctx *gin.Context) {
+	var payload dto.AssessmentDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	assessment, err := a.assessmentUC.CreateAssessment(payload, ctx.MustGet("userID").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendCreateResponse(ctx, assessment, "Created")
+}
+
+func (a *AssessmentController) listHandler(ctx *gin.Context) {
+	assessments, err := a.assessmentUC.FindAssessments(ctx.Query("scheduleId"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, assessments, "Ok")
+}
+
+func (a *AssessmentController) getHandler(ctx *gin.Context) {
+	assessment, err := a.assessmentUC.FindAssessment(ctx.Param("id"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, assessment, "Ok")
+}
+
+func (a *AssessmentController) deleteHandler(ctx *gin.Context) {
+	if err := a.assessmentUC.DeleteAssessment(ctx.Param("id"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string)); err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendDeleteResponse(ctx, "Delete assessment successfully")
+}
+
+func (a *AssessmentController) submitHandler(ctx *gin.Context) {
+	var payload dto.AssessmentSubmissionDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	attempt, err := a.assessmentUC.SubmitAttempt(ctx.Param("id"), ctx.MustGet("userID").(string), payload)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendCreateResponse(ctx, attempt, "Created")
+}
+
+func (a *AssessmentController) listAttemptHandler(ctx *gin.Context) {
+	attempts, err := a.assessmentUC.FindAttempts(ctx.Param("id"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, attempts, "Ok")
+}
+
+func (a *AssessmentController) getAttemptHandler(ctx *gin.Context) {
+	attempt, err := a.assessmentUC.FindAttempt(ctx.Param("id"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, attempt, "Ok")
+}
+
+func (a *AssessmentController) gradeHandler(ctx *gin.Context) {
+	var payload dto.GradeAnswerDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	attempt, err := a.assessmentUC.GradeAnswer(ctx.Param("id"), ctx.Param("answerId"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string), payload)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, attempt, "Ok")
+}
+
+func (a *AssessmentController) resultHandler(ctx *gin.Context) {
+	attempts, err := a.assessmentUC.FindOwnResults(ctx.MustGet("userID").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, attempts, "Ok")
+}
+
+func (a *AssessmentController) Route() {
+	a.rg.POST(config.Assessments, a.authMiddleware.RequireToken("trainer"), a.createHandler)
+	a.rg.GET(config.Assessments, a.authMiddleware.RequireToken("admin", "trainer", "participant"), a.listHandler)
+	a.rg.GET(config.AssessmentByID, a.authMiddleware.RequireToken("admin", "trainer", "participant"), a.getHandler)
+	a.rg.DELETE(config.AssessmentByID, a.authMiddleware.RequireToken("admin", "trainer"), a.deleteHandler)
+	a.rg.POST(config.AssessmentAttempts, a.authMiddleware.RequireToken("participant"), a.submitHandler)
+	a.rg.GET(config.AssessmentAttempts, a.authMiddleware.RequireToken("admin", "trainer"), a.listAttemptHandler)
+	a.rg.GET(config.AttemptByID, a.authMiddleware.RequireToken("admin", "trainer", "participant"), a.getAttemptHandler)
+	a.rg.PUT(config.AttemptAnswerGrade, a.authMiddleware.RequireToken("admin", "trainer"), a.gradeHandler)
+
+	participant := a.rg.Group(config.ParticipantsGroup)
+	participant.GET(config.AssessmentResults, a.authMiddleware.RequireToken("participant"), a.resultHandler)
+}
+
+func NewAssessmentController(assessmentUC usecase.AssessmentUseCase, rg *gin.RouterGroup, auth middleware.AuthMiddleware) *AssessmentController {
+	return &AssessmentController{
+		assessmentUC:   assessmentUC,
+		rg:             rg,
+		authMiddleware: auth,
+	}
+}
@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"instructor-led-app/config"
+	"instructor-led-app/delivery/middleware"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/shared/common"
+	"instructor-led-app/usecase"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AssignmentController struct {
+	assignmentUC   usecase.AssignmentUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+func (a *AssignmentController) createHandler(ctx *gin.Context) {
+	var payload dto.AssignmentDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	assignment, err := a.assignmentUC.CreateAssignment(payload, ctx.MustGet("userID").(string), ctx.MustGet("role").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendCreateResponse(ctx, assignment, "Created")
+}
+
+func (a *AssignmentController) listHandler(ctx *gin.Context) {
+	assignments, err := a.assignmentUC.FindAssignments(ctx.Query("scheduleId"), ctx.Query("cohortId"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, assignments, "Ok")
+}
+
+func (a *AssignmentController) ownListHandler(ctx *gin.Context) {
+	assignments, err := a.assignmentUC.FindOwnAssignments(ctx.MustGet("userID").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, assignments, "Ok")
+}
+
+func (a *AssignmentController) getHandler(ctx *gin.Context) {
+	assignment, err := a.assignmentUC.FindAssignment(ctx.Param("id"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, assignment, "Ok")
+}
+
+func (a *AssignmentController) deleteHandler(ctx *gin.Context) {
+	if err := a.assignmentUC.DeleteAssignment(ctx.Param("id"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string)); err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendDeleteResponse(ctx, "Delete assignment successfully")
+}
+
+// submitHandler takes a multipart form with an optional "text" field and an optional "file".
+func (a *AssignmentController) submitHandler(ctx *gin.Context) {
+	var file *dto.UploadFileDTO
+	if _, err := ctx.FormFile("file"); err == nil {
+		upload, status, err := readUploadedFile(ctx, "file", attachmentExtensions)
+		if err != nil {
+			common.SendErrorResponse(ctx, status, err.Error())
+			return
+		}
+		file = &upload
+	}
+
+	submission, err := a.assignmentUC.Submit(ctx.Param("id"), ctx.MustGet("userID").(string), ctx.PostForm("text"), file)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendCreateResponse(ctx, submission, "Created")
+}
+
+func (a *AssignmentController) listSubmissionHandler(ctx *gin.Context) {
+	submissions, err := a.assignmentUC.FindSubmissions(ctx.Param("id"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, submissions, "Ok")
+}
+
+func (a *AssignmentController) getSubmissionHandler(ctx *gin.Context) {
+	submission, err := a.assignmentUC.FindSubmission(ctx.Param("id"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, submission, "Ok")
+}
+
+func (a *AssignmentController) fileHandler(ctx *gin.Context) {
+	body, submission, err := a.assignmentUC.OpenSubmissionFile(ctx.Param("id"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	defer body.Close()
+
+	ctx.DataFromReader(http.StatusOK, submission.Size, submission.MimeType, body, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": submission.FileName}),
+	})
+}
+
+func (a *AssignmentController) gradeHandler(ctx *gin.Context) {
+	var payload dto.GradeSubmissionDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	submission, err := a.assignmentUC.GradeSubmission(ctx.Param("id"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string), payload)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, submission, "Ok")
+}
+
+func (a *AssignmentController) gradebookHandler(ctx *gin.Context) {
+	gradebook, err := a.assignmentUC.Gradebook(ctx.Param("id"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, gradebook, "Ok")
+}
+
+func (a *AssignmentController) Route() {
+	a.rg.POST(config.Assignments, a.authMiddleware.RequireToken("admin", "trainer"), a.createHandler)
+	a.rg.GET(config.Assignments, a.authMiddleware.RequireToken("admin", "trainer", "participant"), a.listHandler)
+	a.rg.GET(config.AssignmentByID, a.authMiddleware.RequireToken("admin", "trainer", "participant"), a.getHandler)
+	a.rg.DELETE(config.AssignmentByID, a.authMiddleware.RequireToken("admin", "trainer"), a.deleteHandler)
+	a.rg.POST(config.AssignmentSubmissions, a.authMiddleware.RequireToken("participant"), middleware.FileSizeLimitMiddleware(uploadSizeLimit, "file"), a.submitHandler)
+	a.rg.GET(config.AssignmentSubmissions, a.authMiddleware.RequireToken("admin", "trainer"), a.listSubmissionHandler)
+	a.rg.GET(config.SubmissionByID, a.authMiddleware.RequireToken("admin", "trainer", "participant"), a.getSubmissionHandler)
+	a.rg.GET(config.SubmissionFile, a.authMiddleware.RequireToken("admin", "trainer", "participant"), a.fileHandler)
+	a.rg.PUT(config.SubmissionGrade, a.authMiddleware.RequireToken("admin", "trainer"), a.gradeHandler)
+	a.rg.GET(config.CohortGradebook, a.authMiddleware.RequireToken("admin", "trainer"), a.gradebookHandler)
+
+	participant := a.rg.Group(config.ParticipantsGroup)
+	participant.GET(config.Assignments, a.authMiddleware.RequireToken("participant"), a.ownListHandler)
+}
+
+func NewAssignmentController(assignmentUC usecase.AssignmentUseCase, rg *gin.RouterGroup, auth middleware.AuthMiddleware) *AssignmentController {
+	return &AssignmentController{
+		assignmentUC:   assignmentUC,
+		rg:             rg,
+		authMiddleware: auth,
+	}
+}
@@ -1,10 +1,10 @@
 package controller
 
 import (
+	"instructor-led-app/config"
 	"instructor-led-app/entity/dto"
 	"instructor-led-app/shared/common"
 	"instructor-led-app/usecase"
-	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
@@ -17,19 +17,19 @@ type AuthController struct {
 func (a *AuthController) loginHandler(ctx *gin.Context) {
 	var payload dto.AuthRequestDto
 	if err := ctx.ShouldBindJSON(&payload); err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		common.SendBindError(ctx, err)
 		return
 	}
 	rsv, err := a.authUc.Login(payload)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		common.SendError(ctx, err)
 		return
 	}
 	common.SendCreateResponse(ctx, rsv, "Ok")
 }
 
 func (a *AuthController) Route() {
-	a.rg.POST("/auth/login", a.loginHandler)
+	a.rg.POST(config.AuthLogin, a.loginHandler)
 }
 
 func NewAuthController(authUc usecase.AuthUseCase, rg *gin.RouterGroup) *AuthController {
@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"instructor-led-app/config"
+	"instructor-led-app/delivery/middleware"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/shared/common"
+	"instructor-led-app/usecase"
+	"io"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AvailabilityController struct {
+	availabilityUC usecase.AvailabilityUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+func (a *AvailabilityController) listSlotHandler(ctx *gin.Context) {
+	slots, err := a.availabilityUC.FindSlots(ctx.Query("trainerId"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, slots, "Ok")
+}
+
+func (a *AvailabilityController) createSlotHandler(ctx *gin.Context) {
+	var payload dto.AvailabilitySlotDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	slot, err := a.availabilityUC.AddSlot(payload, ctx.MustGet("userID").(string), ctx.MustGet("role").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendCreateResponse(ctx, slot, "Created")
+}
+
+func (a *AvailabilityController) deleteSlotHandler(ctx *gin.Context) {
+	if err := a.availabilityUC.DeleteSlot(ctx.Param("id"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string)); err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendDeleteResponse(ctx, "Delete availability successfully")
+}
+
+func (a *AvailabilityController) listTimeOffHandler(ctx *gin.Context) {
+	timeOffs, err := a.availabilityUC.FindTimeOffs(ctx.Query("trainerId"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, timeOffs, "Ok")
+}
+
+func (a *AvailabilityController) createTimeOffHandler(ctx *gin.Context) {
+	var payload dto.TimeOffDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	timeOff, err := a.availabilityUC.AddTimeOff(payload, ctx.MustGet("userID").(string), ctx.MustGet("role").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendCreateResponse(ctx, timeOff, "Created")
+}
+
+func (a *AvailabilityController) deleteTimeOffHandler(ctx *gin.Context) {
+	if err := a.availabilityUC.DeleteTimeOff(ctx.Param("id"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string)); err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendDeleteResponse(ctx, "Delete time off successfully")
+}
+
+func (a *AvailabilityController) previewAutoAssignHandler(ctx *gin.Context) {
+	var payload dto.AutoAssignRequestDTO
+	if err := ctx.ShouldBindQuery(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	result, err := a.availabilityUC.AutoAssign(payload, false)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, result, "Ok")
+}
+
+func (a *AvailabilityController) autoAssignHandler(ctx *gin.Context) {
+	var payload dto.AutoAssignRequestDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil && err != io.EOF {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	result, err := a.availabilityUC.AutoAssign(payload, true)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, result, "Trainers assigned")
+}
+
+func (a *AvailabilityController) Route() {
+	a.rg.GET(config.Availability, a.authMiddleware.RequireToken("admin", "trainer"), a.listSlotHandler)
+	a.rg.POST(config.Availability, a.authMiddleware.RequireToken("admin", "trainer"), a.createSlotHandler)
+	a.rg.DELETE(config.AvailabilityByID, a.authMiddleware.RequireToken("admin", "trainer"), a.deleteSlotHandler)
+	a.rg.GET(config.TimeOff, a.authMiddleware.RequireToken("admin", "trainer"), a.listTimeOffHandler)
+	a.rg.POST(config.TimeOff, a.authMiddleware.RequireToken("admin", "trainer"), a.createTimeOffHandler)
+	a.rg.DELETE(config.TimeOffByID, a.authMiddleware.RequireToken("admin", "trainer"), a.deleteTimeOffHandler)
+
+	admin := a.rg.Group(config.AdminGroup)
+	admin.GET(config.AutoAssignPreview, a.authMiddleware.RequireToken("admin"), a.previewAutoAssignHandler)
+	admin.POST(config.AutoAssign, a.authMiddleware.RequireToken("admin"), a.autoAssignHandler)
+}
+
+func NewAvailabilityController(availabilityUC usecase.AvailabilityUseCase, rg *gin.RouterGroup, auth middleware.AuthMiddleware) *AvailabilityController {
+	return &AvailabilityController{
+		availabilityUC: availabilityUC,
+		rg:             rg,
+		authMiddleware: auth,
+	}
+}
@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"instructor-led-app/config"
+	"instructor-led-app/delivery/middleware"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/shared/common"
+	"instructor-led-app/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CertificateController struct {
+	certificateUC  usecase.CertificateUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+func (c *CertificateController) eligibilityHandler(ctx *gin.Context) {
+	eligibility, err := c.certificateUC.FindEligibility(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, eligibility, "Ok")
+}
+
+func (c *CertificateController) issueHandler(ctx *gin.Context) {
+	var payload dto.IssueCertificateDTO
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&payload); err != nil {
+			common.SendBindError(ctx, err)
+			return
+		}
+	}
+
+	issued, skipped, err := c.certificateUC.IssueCertificates(ctx.Param("id"), ctx.MustGet("userID").(string), payload)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendCreateResponse(ctx, gin.H{"issued": issued, "skipped": skipped}, "Certificates issued")
+}
+
+func (c *CertificateController) listHandler(ctx *gin.Context) {
+	certificates, err := c.certificateUC.FindCertificates(ctx.Query("cohortId"))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, certificates, "Ok")
+}
+
+func (c *CertificateController) ownHandler(ctx *gin.Context) {
+	certificates, err := c.certificateUC.FindOwnCertificates(ctx.MustGet("userID").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, certificates, "Ok")
+}
+
+func (c *CertificateController) pdfHandler(ctx *gin.Context) {
+	content, certificate, err := c.certificateUC.RenderCertificate(ctx.Param("id"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", `attachment; filename="certificate-`+certificate.Code+`.pdf"`)
+	ctx.Data(http.StatusOK, "application/pdf", content)
+}
+
+func (c *CertificateController) verifyHandler(ctx *gin.Context) {
+	verification := c.certificateUC.VerifyCertificate(ctx.Param("code"))
+	if !verification.Valid {
+		common.SendErrorResponse(ctx, http.StatusNotFound, "certificate not found")
+		return
+	}
+	common.SendSingleResponse(ctx, verification, "Certificate is valid")
+}
+
+func (c *CertificateController) Route() {
+	c.rg.GET(config.CertificateVerify, c.verifyHandler)
+	c.rg.GET(config.CertificatePdf, c.authMiddleware.RequireToken("admin", "participant"), c.pdfHandler)
+
+	participant := c.rg.Group(config.ParticipantsGroup)
+	participant.GET(config.Certificates, c.authMiddleware.RequireToken("participant"), c.ownHandler)
+
+	admin := c.rg.Group(config.AdminGroup)
+	admin.GET(config.Certificates, c.authMiddleware.RequireToken("admin"), c.listHandler)
+	admin.GET(config.CertificateEligible, c.authMiddleware.RequireToken("admin"), c.eligibilityHandler)
+	admin.POST(config.CohortCertificates, c.authMiddleware.RequireToken("admin"), c.issueHandler)
+}
+
+func NewCertificateController(certificateUC usecase.CertificateUseCase, rg *gin.RouterGroup, auth middleware.AuthMiddleware) *CertificateController {
+	return &CertificateController{
+		certificateUC:  certificateUC,
+		rg:             rg,
+		authMiddleware: auth,
+	}
+}
@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"instructor-led-app/config"
+	"instructor-led-app/delivery/middleware"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/shared/common"
+	"instructor-led-app/shared/query"
+	"instructor-led-app/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CohortController struct {
+	cohortUC       usecase.CohortUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+func (c *CohortController) listHandler(ctx *gin.Context) {
+	spec, err := query.Parse(ctx.Request.URL.Query())
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+
+	cohorts, paging, err := c.cohortUC.FindCohorts(spec)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+
+	var response []interface{}
+	for _, cohort := range cohorts {
+		response = append(response, cohort)
+	}
+	common.SendPagedResponse(ctx, response, paging, "Ok")
+}
+
+func (c *CohortController) getHandler(ctx *gin.Context) {
+	cohort, err := c.cohortUC.FindCohortByID(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, cohort, "Ok")
+}
+
+func (c *CohortController) createHandler(ctx *gin.Context) {
+	var payload dto.CohortDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	cohort, err := c.cohortUC.CreateCohort(payload)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendCreateResponse(ctx, cohort, "Created")
+}
+
+func (c *CohortController) updateHandler(ctx *gin.Context) {
+	var payload dto.CohortDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	cohort, err := c.cohortUC.UpdateCohort(ctx.Param("id"), payload)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, cohort, "Ok")
+}
+
+func (c *CohortController) deleteHandler(ctx *gin.Context) {
+	if err := c.cohortUC.DeleteCohort(ctx.Param("id")); err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendDeleteResponse(ctx, "Delete cohort successfully")
+}
+
+func (c *CohortController) listEnrollmentHandler(ctx *gin.Context) {
+	enrollments, err := c.cohortUC.FindEnrollments(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, enrollments, "Ok")
+}
+
+func (c *CohortController) waitlistHandler(ctx *gin.Context) {
+	waitlist, err := c.cohortUC.FindWaitlist(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, waitlist, "Ok")
+}
+
+func (c *CohortController) enrollHandler(ctx *gin.Context) {
+	var payload dto.CohortEnrollDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	enrollments, err := c.cohortUC.EnrollParticipants(ctx.Param("id"), payload)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendCreateResponse(ctx, enrollments, "Created")
+}
+
+func (c *CohortController) unenrollHandler(ctx *gin.Context) {
+	if err := c.cohortUC.UnenrollParticipant(ctx.Param("id"), ctx.Param("participantId")); err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendDeleteResponse(ctx, "Remove participant from cohort successfully")
+}
+
+func (c *CohortController) ownListHandler(ctx *gin.Context) {
+	cohorts, err := c.cohortUC.FindOwnCohorts(ctx.MustGet("userID").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, cohorts, "Ok")
+}
+
+func (c *CohortController) withdrawHandler(ctx *gin.Context) {
+	if err := c.cohortUC.Withdraw(ctx.Param("id"), ctx.MustGet("userID").(string)); err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendDeleteResponse(ctx, "Withdraw from cohort successfully")
+}
+
+func (c *CohortController) Route() {
+	admin := c.rg.Group(config.AdminGroup)
+	admin.GET(config.Cohorts, c.authMiddleware.RequireToken("admin"), c.listHandler)
+	admin.POST(config.Cohorts, c.authMiddleware.RequireToken("admin"), c.createHandler)
+	admin.GET(config.CohortByID, c.authMiddleware.RequireToken("admin"), c.getHandler)
+	admin.PUT(config.CohortByID, c.authMiddleware.RequireToken("admin"), c.updateHandler)
+	admin.DELETE(config.CohortByID, c.authMiddleware.RequireToken("admin"), c.deleteHandler)
+	admin.GET(config.CohortEnrollments, c.authMiddleware.RequireToken("admin"), c.listEnrollmentHandler)
+	admin.POST(config.CohortEnrollments, c.authMiddleware.RequireToken("admin"), c.enrollHandler)
+	admin.DELETE(config.CohortEnrollment, c.authMiddleware.RequireToken("admin"), c.unenrollHandler)
+	admin.GET(config.CohortWaitlist, c.authMiddleware.RequireToken("admin"), c.waitlistHandler)
+
+	participant := c.rg.Group(config.ParticipantsGroup)
+	participant.GET(config.Cohorts, c.authMiddleware.RequireToken("participant"), c.ownListHandler)
+	participant.DELETE(config.CohortWithdrawal, c.authMiddleware.RequireToken("participant"), c.withdrawHandler)
+}
+
+func NewCohortController(cohortUC usecase.CohortUseCase, rg *gin.RouterGroup, auth middleware.AuthMiddleware) *CohortController {
+	return &CohortController{
+		cohortUC:       cohortUC,
+		rg:             rg,
+		authMiddleware: auth,
+	}
+}
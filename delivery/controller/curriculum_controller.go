@@ -0,0 +1,239 @@
+package controller
+
+import (
+	"instructor-led-app/config"
+	"instructor-led-app/delivery/middleware"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/shared/common"
+	"instructor-led-app/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CurriculumController struct {
+	curriculumUC   usecase.CurriculumUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+func (c *CurriculumController) listCourseHandler(ctx *gin.Context) {
+	courses, err := c.curriculumUC.FindCourses(ctx.Query("track"))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, courses, "Ok")
+}
+
+func (c *CurriculumController) getCourseHandler(ctx *gin.Context) {
+	course, err := c.curriculumUC.FindCourseByID(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, course, "Ok")
+}
+
+func (c *CurriculumController) createCourseHandler(ctx *gin.Context) {
+	var payload dto.CourseDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	course, err := c.curriculumUC.CreateCourse(payload)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendCreateResponse(ctx, course, "Created")
+}
+
+func (c *CurriculumController) updateCourseHandler(ctx *gin.Context) {
+	var payload dto.CourseDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	course, err := c.curriculumUC.UpdateCourse(ctx.Param("id"), payload)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, course, "Ok")
+}
+
+func (c *CurriculumController) deleteCourseHandler(ctx *gin.Context) {
+	if err := c.curriculumUC.DeleteCourse(ctx.Param("id")); err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendDeleteResponse(ctx, "Delete course successfully")
+}
+
+func (c *CurriculumController) createModuleHandler(ctx *gin.Context) {
+	var payload dto.CourseModuleDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	module, err := c.curriculumUC.AddModule(ctx.Param("id"), payload)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendCreateResponse(ctx, module, "Created")
+}
+
+func (c *CurriculumController) updateModuleHandler(ctx *gin.Context) {
+	var payload dto.CourseModuleDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	module, err := c.curriculumUC.UpdateModule(ctx.Param("id"), payload)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, module, "Ok")
+}
+
+func (c *CurriculumController) deleteModuleHandler(ctx *gin.Context) {
+	if err := c.curriculumUC.DeleteModule(ctx.Param("id")); err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendDeleteResponse(ctx, "Delete module successfully")
+}
+
+func (c *CurriculumController) reorderModuleHandler(ctx *gin.Context) {
+	var payload dto.ReorderDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	modules, err := c.curriculumUC.ReorderModules(ctx.Param("id"), payload)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, modules, "Ok")
+}
+
+func (c *CurriculumController) createLessonHandler(ctx *gin.Context) {
+	var payload dto.LessonDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	lesson, err := c.curriculumUC.AddLesson(ctx.Param("id"), payload)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendCreateResponse(ctx, lesson, "Created")
+}
+
+func (c *CurriculumController) getLessonHandler(ctx *gin.Context) {
+	lesson, err := c.curriculumUC.FindLessonByID(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, lesson, "Ok")
+}
+
+func (c *CurriculumController) updateLessonHandler(ctx *gin.Context) {
+	var payload dto.LessonDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	lesson, err := c.curriculumUC.UpdateLesson(ctx.Param("id"), payload)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, lesson, "Ok")
+}
+
+func (c *CurriculumController) deleteLessonHandler(ctx *gin.Context) {
+	if err := c.curriculumUC.DeleteLesson(ctx.Param("id")); err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendDeleteResponse(ctx, "Delete lesson successfully")
+}
+
+func (c *CurriculumController) reorderLessonHandler(ctx *gin.Context) {
+	var payload dto.ReorderDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	lessons, err := c.curriculumUC.ReorderLessons(ctx.Param("id"), payload)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, lessons, "Ok")
+}
+
+func (c *CurriculumController) ownCoverageHandler(ctx *gin.Context) {
+	coverage, err := c.curriculumUC.FindOwnCoverage(ctx.MustGet("userID").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, coverage, "Ok")
+}
+
+func (c *CurriculumController) coverageHandler(ctx *gin.Context) {
+	coverage, err := c.curriculumUC.FindParticipantCoverage(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, coverage, "Ok")
+}
+
+func (c *CurriculumController) Route() {
+	c.rg.GET(config.Courses, c.authMiddleware.RequireToken("admin", "trainer", "participant"), c.listCourseHandler)
+	c.rg.GET(config.CourseByID, c.authMiddleware.RequireToken("admin", "trainer", "participant"), c.getCourseHandler)
+	c.rg.POST(config.Courses, c.authMiddleware.RequireToken("admin"), c.createCourseHandler)
+	c.rg.PUT(config.CourseByID, c.authMiddleware.RequireToken("admin"), c.updateCourseHandler)
+	c.rg.DELETE(config.CourseByID, c.authMiddleware.RequireToken("admin"), c.deleteCourseHandler)
+
+	c.rg.POST(config.CourseModules, c.authMiddleware.RequireToken("admin"), c.createModuleHandler)
+	c.rg.PUT(config.CourseModuleOrder, c.authMiddleware.RequireToken("admin"), c.reorderModuleHandler)
+	c.rg.PUT(config.CourseModuleByID, c.authMiddleware.RequireToken("admin"), c.updateModuleHandler)
+	c.rg.DELETE(config.CourseModuleByID, c.authMiddleware.RequireToken("admin"), c.deleteModuleHandler)
+
+	c.rg.POST(config.ModuleLessons, c.authMiddleware.RequireToken("admin"), c.createLessonHandler)
+	c.rg.PUT(config.LessonOrder, c.authMiddleware.RequireToken("admin"), c.reorderLessonHandler)
+	c.rg.GET(config.LessonByID, c.authMiddleware.RequireToken("admin", "trainer", "participant"), c.getLessonHandler)
+	c.rg.PUT(config.LessonByID, c.authMiddleware.RequireToken("admin"), c.updateLessonHandler)
+	c.rg.DELETE(config.LessonByID, c.authMiddleware.RequireToken("admin"), c.deleteLessonHandler)
+
+	participant := c.rg.Group(config.ParticipantsGroup)
+	participant.GET(config.Curriculum, c.authMiddleware.RequireToken("participant"), c.ownCoverageHandler)
+
+	admin := c.rg.Group(config.AdminGroup)
+	admin.GET(config.ParticipantCurriculum, c.authMiddleware.RequireToken("admin"), c.coverageHandler)
+}
+
+func NewCurriculumController(curriculumUC usecase.CurriculumUseCase, rg *gin.RouterGroup, auth middleware.AuthMiddleware) *CurriculumController {
+	return &CurriculumController{
+		curriculumUC:   curriculumUC,
+		rg:             rg,
+		authMiddleware: auth,
+	}
+}
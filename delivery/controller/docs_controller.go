@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"encoding/json"
+	"instructor-led-app/config"
+	"instructor-led-app/delivery/docs"
+	"instructor-led-app/delivery/docs/swaggerui"
+	"instructor-led-app/shared/apperror"
+	"instructor-led-app/shared/common"
+	"log"
+	"mime"
+	"net/http"
+	"path"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+const docsAssets = config.APIGroup + config.APIDocs + "/assets/"
+
+// docsPage loads the embedded Swagger UI and points it at the generated spec.
+const docsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Instructor Led API</title>
+  <link rel="stylesheet" href="` + docsAssets + `swagger-ui.css?v=` + swaggerui.Version + `">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="` + docsAssets + `swagger-ui-bundle.js?v=` + swaggerui.Version + `"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "` + config.APIGroup + config.OpenAPISpec + `", dom_id: "#swagger-ui", persistAuthorization: true });
+  </script>
+</body>
+</html>`
+
+type DocsController struct {
+	routes func() gin.RoutesInfo
+	rg     *gin.RouterGroup
+	once   sync.Once
+	spec   []byte
+	err    error
+}
+
+// specHandler builds the spec on first use, once every controller has registered its routes.
+func (d *DocsController) specHandler(ctx *gin.Context) {
+	d.once.Do(func() {
+		doc, err := docs.Build(d.routes())
+		if err != nil {
+			log.Println(err.Error())
+		}
+		d.spec, d.err = json.Marshal(doc)
+	})
+	if d.err != nil {
+		common.SendError(ctx, d.err)
+		return
+	}
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", d.spec)
+}
+
+func (d *DocsController) uiHandler(ctx *gin.Context) {
+	ctx.Data(http.StatusOK, "text/html; charset=utf-8", []byte(docsPage))
+}
+
+func (d *DocsController) assetHandler(ctx *gin.Context) {
+	name := ctx.Param("name")
+	content, err := swaggerui.Open(name)
+	if err != nil {
+		common.SendError(ctx, apperror.NotFound("docs asset %s not found", name))
+		return
+	}
+	ctx.Data(http.StatusOK, mime.TypeByExtension(path.Ext(name)), content)
+}
+
+func (d *DocsController) Route() {
+	d.rg.GET(config.OpenAPISpec, d.specHandler)
+	d.rg.GET(config.APIDocs, d.uiHandler)
+	d.rg.GET(config.APIDocsAsset, d.assetHandler)
+}
+
+func NewDocsController(routes func() gin.RoutesInfo, rg *gin.RouterGroup) *DocsController {
+	return &DocsController{routes: routes, rg: rg}
+}
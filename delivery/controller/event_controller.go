@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"instructor-led-app/config"
+	"instructor-led-app/delivery/middleware"
+	"instructor-led-app/shared/common"
+	"instructor-led-app/shared/service"
+	"instructor-led-app/usecase"
+	"io"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EventController struct {
+	scheduleUC     usecase.ScheduleUseCase
+	eventBroker    service.EventBroker
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+// streamHandler pushes question and absence events of one schedule as Server-Sent Events.
+func (e *EventController) streamHandler(ctx *gin.Context) {
+	id := ctx.Param("id")
+	userId := ctx.MustGet("userID").(string)
+	role := ctx.MustGet("role").(string)
+
+	schedule, err := e.scheduleUC.FindScheduleForUser(id, userId, role)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+
+	events, unsubscribe := e.eventBroker.Subscribe(schedule.ID)
+	defer unsubscribe()
+
+	heartbeat := time.NewTicker(15 * time.Second)
+	defer heartbeat.Stop()
+
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.SSEvent("ready", gin.H{"scheduleId": schedule.ID})
+	ctx.Writer.Flush()
+
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			ctx.SSEvent(event.Type, event)
+			return true
+		case <-heartbeat.C:
+			ctx.SSEvent("ping", time.Now())
+			return true
+		}
+	})
+}
+
+func (e *EventController) Route() {
+	e.rg.GET(config.ScheduleEvents, e.authMiddleware.RequireStreamToken("admin", "trainer", "participant"), e.streamHandler)
+}
+
+func NewEventController(scheduleUC usecase.ScheduleUseCase, eventBroker service.EventBroker, rg *gin.RouterGroup, authMiddleware middleware.AuthMiddleware) *EventController {
+	return &EventController{
+		scheduleUC:     scheduleUC,
+		eventBroker:    eventBroker,
+		rg:             rg,
+		authMiddleware: authMiddleware,
+	}
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"instructor-led-app/config"
+	"instructor-led-app/delivery/middleware"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/shared/common"
+	"instructor-led-app/shared/query"
+	"instructor-led-app/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FeedbackController struct {
+	feedbackUC     usecase.FeedbackUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+func (f *FeedbackController) submitHandler(ctx *gin.Context) {
+	var payload dto.FeedbackDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	feedback, err := f.feedbackUC.SubmitFeedback(ctx.MustGet("userID").(string), payload)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendCreateResponse(ctx, feedback, "Thank you for your feedback")
+}
+
+func (f *FeedbackController) trainerFeedbackHandler(ctx *gin.Context) {
+	spec, err := query.Parse(ctx.Request.URL.Query())
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+
+	feedbacks, paging, err := f.feedbackUC.FindTrainerFeedback(ctx.MustGet("userID").(string), spec)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	var response []interface{}
+	for _, v := range feedbacks {
+		response = append(response, v)
+	}
+	common.SendPagedResponse(ctx, response, paging, "Ok")
+}
+
+func (f *FeedbackController) ratingSummaryHandler(ctx *gin.Context) {
+	var filter dto.RatingSummaryFilterDTO
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	ratings, err := f.feedbackUC.TrainerRatings(filter)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, ratings, "Ok")
+}
+
+func (f *FeedbackController) Route() {
+	participant := f.rg.Group(config.ParticipantsGroup)
+	participant.POST(config.Feedback, f.authMiddleware.RequireToken("participant"), f.submitHandler)
+
+	trainer := f.rg.Group(config.TrainerGroup)
+	trainer.GET(config.Feedback, f.authMiddleware.RequireToken("trainer"), f.trainerFeedbackHandler)
+
+	admin := f.rg.Group(config.AdminGroup)
+	admin.GET(config.ReportTrainerRatings, f.authMiddleware.RequireToken("admin"), f.ratingSummaryHandler)
+}
+
+func NewFeedbackController(feedbackUC usecase.FeedbackUseCase, rg *gin.RouterGroup, auth middleware.AuthMiddleware) *FeedbackController {
+	return &FeedbackController{
+		feedbackUC:     feedbackUC,
+		rg:             rg,
+		authMiddleware: auth,
+	}
+}
@@ -0,0 +1,177 @@
+package controller
+
+import (
+	"instructor-led-app/config"
+	"instructor-led-app/delivery/middleware"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/shared/common"
+	"instructor-led-app/usecase"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MaterialController struct {
+	materialUC     usecase.MaterialUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+// bindMaterialForm reads the multipart form of a material, the "file" field is optional when a url is sent.
+// It answers the request itself when the form can't be used.
+func bindMaterialForm(ctx *gin.Context) (dto.MaterialDTO, *dto.UploadFileDTO, bool) {
+	var payload dto.MaterialDTO
+	if err := ctx.ShouldBind(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return dto.MaterialDTO{}, nil, false
+	}
+
+	if _, err := ctx.FormFile("file"); err != nil {
+		return payload, nil, true
+	}
+	upload, status, err := readUploadedFile(ctx, "file", materialExtensions)
+	if err != nil {
+		common.SendErrorResponse(ctx, status, err.Error())
+		return dto.MaterialDTO{}, nil, false
+	}
+	return payload, &upload, true
+}
+
+func (m *MaterialController) createHandler(ctx *gin.Context) {
+	payload, file, ok := bindMaterialForm(ctx)
+	if !ok {
+		return
+	}
+
+	material, err := m.materialUC.CreateMaterial(ctx.MustGet("userID").(string), ctx.MustGet("role").(string), payload, file)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendCreateResponse(ctx, material, "Created")
+}
+
+func (m *MaterialController) listHandler(ctx *gin.Context) {
+	materials, err := m.materialUC.FindMaterials(ctx.Query("scheduleId"), ctx.Query("lessonId"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, materials, "Ok")
+}
+
+func (m *MaterialController) ownListHandler(ctx *gin.Context) {
+	sessions, err := m.materialUC.FindOwnMaterials(ctx.MustGet("userID").(string), ctx.Query("when"))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, sessions, "Ok")
+}
+
+func (m *MaterialController) getHandler(ctx *gin.Context) {
+	material, err := m.materialUC.FindMaterial(ctx.Param("id"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, material, "Ok")
+}
+
+func (m *MaterialController) updateHandler(ctx *gin.Context) {
+	var payload dto.MaterialUpdateDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	material, err := m.materialUC.UpdateMaterial(ctx.Param("id"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string), payload)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, material, "Ok")
+}
+
+func (m *MaterialController) deleteHandler(ctx *gin.Context) {
+	if err := m.materialUC.DeleteMaterial(ctx.Param("id"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string)); err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendDeleteResponse(ctx, "Delete material successfully")
+}
+
+func (m *MaterialController) addVersionHandler(ctx *gin.Context) {
+	payload, file, ok := bindMaterialForm(ctx)
+	if !ok {
+		return
+	}
+
+	material, err := m.materialUC.AddVersion(ctx.Param("id"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string), payload, file)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendCreateResponse(ctx, material, "Created")
+}
+
+// downloadHandler streams the file of a version, or redirects when the version is a link.
+func (m *MaterialController) downloadHandler(ctx *gin.Context) {
+	version := 0
+	if raw := ctx.Query("version"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			common.SendErrorResponse(ctx, http.StatusBadRequest, "version must be a number")
+			return
+		}
+		version = parsed
+	}
+
+	body, selected, err := m.materialUC.OpenMaterial(ctx.Param("id"), version, ctx.MustGet("userID").(string), ctx.MustGet("role").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	if body == nil {
+		ctx.Redirect(http.StatusFound, selected.URL)
+		return
+	}
+	defer body.Close()
+
+	ctx.DataFromReader(http.StatusOK, selected.Size, selected.MimeType, body, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": selected.FileName}),
+	})
+}
+
+func (m *MaterialController) downloadsHandler(ctx *gin.Context) {
+	downloads, err := m.materialUC.FindDownloads(ctx.Param("id"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, downloads, "Ok")
+}
+
+func (m *MaterialController) Route() {
+	m.rg.POST(config.Materials, m.authMiddleware.RequireToken("admin", "trainer"), middleware.FileSizeLimitMiddleware(materialSizeLimit, "file"), m.createHandler)
+	m.rg.GET(config.Materials, m.authMiddleware.RequireToken("admin", "trainer", "participant"), m.listHandler)
+	m.rg.GET(config.MaterialByID, m.authMiddleware.RequireToken("admin", "trainer", "participant"), m.getHandler)
+	m.rg.PUT(config.MaterialByID, m.authMiddleware.RequireToken("admin", "trainer"), m.updateHandler)
+	m.rg.DELETE(config.MaterialByID, m.authMiddleware.RequireToken("admin", "trainer"), m.deleteHandler)
+	m.rg.POST(config.MaterialVersions, m.authMiddleware.RequireToken("admin", "trainer"), middleware.FileSizeLimitMiddleware(materialSizeLimit, "file"), m.addVersionHandler)
+	m.rg.GET(config.MaterialDownload, m.authMiddleware.RequireToken("admin", "trainer", "participant"), m.downloadHandler)
+	m.rg.GET(config.MaterialDownloads, m.authMiddleware.RequireToken("admin", "trainer"), m.downloadsHandler)
+
+	participant := m.rg.Group(config.ParticipantsGroup)
+	participant.GET(config.Materials, m.authMiddleware.RequireToken("participant"), m.ownListHandler)
+}
+
+func NewMaterialController(materialUC usecase.MaterialUseCase, rg *gin.RouterGroup, auth middleware.AuthMiddleware) *MaterialController {
+	return &MaterialController{
+		materialUC:     materialUC,
+		rg:             rg,
+		authMiddleware: auth,
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"instructor-led-app/entity/dto"
 	"instructor-led-app/shared/common"
 	"instructor-led-app/usecase"
-	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -22,13 +21,13 @@ type participantController struct {
 func (c *participantController) insertHandler(ctx *gin.Context) {
 	var participantDto dto.ParticipantDTO
 	if err := ctx.ShouldBindJSON(&participantDto); err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		common.SendBindError(ctx, err)
 		return
 	}
 
 	participantDto, err := c.participantUseCase.CreateNewParticipant(participantDto)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		common.SendError(ctx, err)
 		return
 	}
 
@@ -41,7 +40,7 @@ func (c *participantController) listHandler(ctx *gin.Context) {
 
 	participants, paging, err := c.participantUseCase.GetAllParticipants(page, size)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		common.SendError(ctx, err)
 		return
 	}
 
@@ -58,7 +57,7 @@ func (c *participantController) getHandler(ctx *gin.Context) {
 
 	participant, err := c.participantUseCase.GetParticipantByID(id)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		common.SendError(ctx, err)
 		return
 	}
 
@@ -72,7 +71,7 @@ func (c *participantController) getHandler(ctx *gin.Context) {
 // 	participant, err := c.participantUseCase.FindScheduleWithParticipantId(user)
 // 	fmt.Println(participant)
 // 	if err != nil {
-// 		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+// 		common.SendError(ctx, err)
 // 		return
 // 	}
 
@@ -86,14 +85,14 @@ func (c *participantController) getHandler(ctx *gin.Context) {
 
 // 	var participantDto dto.ParticipantDTO
 // 	if err := ctx.ShouldBindJSON(&participantDto); err != nil {
-// 		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+// 		common.SendError(ctx, err)
 // 		return
 // 	}
 // 	participantDto.ID = id
 
 // 	participant, err := c.participantUseCase.UpdateParticipantByID(participantDto)
 // 	if err != nil {
-// 		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+// 		common.SendError(ctx, err)
 // 		return
 // 	}
 
@@ -104,7 +103,7 @@ func (c *participantController) deleteHandler(ctx *gin.Context) {
 	id := ctx.Param("id")
 
 	if err := c.participantUseCase.DeleteParticipantByID(id); err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		common.SendError(ctx, err)
 		return
 	}
 
@@ -115,24 +114,24 @@ func (c *participantController) UpdateParticipantRoleByAdmin(ctx *gin.Context) {
 	var payload dto.ParticipantRoleDTO
 
 	if err := ctx.ShouldBindJSON(&payload); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid request"})
+		common.SendBindError(ctx, err)
 		return
 	}
 	userId, err := c.userUc.FindUserIDByName(payload.Name)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, "user not found")
+		common.SendError(ctx, err)
 		return
 	}
 
 	participantId, err := c.participantUseCase.GetParticipantByUserId(userId.Id)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, "participant not found")
+		common.SendError(ctx, err)
 		return
 	}
 
 	err = c.participantUseCase.UpdateParticipantByRole(payload.Role, participantId.ID)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, "Failed to update Participant: "+err.Error())
+		common.SendError(ctx, err)
 		return
 	}
 
@@ -144,20 +143,20 @@ func (c *participantController) UpdateParticipantByParticipantId(ctx *gin.Contex
 	user := ctx.MustGet("userID").(string)
 	participantId, err := c.participantUseCase.GetParticipantByUserId(user)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, "invalid ID")
+		common.SendError(ctx, err)
 		return
 	}
 	var participant dto.ParticipantDTO
 
 	if err := ctx.ShouldBindJSON(&participant); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid request"})
+		common.SendBindError(ctx, err)
 		return
 	}
 
 	participant.ID = participantId.ID
 	updateParticipant, err := c.participantUseCase.UpdateParticipantByID(participant)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, "Failed to update trainer: "+err.Error())
+		common.SendError(ctx, err)
 		return
 	}
 	common.SendSingleResponse(ctx, updateParticipant, "Ok")
@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"instructor-led-app/config"
+	"instructor-led-app/delivery/middleware"
+	"instructor-led-app/entity"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/shared/common"
+	"instructor-led-app/shared/query"
+	"instructor-led-app/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PromotionController struct {
+	promotionUC    usecase.PromotionUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+func (p *PromotionController) criteriaHandler(ctx *gin.Context) {
+	criteria, err := p.promotionUC.FindCriteria()
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, criteria, "Ok")
+}
+
+func (p *PromotionController) updateCriteriaHandler(ctx *gin.Context) {
+	var payload entity.PromotionCriteria
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	criteria, err := p.promotionUC.UpdateCriteria(payload)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, criteria, "Ok")
+}
+
+func (p *PromotionController) progressHandler(ctx *gin.Context) {
+	progress, err := p.promotionUC.FindProgress(ctx.Query("participantId"))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, progress, "Ok")
+}
+
+func (p *PromotionController) ownProgressHandler(ctx *gin.Context) {
+	progress, err := p.promotionUC.FindOwnProgress(ctx.MustGet("userID").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, progress, "Ok")
+}
+
+func (p *PromotionController) evaluateHandler(ctx *gin.Context) {
+	queued, err := p.promotionUC.EvaluateAll()
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, queued, "Ok")
+}
+
+func (p *PromotionController) listHandler(ctx *gin.Context) {
+	spec, err := query.Parse(ctx.Request.URL.Query())
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	// status=x is short for filter[status]=x
+	if status := ctx.Query("status"); status != "" {
+		spec.Where("status", query.Eq, status)
+	}
+
+	requests, paging, err := p.promotionUC.FindRequests(spec)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+
+	var response []interface{}
+	for _, request := range requests {
+		response = append(response, request)
+	}
+	common.SendPagedResponse(ctx, response, paging, "Ok")
+}
+
+func (p *PromotionController) reviewHandler(ctx *gin.Context) {
+	var payload dto.PromotionReviewDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	request, err := p.promotionUC.ReviewRequest(ctx.Param("id"), ctx.MustGet("userID").(string), payload)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, request, "Ok")
+}
+
+func (p *PromotionController) historyHandler(ctx *gin.Context) {
+	history, err := p.promotionUC.FindHistory(ctx.Param("id"))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, history, "Ok")
+}
+
+func (p *PromotionController) Route() {
+	participant := p.rg.Group(config.ParticipantsGroup)
+	participant.GET(config.ParticipantProgress, p.authMiddleware.RequireToken("participant"), p.ownProgressHandler)
+
+	admin := p.rg.Group(config.AdminGroup)
+	admin.GET(config.PromotionCriteria, p.authMiddleware.RequireToken("admin"), p.criteriaHandler)
+	admin.PUT(config.PromotionCriteria, p.authMiddleware.RequireToken("admin"), p.updateCriteriaHandler)
+	admin.GET(config.ProgressReport, p.authMiddleware.RequireToken("admin"), p.progressHandler)
+	admin.GET(config.Promotions, p.authMiddleware.RequireToken("admin"), p.listHandler)
+	admin.POST(config.PromotionEvaluate, p.authMiddleware.RequireToken("admin"), p.evaluateHandler)
+	admin.PUT(config.PromotionReview, p.authMiddleware.RequireToken("admin"), p.reviewHandler)
+	admin.GET(config.TrackHistory, p.authMiddleware.RequireToken("admin"), p.historyHandler)
+}
+
+func NewPromotionController(promotionUC usecase.PromotionUseCase, rg *gin.RouterGroup, auth middleware.AuthMiddleware) *PromotionController {
+	return &PromotionController{
+		promotionUC:    promotionUC,
+		rg:             rg,
+		authMiddleware: auth,
+	}
+}
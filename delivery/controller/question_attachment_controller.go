@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"instructor-led-app/config"
+	"instructor-led-app/delivery/middleware"
+	"instructor-led-app/entity"
+	"instructor-led-app/shared/common"
+	"instructor-led-app/usecase"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type QuestionAttachmentController struct {
+	attachmentUC   usecase.QuestionAttachmentUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+func (q *QuestionAttachmentController) uploadQuestionHandler(ctx *gin.Context) {
+	q.upload(ctx, "question")
+}
+
+func (q *QuestionAttachmentController) uploadAnswerHandler(ctx *gin.Context) {
+	q.upload(ctx, "answer")
+}
+
+func (q *QuestionAttachmentController) upload(ctx *gin.Context, kind string) {
+	questionId := ctx.Param("id")
+	userId := ctx.MustGet("userID").(string)
+
+	if _, err := q.attachmentUC.CheckUploadAccess(questionId, userId, kind); err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+
+	file, status, err := readUploadedFile(ctx, "file", attachmentExtensions)
+	if err != nil {
+		common.SendErrorResponse(ctx, status, err.Error())
+		return
+	}
+
+	attachment, err := q.attachmentUC.AddAttachment(entity.QuestionAttachment{
+		QuestionID: questionId,
+		Kind:       kind,
+		UploadedBy: userId,
+	}, file)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+
+	common.SendCreateResponse(ctx, attachment, "Upload attachment successfully")
+}
+
+func (q *QuestionAttachmentController) listHandler(ctx *gin.Context) {
+	userId := ctx.MustGet("userID").(string)
+	role := ctx.MustGet("role").(string)
+
+	attachments, err := q.attachmentUC.FindAttachments(ctx.Param("id"), userId, role)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, attachments, "Ok")
+}
+
+func (q *QuestionAttachmentController) downloadHandler(ctx *gin.Context) {
+	userId := ctx.MustGet("userID").(string)
+	role := ctx.MustGet("role").(string)
+
+	body, attachment, err := q.attachmentUC.OpenAttachment(ctx.Param("id"), userId, role)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	defer body.Close()
+
+	// attachments saved before the file storage have no size or type on record
+	size, mimeType := attachment.Size, attachment.MimeType
+	if size == 0 {
+		size = -1
+	}
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+	ctx.DataFromReader(http.StatusOK, size, mimeType, body, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": attachment.OriginalName}),
+	})
+}
+
+func (q *QuestionAttachmentController) Route() {
+	q.rg.POST(config.QuestionAttachments, q.authMiddleware.RequireToken("participant"), middleware.FileSizeLimitMiddleware(attachmentSizeLimit, "file"), q.uploadQuestionHandler)
+	q.rg.POST(config.AnswerAttachments, q.authMiddleware.RequireToken("trainer"), middleware.FileSizeLimitMiddleware(attachmentSizeLimit, "file"), q.uploadAnswerHandler)
+	q.rg.GET(config.QuestionAttachments, q.authMiddleware.RequireToken("admin", "trainer", "participant"), q.listHandler)
+	q.rg.GET(config.AttachmentDownload, q.authMiddleware.RequireToken("admin", "trainer", "participant"), q.downloadHandler)
+}
+
+func NewQuestionAttachmentController(attachmentUC usecase.QuestionAttachmentUseCase, rg *gin.RouterGroup, authMiddleware middleware.AuthMiddleware) *QuestionAttachmentController {
+	return &QuestionAttachmentController{
+		attachmentUC:   attachmentUC,
+		rg:             rg,
+		authMiddleware: authMiddleware,
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"instructor-led-app/entity"
 	"instructor-led-app/entity/dto"
 	"instructor-led-app/shared/common"
+	"instructor-led-app/shared/query"
 	"instructor-led-app/usecase"
 	"net/http"
 	"strconv"
@@ -27,11 +28,16 @@ type QuestionController struct {
 }
 
 func (q *QuestionController) listHandler(ctx *gin.Context) {
-	page, _ := strconv.Atoi(ctx.Query("page"))
-	size, _ := strconv.Atoi(ctx.Query("size"))
-	questions, paging, err := q.questionUC.FindAllQuestion(page, size)
+	spec, err := query.Parse(ctx.Request.URL.Query())
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		common.SendError(ctx, err)
+		return
+	}
+	userId := ctx.MustGet("userID").(string)
+	role := ctx.MustGet("role").(string)
+	questions, paging, err := q.questionUC.FindAllQuestion(spec, userId, role)
+	if err != nil {
+		common.SendError(ctx, err)
 		return
 	}
 	var response []interface{}
@@ -48,7 +54,7 @@ func (q *QuestionController) UpdatadStatusQuestionByTrainer(ctx *gin.Context) {
 	trainer, _ := q.trainerUC.FindTrainerByUserId(user)
 	logger.Infoln(trainer.ID)
 	if err := ctx.ShouldBindJSON(&payload); err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		common.SendBindError(ctx, err)
 		return
 	}
 	logger.Infoln(payload.ParticipantName)
@@ -60,7 +66,7 @@ func (q *QuestionController) UpdatadStatusQuestionByTrainer(ctx *gin.Context) {
 	fmt.Println(participantId.ID)
 	UpdatedQuestion, err := q.questionUC.UpdatadStatusQuestionByTrainer(trainer.ID, participantId.ID, payload)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed update"})
+		common.SendError(ctx, err)
 		return
 	}
 	ctx.JSON(http.StatusOK, gin.H{"data": UpdatedQuestion})
@@ -71,14 +77,14 @@ func (q *QuestionController) CreateQuestionByTrainer(ctx *gin.Context) {
 	user := ctx.MustGet("userID").(string)
 	trainer, _ := q.trainerUC.FindTrainerByUserId(user)
 	if err := ctx.ShouldBindJSON(&payload); err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		common.SendBindError(ctx, err)
 		return
 	}
 	userId, _ := q.userUC.FindUserIDByName(payload.ParticipantName)
 	participantId, _ := q.participantUC.GetParticipantByUserId(userId.Id)
 	InsertQuestion, err := q.questionUC.CreateQuestionByTrainer(trainer.ID, participantId.ID, payload)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed update"})
+		common.SendError(ctx, err)
 		return
 	}
 	ctx.JSON(http.StatusOK, gin.H{"data": InsertQuestion})
@@ -87,12 +93,12 @@ func (q *QuestionController) CreateQuestionByTrainer(ctx *gin.Context) {
 func (q *QuestionController) createHandler(ctx *gin.Context) {
 	var payload entity.Question
 	if err := ctx.ShouldBindJSON(&payload); err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		common.SendBindError(ctx, err)
 		return
 	}
 	question, err := q.questionUC.CreateNewQuestion(payload)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		common.SendError(ctx, err)
 		return
 	}
 	common.SendCreateResponse(ctx, question, "Created")
@@ -102,7 +108,7 @@ func (q *QuestionController) deleteHandler(ctx *gin.Context) {
 	id := ctx.Param("id")
 	err := q.questionUC.DeleteQuestion(id)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		common.SendError(ctx, err)
 		return
 	}
 	common.SendErrorResponse(ctx, http.StatusNoContent, "Deleted Successfully")
@@ -110,14 +116,14 @@ func (q *QuestionController) deleteHandler(ctx *gin.Context) {
 
 func (q *QuestionController) update(c *gin.Context) {
 	id := c.Param("id")
-	var payload entity.Question
+	var payload dto.QuestionStatusDTO
 	if err := c.ShouldBindJSON(&payload); err != nil {
-		common.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		common.SendBindError(c, err)
 		return
 	}
-	question, err := q.questionUC.UpdateQuestion(id, payload)
+	question, err := q.questionUC.UpdateQuestion(id, c.MustGet("userID").(string), c.MustGet("role").(string), payload)
 	if err != nil {
-		common.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		common.SendError(c, err)
 		return
 	}
 	common.SendSingleResponse(c, question, "Updated Successfully")
@@ -125,9 +131,11 @@ func (q *QuestionController) update(c *gin.Context) {
 
 func (q *QuestionController) getById(c *gin.Context) {
 	id := c.Param("id")
-	question, err := q.questionUC.FindById(id)
+	userId := c.MustGet("userID").(string)
+	role := c.MustGet("role").(string)
+	question, err := q.questionUC.FindById(id, userId, role)
 	if err != nil {
-		common.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		common.SendError(c, err)
 		return
 	}
 	common.SendSingleResponse(c, question, "Ok")
@@ -141,7 +149,7 @@ func (q *QuestionController) GetQuestionByTrainerId(c *gin.Context) {
 	size, _ := strconv.Atoi(c.Query("size"))
 	question, paging, err := q.questionUC.FindQuestionByTrainerId(userId, page, size)
 	if err != nil {
-		common.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		common.SendError(c, err)
 		return
 	}
 	var response []interface{}
@@ -159,24 +167,58 @@ func (q *QuestionController) NewQuestionByPartcipant(ctx *gin.Context) {
 
 	participantID, err := q.participantUC.GetParticipantByUserId(userId)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get participantID"})
+		common.SendError(ctx, err)
 		return
 	}
 
 	if err := ctx.ShouldBindJSON(&payload); err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		common.SendBindError(ctx, err)
 		return
 	}
 
 	// Buat pertanyaan tanpa memasukkan participantId dari payload
 	newQuestion, err := q.questionUC.CreateQuestionByParticipant(participantID.ID, payload)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, err.Error())
+		common.SendError(ctx, err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{"data": newQuestion})
 }
+func (q *QuestionController) voteHandler(ctx *gin.Context) {
+	id := ctx.Param("id")
+	userId := ctx.MustGet("userID").(string)
+	vote, err := q.questionUC.VoteQuestion(id, userId)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, vote, "Voted")
+}
+
+func (q *QuestionController) unvoteHandler(ctx *gin.Context) {
+	id := ctx.Param("id")
+	userId := ctx.MustGet("userID").(string)
+	vote, err := q.questionUC.UnvoteQuestion(id, userId)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, vote, "Vote removed")
+}
+
+func (q *QuestionController) sessionQuestionsHandler(ctx *gin.Context) {
+	id := ctx.Param("id")
+	userId := ctx.MustGet("userID").(string)
+	role := ctx.MustGet("role").(string)
+	questions, err := q.questionUC.FindSessionQuestions(id, userId, role, ctx.Query("sort"))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, questions, "Ok")
+}
+
 func (q *QuestionController) Route() {
 	q.rg.GET(config.QuestionGetList, q.authMiddleware.RequireToken("participant"), q.listHandler)
 	q.rg.GET(config.QuestionGetById, q.authMiddleware.RequireToken("participant", "trainer"), q.getById)
@@ -187,6 +229,9 @@ func (q *QuestionController) Route() {
 	q.rg.POST(config.QuestionTrainer, q.authMiddleware.RequireToken("trainer"), q.CreateQuestionByTrainer)
 	q.rg.PUT(config.UpdateQuestionByTrainer, q.authMiddleware.RequireToken("trainer"), q.UpdatadStatusQuestionByTrainer)
 	q.rg.POST(config.ParticipantNewQuetion, q.authMiddleware.RequireToken("participant"), q.NewQuestionByPartcipant)
+	q.rg.POST(config.QuestionVote, q.authMiddleware.RequireToken("participant"), q.voteHandler)
+	q.rg.DELETE(config.QuestionVote, q.authMiddleware.RequireToken("participant"), q.unvoteHandler)
+	q.rg.GET(config.QuestionBySchedule, q.authMiddleware.RequireToken("admin", "trainer", "participant"), q.sessionQuestionsHandler)
 }
 func NewQuestionController(questionUC usecase.QuestionUseCase, scheduleUC usecase.ScheduleUseCase, trainerUC usecase.TrainerUsecase, participantUC usecase.ParticipantUseCase, userUC usecase.UserUsecase, rg *gin.RouterGroup, authMiddleware middleware.AuthMiddleware) *QuestionController {
 	return &QuestionController{
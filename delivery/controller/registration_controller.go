@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"instructor-led-app/config"
+	"instructor-led-app/delivery/middleware"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/shared/common"
+	"instructor-led-app/shared/query"
+	"instructor-led-app/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RegistrationController struct {
+	registrationUC usecase.RegistrationUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+func (r *RegistrationController) registerHandler(ctx *gin.Context) {
+	var payload dto.RegistrationDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	registration, err := r.registrationUC.Register(payload)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendCreateResponse(ctx, registration, "Check your email to verify your registration")
+}
+
+func (r *RegistrationController) resendHandler(ctx *gin.Context) {
+	var payload dto.ResendVerificationDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	if err := r.registrationUC.ResendVerification(payload.Email); err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, nil, "If the email is waiting for verification, a new link was sent")
+}
+
+func (r *RegistrationController) verifyHandler(ctx *gin.Context) {
+	registration, err := r.registrationUC.VerifyEmail(ctx.Query("token"))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, registration, "Email verified, your registration is waiting for approval")
+}
+
+func (r *RegistrationController) listHandler(ctx *gin.Context) {
+	spec, err := query.Parse(ctx.Request.URL.Query())
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	// status=x is short for filter[status]=x
+	if status := ctx.Query("status"); status != "" {
+		spec.Where("status", query.Eq, status)
+	}
+
+	registrations, paging, err := r.registrationUC.FindRegistrations(spec)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+
+	var response []interface{}
+	for _, registration := range registrations {
+		response = append(response, registration)
+	}
+	common.SendPagedResponse(ctx, response, paging, "Ok")
+}
+
+func (r *RegistrationController) reviewHandler(ctx *gin.Context) {
+	var payload dto.RegistrationReviewDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	registration, err := r.registrationUC.ReviewRegistration(ctx.Param("id"), ctx.MustGet("userID").(string), payload)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, registration, "Ok")
+}
+
+func (r *RegistrationController) Route() {
+	r.rg.POST(config.Registrations, r.registerHandler)
+	r.rg.POST(config.RegistrationResend, r.resendHandler)
+	r.rg.GET(config.RegistrationVerify, r.verifyHandler)
+
+	admin := r.rg.Group(config.AdminGroup)
+	admin.GET(config.Registrations, r.authMiddleware.RequireToken("admin"), r.listHandler)
+	admin.PUT(config.RegistrationReview, r.authMiddleware.RequireToken("admin"), r.reviewHandler)
+}
+
+func NewRegistrationController(registrationUC usecase.RegistrationUseCase, rg *gin.RouterGroup, auth middleware.AuthMiddleware) *RegistrationController {
+	return &RegistrationController{
+		registrationUC: registrationUC,
+		rg:             rg,
+		authMiddleware: auth,
+	}
+}
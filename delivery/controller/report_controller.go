@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	"instructor-led-app/config"
+	"instructor-led-app/delivery/middleware"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/shared/common"
+	"instructor-led-app/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReportController struct {
+	reportUC       usecase.ReportUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+func (r *ReportController) trainerWorkloadHandler(ctx *gin.Context) {
+	var filter dto.TrainerWorkloadFilterDTO
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	report, err := r.reportUC.TrainerWorkload(filter)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, report, "Ok")
+}
+
+func (r *ReportController) trainerWorkloadCsvHandler(ctx *gin.Context) {
+	var filter dto.TrainerWorkloadFilterDTO
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	report, err := r.reportUC.TrainerWorkload(filter)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := r.reportUC.WriteTrainerWorkloadCSV(report, &buf); err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+
+	fileName := fmt.Sprintf("trainer-workload_%s_%s.csv", report.StartDate.Format("20060102"), report.EndDate.Format("20060102"))
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	ctx.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
+
+func (r *ReportController) listRateHandler(ctx *gin.Context) {
+	rates, err := r.reportUC.FindTrainerRates()
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, rates, "Ok")
+}
+
+func (r *ReportController) saveRateHandler(ctx *gin.Context) {
+	var payload dto.TrainerRateDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	rate, err := r.reportUC.SaveTrainerRate(ctx.Param("id"), payload)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, rate, "Ok")
+}
+
+func (r *ReportController) deleteRateHandler(ctx *gin.Context) {
+	if err := r.reportUC.DeleteTrainerRate(ctx.Param("id")); err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendDeleteResponse(ctx, "Trainer rate reset to default")
+}
+
+func (r *ReportController) Route() {
+	admin := r.rg.Group(config.AdminGroup)
+	admin.GET(config.ReportTrainerWorkload, r.authMiddleware.RequireToken("admin"), r.trainerWorkloadHandler)
+	admin.GET(config.ReportTrainerWorkloadCsv, r.authMiddleware.RequireToken("admin"), r.trainerWorkloadCsvHandler)
+	admin.GET(config.TrainerRates, r.authMiddleware.RequireToken("admin"), r.listRateHandler)
+	admin.PUT(config.TrainerRateByTrainerID, r.authMiddleware.RequireToken("admin"), r.saveRateHandler)
+	admin.DELETE(config.TrainerRateByTrainerID, r.authMiddleware.RequireToken("admin"), r.deleteRateHandler)
+}
+
+func NewReportController(reportUC usecase.ReportUseCase, rg *gin.RouterGroup, auth middleware.AuthMiddleware) *ReportController {
+	return &ReportController{
+		reportUC:       reportUC,
+		rg:             rg,
+		authMiddleware: auth,
+	}
+}
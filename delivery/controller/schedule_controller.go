@@ -5,6 +5,7 @@ import (
 	"instructor-led-app/delivery/middleware"
 	"instructor-led-app/entity/dto"
 	"instructor-led-app/shared/common"
+	"instructor-led-app/shared/query"
 	"instructor-led-app/usecase"
 	"net/http"
 	"strconv"
@@ -26,22 +27,22 @@ type ScheduleController struct {
 func (s *ScheduleController) UpdateByAdminHandler(ctx *gin.Context) {
 	var payload dto.UpdateAdminDto
 	if err := ctx.ShouldBindJSON(&payload); err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		common.SendBindError(ctx, err)
 		return
 	}
 	userId, err := s.userUC.FindUserIDByName(payload.TrainerName)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, "No userId Found")
+		common.SendError(ctx, err)
 		return
 	}
 	trainerId, err := s.trainerUC.FindTrainerByUserId(userId.Id)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, "No trainer Found")
+		common.SendError(ctx, err)
 		return
 	}
 	updateSchedule, err := s.scheduleUC.UpdateScheduleByAdmin(trainerId.ID, payload.CodeDate)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed update"})
+		common.SendError(ctx, err)
 		return
 	}
 	ctx.JSON(http.StatusOK, gin.H{"data": updateSchedule})
@@ -51,12 +52,12 @@ func (s *ScheduleController) UpdateByAdminHandler(ctx *gin.Context) {
 func (s *ScheduleController) createScheduleHandler(ctx *gin.Context) {
 	var payload dto.ScheduleDto
 	if err := ctx.ShouldBindJSON(&payload); err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		common.SendBindError(ctx, err)
 		return
 	}
 	schedule, err := s.scheduleUC.InsertNewSchedule(payload)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		common.SendError(ctx, err)
 		return
 	}
 
@@ -64,15 +65,17 @@ func (s *ScheduleController) createScheduleHandler(ctx *gin.Context) {
 }
 
 func (s *ScheduleController) listScheduleHandler(ctx *gin.Context) {
-	page, _ := strconv.Atoi(ctx.Query("page"))
-	size, _ := strconv.Atoi(ctx.Query("size"))
+	spec, err := query.Parse(ctx.Request.URL.Query())
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
 
 	// Tambahkan variabel untuk startDate dan endDate
 	startDateParam := ctx.Query("startDate")
 	endDateParam := ctx.Query("endDate")
 
 	var startDate, endDate time.Time
-	var err error
 
 	// Parsing startDate
 	if startDateParam != "" {
@@ -91,9 +94,13 @@ func (s *ScheduleController) listScheduleHandler(ctx *gin.Context) {
 			return
 		}
 	}
-	schedules, paging, err := s.scheduleUC.FindAllSchedule(startDate, endDate, page, size)
+	// startDate dan endDate tetap didukung sebagai filter[date][range]
+	if !startDate.IsZero() && !endDate.IsZero() {
+		spec.Where("date", query.Range, startDateParam, endDateParam)
+	}
+	schedules, paging, err := s.scheduleUC.FindAllSchedule(spec)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		common.SendError(ctx, err)
 		return
 	}
 	var response []interface{}
@@ -109,7 +116,7 @@ func (s *ScheduleController) GetScheduleByParticipantID(ctx *gin.Context) {
 	size, _ := strconv.Atoi(ctx.Query("size"))
 	schedules, paging, err := s.scheduleUC.GetScheduleByParticipantID(id, page, size)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		common.SendError(ctx, err)
 		return
 	}
 	var response []interface{}
@@ -125,7 +132,7 @@ func (s *ScheduleController) GetScheduleByTrainerID(ctx *gin.Context) {
 	size, _ := strconv.Atoi(ctx.Query("size"))
 	schedules, paging, err := s.scheduleUC.GetScheduleByTrainerID(userId, page, size)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		common.SendError(ctx, err)
 		return
 	}
 	var response []interface{}
@@ -139,20 +146,36 @@ func (s *ScheduleController) deleteHandler(ctx *gin.Context) {
 	date := ctx.Param("date")
 
 	if err := s.scheduleUC.DeleteScheduleByDate(date); err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		common.SendError(ctx, err)
 		return
 	}
 
 	common.SendDeleteResponse(ctx, "Delete Schedule successfully")
 }
 
+func (s *ScheduleController) assignLessonHandler(ctx *gin.Context) {
+	var payload dto.ScheduleLessonDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	schedule, err := s.scheduleUC.AssignLesson(ctx.Param("id"), payload)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, schedule, "Ok")
+}
+
 func (s *ScheduleController) Route() {
 	s.rg.POST(config.SchedulePost, s.authMiddleware.RequireToken("admin"), s.createScheduleHandler)           //taran
 	s.rg.GET(config.ScheduleList, s.authMiddleware.RequireToken("admin"), s.listScheduleHandler)              //bisa
 	s.rg.GET(config.ScheduleById, s.authMiddleware.RequireToken("participant"), s.GetScheduleByParticipantID) //ini harusnya bagian puji sih
 	s.rg.GET(config.ScheduleByTrainerId, s.authMiddleware.RequireToken("trainer"), s.GetScheduleByTrainerID)  //bisa
 	s.rg.PUT(config.ScheduleByTrainerId, s.authMiddleware.RequireToken("admin"), s.UpdateByAdminHandler)      //bisa
-	s.rg.DELETE(config.DeleteSchedule, s.authMiddleware.RequireToken("admin"), s.deleteHandler)
+	s.rg.DELETE(config.ScheduleByDate, s.authMiddleware.RequireToken("admin"), s.deleteHandler)
+	s.rg.PUT(config.ScheduleLesson, s.authMiddleware.RequireToken("admin"), s.assignLessonHandler)
 }
 
 func NewScheduleController(scheduleUc usecase.ScheduleUseCase, userUC usecase.UserUsecase, trainerUC usecase.TrainerUsecase, rg *gin.RouterGroup, auth middleware.AuthMiddleware) *ScheduleController {
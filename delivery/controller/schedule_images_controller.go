@@ -1,15 +1,14 @@
 package controller
 
 import (
-	"fmt"
 	"instructor-led-app/config"
 	"instructor-led-app/delivery/middleware"
+	"instructor-led-app/entity/dto"
 	"instructor-led-app/shared/common"
+	"instructor-led-app/shared/query"
 	"instructor-led-app/usecase"
 	"log"
 	"net/http"
-	"path/filepath"
-	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -21,59 +20,170 @@ type ScheduleImageController struct {
 }
 
 func (c *ScheduleImageController) UploadImageHandler(ctx *gin.Context) {
-	file, err := ctx.FormFile("image")
+	userId := ctx.MustGet("userID").(string)
+
+	log.Println("UserID :", userId)
+
+	file, status, err := readUploadedFile(ctx, "image", imageExtensions)
 	if err != nil {
-		log.Println("FormFile")
-		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		common.SendErrorResponse(ctx, status, err.Error())
 		return
 	}
 
-	userId := ctx.MustGet("userID").(string)
-	userIdSep := strings.Split(userId, "-")[0]
+	imageDto, err := c.scheduleImageUseCase.UploadImageActivity(userId, ctx.PostForm("scheduleId"), ctx.PostForm("caption"), file)
+	if err != nil {
+		log.Println("upload image activity")
+		common.SendError(ctx, err)
+		return
+	}
 
-	log.Println("UserID :", userId)
+	common.SendSingleResponse(ctx, imageDto, "Upload Image successfully")
+}
 
-	allowedExtension := []string{".jpg", ".jpeg", ".png", ".webp"}
-	filename := fmt.Sprintf("assets/images/%s-%s", userIdSep, file.Filename)
-	ext := filepath.Ext(filename)
+func (c *ScheduleImageController) listActivityProofHandler(ctx *gin.Context) {
+	spec, err := query.Parse(ctx.Request.URL.Query())
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	var filter dto.ActivityProofFilterDTO
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	// the older parameters are short for the filters of the same fields
+	for _, legacy := range [][2]string{
+		{"reviewStatus", filter.Status},
+		{"scheduleId", filter.ScheduleID},
+		{"trainerId", filter.TrainerID},
+		{"verification", filter.Verification},
+	} {
+		if legacy[1] != "" {
+			spec.Where(legacy[0], query.Eq, legacy[1])
+		}
+	}
 
-	if !c.isAllowedExtension(ext, allowedExtension...) {
-		log.Println("Not Allowed Ext")
-		common.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid file extension")
+	images, paging, err := c.scheduleImageUseCase.FindActivityProofs(spec)
+	if err != nil {
+		common.SendError(ctx, err)
 		return
 	}
+	var response []interface{}
+	for _, v := range images {
+		response = append(response, v)
+	}
+	common.SendPagedResponse(ctx, response, paging, "Ok")
+}
 
-	if err := ctx.SaveUploadedFile(file, filename); err != nil {
-		log.Println("uploaded file")
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+func (c *ScheduleImageController) listTrainerActivityProofHandler(ctx *gin.Context) {
+	spec, err := query.Parse(ctx.Request.URL.Query())
+	if err != nil {
+		common.SendError(ctx, err)
 		return
 	}
+	userId := ctx.MustGet("userID").(string)
 
-	startTime, endTime := ctx.Query("startTime"), ctx.Query("endTime")
+	images, paging, err := c.scheduleImageUseCase.FindTrainerActivityProofs(userId, spec)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	var response []interface{}
+	for _, v := range images {
+		response = append(response, v)
+	}
+	common.SendPagedResponse(ctx, response, paging, "Ok")
+}
 
-	imageDto, err := c.scheduleImageUseCase.UploadImageActivity(userId, filename, startTime, endTime)
+func (c *ScheduleImageController) getActivityProofHandler(ctx *gin.Context) {
+	image, err := c.scheduleImageUseCase.FindActivityProof(ctx.Param("id"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string))
 	if err != nil {
-		log.Println("upload image activity")
-		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		common.SendError(ctx, err)
 		return
 	}
+	common.SendSingleResponse(ctx, image, "Ok")
+}
 
-	common.SendSingleResponse(ctx, imageDto, "Upload Image successfully")
+func (c *ScheduleImageController) imageHandler(ctx *gin.Context) {
+	c.serveActivityProofFile(ctx, false)
 }
 
-func (c *ScheduleImageController) isAllowedExtension(ext string, allowedExtensions ...string) bool {
-	for _, allowedExtension := range allowedExtensions {
-		if ext == allowedExtension {
-			return true
-		}
+func (c *ScheduleImageController) thumbnailHandler(ctx *gin.Context) {
+	c.serveActivityProofFile(ctx, true)
+}
+
+func (c *ScheduleImageController) serveActivityProofFile(ctx *gin.Context, thumbnail bool) {
+	body, image, err := c.scheduleImageUseCase.OpenActivityProofImage(ctx.Param("id"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string), thumbnail)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	defer body.Close()
+
+	size := image.Size
+	if thumbnail {
+		size = -1
+	}
+	ctx.DataFromReader(http.StatusOK, size, image.MimeType, body, nil)
+}
+
+func (c *ScheduleImageController) reviewActivityProofHandler(ctx *gin.Context) {
+	var payload dto.ActivityProofReviewDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	image, err := c.scheduleImageUseCase.ReviewActivityProof(ctx.Param("id"), ctx.MustGet("userID").(string), payload)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, image, "Activity proof reviewed")
+}
+
+func (c *ScheduleImageController) deleteActivityProofHandler(ctx *gin.Context) {
+	if err := c.scheduleImageUseCase.DeleteActivityProof(ctx.Param("id")); err != nil {
+		common.SendError(ctx, err)
+		return
 	}
+	common.SendDeleteResponse(ctx, "Activity proof deleted")
+}
 
-	return false
+func (c *ScheduleImageController) missingProofHandler(ctx *gin.Context) {
+	spec, err := query.Parse(ctx.Request.URL.Query())
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+
+	schedules, paging, err := c.scheduleImageUseCase.FindSessionsWithoutProof(spec)
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	var response []interface{}
+	for _, v := range schedules {
+		response = append(response, v)
+	}
+	common.SendPagedResponse(ctx, response, paging, "Ok")
 }
 
 func (c *ScheduleImageController) Route() {
 	trainer := c.rg.Group(config.TrainerGroup)
-	trainer.POST(config.UploadActivityProof, c.authMiddleware.RequireToken("trainer"), middleware.FileSizeLimitMiddleware(10<<20), c.UploadImageHandler)
+	trainer.POST(config.UploadActivityProof, c.authMiddleware.RequireToken("trainer"), middleware.FileSizeLimitMiddleware(uploadSizeLimit), c.UploadImageHandler)
+	trainer.GET(config.ActivityProofs, c.authMiddleware.RequireToken("trainer"), c.listTrainerActivityProofHandler)
+
+	admin := c.rg.Group(config.AdminGroup)
+	admin.GET(config.ActivityProofs, c.authMiddleware.RequireToken("admin"), c.listActivityProofHandler)
+	admin.GET(config.ActivityProofMissing, c.authMiddleware.RequireToken("admin"), c.missingProofHandler)
+	admin.PUT(config.ActivityProofReview, c.authMiddleware.RequireToken("admin"), c.reviewActivityProofHandler)
+	admin.DELETE(config.ActivityProofByID, c.authMiddleware.RequireToken("admin"), c.deleteActivityProofHandler)
+
+	c.rg.GET(config.ActivityProofByID, c.authMiddleware.RequireToken("admin", "trainer"), c.getActivityProofHandler)
+	c.rg.GET(config.ActivityProofImage, c.authMiddleware.RequireToken("admin", "trainer"), c.imageHandler)
+	c.rg.GET(config.ActivityProofThumbnail, c.authMiddleware.RequireToken("admin", "trainer"), c.thumbnailHandler)
 }
 
 func NewScheduleImageController(scheduleImageUseCase usecase.ScheduleImageUseCase, authMiddleware middleware.AuthMiddleware, rg *gin.RouterGroup) *ScheduleImageController {
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"instructor-led-app/config"
+	"instructor-led-app/delivery/middleware"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/shared/common"
+	"instructor-led-app/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SpecializationController struct {
+	specializationUC usecase.SpecializationUseCase
+	rg               *gin.RouterGroup
+	authMiddleware   middleware.AuthMiddleware
+}
+
+func (s *SpecializationController) listHandler(ctx *gin.Context) {
+	specializations, err := s.specializationUC.FindSpecializations(ctx.Query("trainerId"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, specializations, "Ok")
+}
+
+func (s *SpecializationController) createHandler(ctx *gin.Context) {
+	var payload dto.SpecializationDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	specialization, err := s.specializationUC.AddSpecialization(payload, ctx.MustGet("userID").(string), ctx.MustGet("role").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendCreateResponse(ctx, specialization, "Created")
+}
+
+func (s *SpecializationController) updateHandler(ctx *gin.Context) {
+	var payload dto.SpecializationDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		common.SendBindError(ctx, err)
+		return
+	}
+
+	specialization, err := s.specializationUC.UpdateSpecialization(ctx.Param("id"), payload, ctx.MustGet("userID").(string), ctx.MustGet("role").(string))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, specialization, "Ok")
+}
+
+func (s *SpecializationController) deleteHandler(ctx *gin.Context) {
+	if err := s.specializationUC.DeleteSpecialization(ctx.Param("id"), ctx.MustGet("userID").(string), ctx.MustGet("role").(string)); err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendDeleteResponse(ctx, "Delete specialization successfully")
+}
+
+func (s *SpecializationController) suggestHandler(ctx *gin.Context) {
+	suggestions, err := s.specializationUC.SuggestTrainers(ctx.Query("activity"), ctx.Query("scheduleId"))
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, suggestions, "Ok")
+}
+
+func (s *SpecializationController) Route() {
+	s.rg.GET(config.Specializations, s.authMiddleware.RequireToken("admin", "trainer"), s.listHandler)
+	s.rg.POST(config.Specializations, s.authMiddleware.RequireToken("admin", "trainer"), s.createHandler)
+	s.rg.PUT(config.SpecializationByID, s.authMiddleware.RequireToken("admin", "trainer"), s.updateHandler)
+	s.rg.DELETE(config.SpecializationByID, s.authMiddleware.RequireToken("admin", "trainer"), s.deleteHandler)
+
+	admin := s.rg.Group(config.AdminGroup)
+	admin.GET(config.TrainerSuggestions, s.authMiddleware.RequireToken("admin"), s.suggestHandler)
+}
+
+func NewSpecializationController(specializationUC usecase.SpecializationUseCase, rg *gin.RouterGroup, auth middleware.AuthMiddleware) *SpecializationController {
+	return &SpecializationController{
+		specializationUC: specializationUC,
+		rg:               rg,
+		authMiddleware:   auth,
+	}
+}
@@ -5,9 +5,9 @@ import (
 	"instructor-led-app/delivery/middleware"
 	"instructor-led-app/entity/dto"
 	"instructor-led-app/shared/common"
+	"instructor-led-app/shared/query"
 	"instructor-led-app/usecase"
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -19,12 +19,20 @@ type TrainerController struct {
 }
 
 func (t *TrainerController) listHandler(ctx *gin.Context) {
-	page, _ := strconv.Atoi(ctx.Query("page"))
-	size, _ := strconv.Atoi(ctx.Query("size"))
+	spec, err := query.Parse(ctx.Request.URL.Query())
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
 
-	trainer, paging, err := t.trainerUc.FindAllTrainer(page, size)
+	// specialization=x is short for filter[specialization][contains]=x
+	if specialization := ctx.Query("specialization"); specialization != "" {
+		spec.Where("specialization", query.Contains, specialization)
+	}
+	trainer, paging, err := t.trainerUc.FindAllTrainer(spec)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		common.SendError(ctx, err)
+		return
 	}
 	var response []interface{}
 	for _, v := range trainer {
@@ -42,7 +50,7 @@ func (t *TrainerController) trainerByIdHandler(ctx *gin.Context) {
 	}
 	trainer, err := t.trainerUc.FindTrainerById(tranerId)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, "trainer with ID "+tranerId+" not found")
+		common.SendError(ctx, err)
 		return
 	}
 	common.SendSingleResponse(ctx, trainer, "Ok")
@@ -52,7 +60,7 @@ func (t *TrainerController) trainerByUserIdHandler(ctx *gin.Context) {
 	tranerId := ctx.Param("id")
 	trainer, err := t.trainerUc.FindTrainerByUserId(tranerId)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, err.Error())
+		common.SendError(ctx, err)
 		return
 	}
 	ctx.JSON(http.StatusOK, gin.H{"data": trainer})
@@ -68,7 +76,7 @@ func (t *TrainerController) deleteHandler(ctx *gin.Context) {
 
 	_, err := t.trainerUc.DeleteTrainer(tranerId)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, "Failed to delete trainer: "+err.Error())
+		common.SendError(ctx, err)
 		return
 	}
 	common.SendSingleResponse(ctx, "Ok", "Delete succesfully")
@@ -85,7 +93,7 @@ func (t *TrainerController) updatedTrainerHandler(ctx *gin.Context) {
 	var trainer dto.TrainerDTO
 
 	if err := ctx.ShouldBindJSON(&trainer); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid request"})
+		common.SendBindError(ctx, err)
 		return
 	}
 
@@ -93,7 +101,7 @@ func (t *TrainerController) updatedTrainerHandler(ctx *gin.Context) {
 
 	updateTrainer, err := t.trainerUc.TrainerUpdated(trainer)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, "Failed to update trainer: "+err.Error())
+		common.SendError(ctx, err)
 		return
 	}
 	common.SendSingleResponse(ctx, updateTrainer, "Ok")
@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"fmt"
+	"instructor-led-app/entity/dto"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// uploadSizeLimit is the cap of the activity proof photos, assignment submissions share it.
+const uploadSizeLimit = 10 << 20
+
+// materialSizeLimit is larger since slide decks easily go past the upload limit.
+const materialSizeLimit = 50 << 20
+
+// attachmentSizeLimit is smaller, question attachments are screenshots and code snippets.
+const attachmentSizeLimit = 5 << 20
+
+var (
+	imageExtensions      = []string{".jpg", ".jpeg", ".png", ".webp"}
+	attachmentExtensions = []string{".jpg", ".jpeg", ".png", ".webp", ".pdf", ".txt", ".md", ".zip", ".go", ".js", ".ts", ".py", ".java", ".sql", ".json"}
+	materialExtensions   = []string{".pdf", ".ppt", ".pptx", ".odp", ".key"}
+)
+
+// readUploadedFile loads the multipart field in memory so it can be hashed and handed to the storage.
+func readUploadedFile(ctx *gin.Context, field string, allowedExtensions []string) (dto.UploadFileDTO, int, error) {
+	file, err := ctx.FormFile(field)
+	if err != nil {
+		log.Println("FormFile")
+		return dto.UploadFileDTO{}, http.StatusBadRequest, err
+	}
+
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	if !isAllowedExtension(ext, allowedExtensions...) {
+		log.Println("Not Allowed Ext")
+		return dto.UploadFileDTO{}, http.StatusBadRequest, fmt.Errorf("Invalid file extension")
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return dto.UploadFileDTO{}, http.StatusInternalServerError, err
+	}
+	defer src.Close()
+
+	content, err := io.ReadAll(src)
+	if err != nil {
+		return dto.UploadFileDTO{}, http.StatusInternalServerError, err
+	}
+
+	return dto.UploadFileDTO{
+		FileName:    filepath.Base(file.Filename),
+		ContentType: file.Header.Get("Content-Type"),
+		Size:        file.Size,
+		Content:     content,
+	}, http.StatusOK, nil
+}
+
+func isAllowedExtension(ext string, allowedExtensions ...string) bool {
+	for _, allowedExtension := range allowedExtensions {
+		if ext == allowedExtension {
+			return true
+		}
+	}
+
+	return false
+}
@@ -4,12 +4,13 @@ import (
 	"instructor-led-app/config"
 	"instructor-led-app/delivery/middleware"
 	"instructor-led-app/entity"
+	"instructor-led-app/entity/dto"
 	"instructor-led-app/shared/common"
+	"instructor-led-app/shared/query"
 	"instructor-led-app/usecase"
 	"log"
 	"net/http"
 	"os"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -21,12 +22,16 @@ type UserController struct {
 }
 
 func (t *UserController) ListHandler(ctx *gin.Context) {
-	page, _ := strconv.Atoi(ctx.Query("page"))
-	size, _ := strconv.Atoi(ctx.Query("size"))
+	spec, err := query.Parse(ctx.Request.URL.Query())
+	if err != nil {
+		common.SendError(ctx, err)
+		return
+	}
 
-	user, paging, err := t.userUC.FindAllUser(page, size)
+	user, paging, err := t.userUC.FindAllUser(spec)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		common.SendError(ctx, err)
+		return
 	}
 	var response []interface{}
 	for _, v := range user {
@@ -53,7 +58,7 @@ func (t *UserController) createdByCsv(c *gin.Context) {
 	// Panggil use case untuk menangani pembacaan dan penyimpanan data dari file CSV
 	users, err := t.userUC.CreatedUserByCsv(filePath)
 	if err != nil {
-		common.SendErrorResponse(c, http.StatusInternalServerError, "Error creating users from CSV: "+err.Error())
+		common.SendError(c, err)
 		return
 	}
 
@@ -69,7 +74,7 @@ func (t *UserController) getById(c *gin.Context) {
 	id := c.Param("id")
 	user, err := t.userUC.FindById(id)
 	if err != nil {
-		common.SendErrorResponse(c, http.StatusNotFound, "User with ID "+id+" not found")
+		common.SendError(c, err)
 		return
 	}
 	common.SendSingleResponse(c, user, "ok")
@@ -78,12 +83,12 @@ func (t *UserController) getById(c *gin.Context) {
 func (t *UserController) create(c *gin.Context) {
 	var data entity.User
 	if err := c.ShouldBindJSON(&data); err != nil {
-		common.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		common.SendBindError(c, err)
 		return
 	}
 	user, err := t.userUC.CreatedUser(data)
 	if err != nil {
-		common.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		common.SendError(c, err)
 		return
 	}
 	common.SendSingleResponse(c, user, "Created")
@@ -99,19 +104,26 @@ func (t *UserController) update(c *gin.Context) {
 	// Pencarian customer berdasarkan ID
 	_, err := t.userUC.FindById(id)
 	if err != nil {
-		common.SendErrorResponse(c, http.StatusNotFound, "User with ID "+id+" not found")
+		common.SendError(c, err)
 		return
 	}
-	var data entity.User
+	var data dto.UserUpdateDTO
 	// Pengecekan dan binding data JSON
 	if err := c.ShouldBindJSON(&data); err != nil {
-		common.SendErrorResponse(c, http.StatusBadRequest, "Invalid JSON data: "+err.Error())
+		common.SendBindError(c, err)
 		return
 	}
 	// Pembaruan user
-	user, err := t.userUC.UpdatedUser(id, data)
+	user, err := t.userUC.UpdatedUser(id, entity.User{
+		Name:         data.Name,
+		Email:        data.Email,
+		Username:     data.Username,
+		Address:      data.Address,
+		Hashpassword: data.Hashpassword,
+		Role:         data.Role,
+	})
 	if err != nil {
-		common.SendErrorResponse(c, http.StatusInternalServerError, "Failed to update customer: "+err.Error())
+		common.SendError(c, err)
 		return
 	}
 	common.SendSingleResponse(c, user, "Updated successfully")
@@ -127,12 +139,12 @@ func (t *UserController) delete(c *gin.Context) {
 	// Pencarian user berdasarkan ID
 	_, err := t.userUC.FindById(id)
 	if err != nil {
-		common.SendErrorResponse(c, http.StatusNotFound, "Customer with ID "+id+" not found")
+		common.SendError(c, err)
 		return
 	}
 	_, err = t.userUC.DeleteUser(id)
 	if err != nil {
-		common.SendErrorResponse(c, http.StatusInternalServerError, "Failed to delete customer: "+err.Error())
+		common.SendError(c, err)
 		return
 	}
 	common.SendSingleResponse(c, "Ok", "Delete successfully")
@@ -142,7 +154,7 @@ func (t *UserController) delete(c *gin.Context) {
 func (t *UserController) Route() {
 	admin := t.rg.Group(config.AdminGroup)
 	admin.POST(config.MasterDataUsersCsv, t.authMiddleware.RequireToken("admin"), t.createdByCsv) //bisa
-	admin.POST(config.MasterDataUsers, t.create)                                                  //bisa
+	admin.POST(config.MasterDataUsers, t.authMiddleware.RequireToken("admin"), t.create)          //bisa
 	admin.GET(config.MasterDataUsers, t.authMiddleware.RequireToken("admin"), t.ListHandler)      //bisa
 	admin.GET(config.MasterDataUserByID, t.authMiddleware.RequireToken("admin"), t.getById)       //bisa
 	admin.PUT(config.MasterDataUserByID, t.authMiddleware.RequireToken("admin"), t.update)        //bisa
@@ -0,0 +1,274 @@
+package docs
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"instructor-led-app/shared/model"
+	"instructor-led-app/shared/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Operation documents one route. Path is the full gin path and the types are zero values
+// of the dto and entity structs the handler binds and answers with.
+type Operation struct {
+	Method  string
+	Path    string
+	Tag     string
+	Summary string
+	// Roles allowed by RequireToken, nil for public routes.
+	Roles []string
+	// Query lists plain ctx.Query parameters, Filter is a struct bound with ShouldBindQuery.
+	Query  []string
+	Filter interface{}
+	// Body is bound with ShouldBindJSON.
+	Body         interface{}
+	BodyOptional bool
+	// Form is a multipart form struct, FormFields its loose ctx.PostForm fields and File its upload.
+	Form         interface{}
+	FormFields   []string
+	File         string
+	FileOptional bool
+	// Response is the data of the envelope, nil when the handler only sends a status.
+	Response interface{}
+	Paged    bool
+	// Listing marks paged lists that also take the sort, cursor and filter parameters of query.Parse.
+	Listing bool
+	// DataOnly marks handlers answering {"data": ...} without the status envelope.
+	DataOnly bool
+	// Status defaults to 200.
+	Status int
+	// Produces is the content type of handlers that don't answer with the JSON envelope.
+	Produces string
+	// Redirect marks handlers that may answer with 302 instead.
+	Redirect bool
+}
+
+const securityScheme = "bearerAuth"
+
+// Build generates the document of the documented operations and reports the routes that are
+// registered without documentation, or documented without being registered.
+func Build(routes gin.RoutesInfo) (openapi.Document, error) {
+	gen := openapi.NewGenerator()
+	doc := openapi.Document{
+		OpenAPI: "3.0.3",
+		Info: openapi.Info{
+			Title:       "Instructor Led API",
+			Description: "Generated from the routes registered by the controllers.",
+			Version:     "1.0.0",
+		},
+		Paths: map[string]map[string]*openapi.Operation{},
+		Components: openapi.Components{
+			SecuritySchemes: map[string]*openapi.SecurityScheme{
+				securityScheme: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+
+	handlers := map[string]string{}
+	for _, route := range routes {
+		handlers[route.Method+" "+route.Path] = route.Handler
+	}
+
+	var unregistered []string
+	documented := map[string]bool{}
+	tags := map[string]bool{}
+	for _, op := range operations {
+		key := op.Method + " " + op.Path
+		handler, ok := handlers[key]
+		if !ok {
+			unregistered = append(unregistered, key)
+			continue
+		}
+		documented[key] = true
+		tags[op.Tag] = true
+
+		path, params := openapi.Path(op.Path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = map[string]*openapi.Operation{}
+		}
+		operation := op.build(gen, params)
+		operation.OperationID = operationID(handler)
+		doc.Paths[path][strings.ToLower(op.Method)] = operation
+	}
+
+	var undocumented []string
+	for _, route := range routes {
+		if key := route.Method + " " + route.Path; !documented[key] {
+			undocumented = append(undocumented, key)
+		}
+	}
+
+	for tag := range tags {
+		doc.Tags = append(doc.Tags, openapi.Tag{Name: tag})
+	}
+	sort.Slice(doc.Tags, func(i, j int) bool { return doc.Tags[i].Name < doc.Tags[j].Name })
+	doc.Components.Schemas = gen.Schemas()
+
+	var problems []string
+	if len(undocumented) > 0 {
+		sort.Strings(undocumented)
+		problems = append(problems, "routes without documentation: "+strings.Join(undocumented, ", "))
+	}
+	if len(unregistered) > 0 {
+		sort.Strings(unregistered)
+		problems = append(problems, "documented routes that aren't registered: "+strings.Join(unregistered, ", "))
+	}
+	if len(problems) > 0 {
+		return doc, fmt.Errorf("openapi: %s", strings.Join(problems, "; "))
+	}
+	return doc, nil
+}
+
+func (op Operation) build(gen *openapi.Generator, pathParams []string) *openapi.Operation {
+	operation := &openapi.Operation{
+		Tags:      []string{op.Tag},
+		Summary:   op.Summary,
+		Responses: map[string]*openapi.Response{},
+	}
+
+	for _, name := range pathParams {
+		operation.Parameters = append(operation.Parameters, openapi.Parameter{
+			Name: name, In: "path", Required: true, Schema: &openapi.Schema{Type: "string"},
+		})
+	}
+	if op.Paged {
+		operation.Parameters = append(operation.Parameters,
+			openapi.Parameter{Name: "page", In: "query", Description: "Page number, starting at 1", Schema: &openapi.Schema{Type: "integer"}},
+			openapi.Parameter{Name: "size", In: "query", Description: "Rows per page", Schema: &openapi.Schema{Type: "integer"}},
+		)
+	}
+	if op.Listing {
+		operation.Parameters = append(operation.Parameters,
+			openapi.Parameter{Name: "cursor", In: "query", Description: "nextCursor of the previous page, replaces page", Schema: &openapi.Schema{Type: "string"}},
+			openapi.Parameter{Name: "sort", In: "query", Description: "Comma separated fields, descending with a leading -, e.g. -createdAt", Schema: &openapi.Schema{Type: "string"}},
+			openapi.Parameter{
+				Name: "filter", In: "query", Style: "deepObject", Explode: true,
+				Description: "filter[field]=value, or filter[field][in|range|contains]=value with comma separated values and range bounds",
+				Schema:      &openapi.Schema{Type: "object", AdditionalProperties: &openapi.Schema{Type: "string"}},
+			},
+		)
+	}
+	for _, name := range op.Query {
+		operation.Parameters = append(operation.Parameters, openapi.Parameter{Name: name, In: "query", Schema: querySchema(name)})
+	}
+	if op.Filter != nil {
+		operation.Parameters = append(operation.Parameters, gen.QueryParameters(reflect.TypeOf(op.Filter))...)
+	}
+
+	switch {
+	case op.Body != nil:
+		operation.RequestBody = &openapi.RequestBody{
+			Required: !op.BodyOptional,
+			Content:  map[string]*openapi.MediaType{"application/json": {Schema: gen.Schema(reflect.TypeOf(op.Body))}},
+		}
+	case op.Form != nil || len(op.FormFields) > 0 || op.File != "":
+		operation.RequestBody = &openapi.RequestBody{
+			Required: true,
+			Content:  map[string]*openapi.MediaType{"multipart/form-data": {Schema: op.formSchema(gen)}},
+		}
+	}
+
+	if op.Roles != nil {
+		operation.Description = "Roles: " + strings.Join(op.Roles, ", ")
+		operation.Security = []map[string][]string{{securityScheme: {}}}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	operation.Responses[fmt.Sprint(status)] = op.response(gen, status)
+	if op.Redirect {
+		operation.Responses[fmt.Sprint(http.StatusFound)] = &openapi.Response{Description: "Redirect to the linked resource"}
+	}
+
+	errorSchema := gen.Schema(reflect.TypeOf(model.ErrorResponse{}))
+	errorResponse := func(description string) *openapi.Response {
+		return &openapi.Response{
+			Description: description,
+			Content:     map[string]*openapi.MediaType{"application/json": {Schema: errorSchema}},
+		}
+	}
+	if operation.RequestBody != nil || op.Filter != nil || op.Listing {
+		operation.Responses["400"] = errorResponse("Invalid request")
+	}
+	if op.Roles != nil {
+		operation.Responses["401"] = errorResponse("Missing or invalid token")
+		operation.Responses["403"] = errorResponse("Role not allowed")
+	}
+	operation.Responses["default"] = errorResponse("Error")
+	return operation
+}
+
+func (op Operation) formSchema(gen *openapi.Generator) *openapi.Schema {
+	schema := &openapi.Schema{Type: "object", Properties: map[string]*openapi.Schema{}}
+	if op.Form != nil {
+		schema = gen.FormSchema(reflect.TypeOf(op.Form))
+	}
+	for _, name := range op.FormFields {
+		schema.Properties[name] = &openapi.Schema{Type: "string"}
+	}
+	if op.File != "" {
+		schema.Properties[op.File] = &openapi.Schema{Type: "string", Format: "binary"}
+		if !op.FileOptional {
+			schema.Required = append(schema.Required, op.File)
+		}
+	}
+	return schema
+}
+
+func (op Operation) response(gen *openapi.Generator, status int) *openapi.Response {
+	response := &openapi.Response{Description: http.StatusText(status)}
+	if status == http.StatusNoContent {
+		return response
+	}
+	if op.Produces != "" {
+		schema := &openapi.Schema{Type: "string", Format: "binary"}
+		if op.Produces == jsonDocument {
+			schema = &openapi.Schema{Type: "object"}
+		}
+		response.Content = map[string]*openapi.MediaType{op.Produces: {Schema: schema}}
+		return response
+	}
+
+	body := &openapi.Schema{Type: "object", Properties: map[string]*openapi.Schema{}}
+	if !op.DataOnly {
+		body.Properties["status"] = gen.Schema(reflect.TypeOf(model.Status{}))
+		body.Required = []string{"status"}
+	}
+	if op.Response != nil {
+		data := gen.Schema(reflect.TypeOf(op.Response))
+		if op.Paged {
+			data = &openapi.Schema{Type: "array", Items: data}
+			body.Properties["paging"] = gen.Schema(reflect.TypeOf(model.Paging{}))
+		}
+		body.Properties["data"] = data
+	}
+	response.Content = map[string]*openapi.MediaType{"application/json": {Schema: body}}
+	return response
+}
+
+func querySchema(name string) *openapi.Schema {
+	switch name {
+	case "startDate", "endDate":
+		return &openapi.Schema{Type: "string", Format: "date"}
+	case "version":
+		return &openapi.Schema{Type: "integer"}
+	}
+	return &openapi.Schema{Type: "string"}
+}
+
+// operationID names an operation after its handler, e.g. CohortController.listHandler.
+func operationID(handler string) string {
+	name := handler[strings.LastIndex(handler, "/")+1:]
+	if _, rest, ok := strings.Cut(name, "."); ok {
+		name = rest
+	}
+	name = strings.TrimSuffix(name, "-fm")
+	return strings.NewReplacer("(*", "", ")", "").Replace(name)
+}
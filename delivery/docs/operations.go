@@ -0,0 +1,241 @@
+package docs
+
+import (
+	"net/http"
+
+	"instructor-led-app/config"
+	"instructor-led-app/entity"
+	"instructor-led-app/entity/dto"
+)
+
+const (
+	api          = config.APIGroup
+	admin        = config.APIGroup + config.AdminGroup
+	trainers     = config.APIGroup + config.TrainerGroup
+	participants = config.APIGroup + config.ParticipantsGroup
+
+	jsonDocument = "application/json"
+	pdf          = "application/pdf"
+	csv          = "text/csv"
+	html         = "text/html"
+	image        = "image/*"
+	octetStream  = "application/octet-stream"
+	eventStream  = "text/event-stream"
+)
+
+var (
+	adminRole       = []string{"admin"}
+	trainerRole     = []string{"trainer"}
+	participantRole = []string{"participant"}
+	staffRoles      = []string{"admin", "trainer"}
+	allRoles        = []string{"admin", "trainer", "participant"}
+)
+
+// issuedCertificates is what issuing certificates of a cohort answers with.
+type issuedCertificates struct {
+	Issued  []entity.Certificate `json:"issued"`
+	Skipped map[string]string    `json:"skipped"`
+}
+
+// operations lists every route the controllers register, grouped like their Route methods.
+var operations = []Operation{
+	// docs
+	{Method: http.MethodGet, Path: api + config.OpenAPISpec, Tag: "Docs", Summary: "OpenAPI specification of this API", Produces: jsonDocument},
+	{Method: http.MethodGet, Path: api + config.APIDocs, Tag: "Docs", Summary: "Interactive API documentation", Produces: html},
+	{Method: http.MethodGet, Path: api + config.APIDocsAsset, Tag: "Docs", Summary: "Swagger UI file of the documentation page", Produces: octetStream},
+
+	// auth
+	{Method: http.MethodPost, Path: api + config.AuthLogin, Tag: "Auth", Summary: "Log in and get a token", Body: dto.AuthRequestDto{}, Response: dto.AuthResponseDto{}, Status: http.StatusCreated},
+
+	// users
+	{Method: http.MethodPost, Path: admin + config.MasterDataUsersCsv, Tag: "Users", Summary: "Create users from a CSV file", Roles: adminRole, File: "file", Response: []entity.User{}},
+	{Method: http.MethodPost, Path: admin + config.MasterDataUsers, Tag: "Users", Summary: "Create a user", Roles: adminRole, Body: entity.User{}, Response: entity.User{}},
+	{Method: http.MethodGet, Path: admin + config.MasterDataUsers, Tag: "Users", Summary: "List users", Roles: adminRole, Response: entity.User{}, Paged: true, Listing: true},
+	{Method: http.MethodGet, Path: admin + config.MasterDataUserByID, Tag: "Users", Summary: "Get a user", Roles: adminRole, Response: entity.User{}},
+	{Method: http.MethodPut, Path: admin + config.MasterDataUserByID, Tag: "Users", Summary: "Update a user", Roles: adminRole, Body: dto.UserUpdateDTO{}, Response: entity.User{}},
+	{Method: http.MethodDelete, Path: admin + config.MasterDataUserByID, Tag: "Users", Summary: "Delete a user", Roles: adminRole, Response: ""},
+
+	// trainers
+	{Method: http.MethodGet, Path: admin + config.MasterDataTrainerByUserID, Tag: "Trainers", Summary: "Get the trainer of a user", Roles: adminRole, Response: dto.TrainerDTO{}, DataOnly: true},
+	{Method: http.MethodGet, Path: admin + config.MasterDataTrainers, Tag: "Trainers", Summary: "List trainers", Roles: adminRole, Query: []string{"specialization"}, Response: entity.Trainer{}, Paged: true, Listing: true},
+	{Method: http.MethodGet, Path: admin + config.MasterDataTrainerByID, Tag: "Trainers", Summary: "Get a trainer", Roles: adminRole, Response: []entity.Trainer{}},
+	{Method: http.MethodPut, Path: admin + config.MasterDataTrainerByID, Tag: "Trainers", Summary: "Update a trainer", Roles: staffRoles, Body: dto.TrainerDTO{}, Response: dto.TrainerDTO{}},
+	{Method: http.MethodDelete, Path: admin + config.MasterDataTrainerByID, Tag: "Trainers", Summary: "Delete a trainer", Roles: adminRole, Response: ""},
+
+	// participants
+	{Method: http.MethodPost, Path: admin + config.MasterDataParticipants, Tag: "Participants", Summary: "Create a participant", Roles: adminRole, Body: dto.ParticipantDTO{}, Response: dto.ParticipantDTO{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: admin + config.MasterDataParticipants, Tag: "Participants", Summary: "List participants", Roles: []string{"trainer", "admin"}, Response: dto.ParticipantDTO{}, Paged: true},
+	{Method: http.MethodGet, Path: admin + config.MasterDataParticipantByID, Tag: "Participants", Summary: "Get a participant", Roles: []string{"trainer", "admin"}, Response: dto.ParticipantDTO{}},
+	{Method: http.MethodPut, Path: admin + config.MasterDataParticipants, Tag: "Participants", Summary: "Update the participant of the caller", Roles: []string{"participant", "admin"}, Body: dto.ParticipantDTO{}, Response: dto.ParticipantDTO{}},
+	{Method: http.MethodPut, Path: admin + config.MasterDataParticipantsRole, Tag: "Participants", Summary: "Change the track of a participant", Roles: adminRole, Body: dto.ParticipantRoleDTO{}},
+	{Method: http.MethodDelete, Path: admin + config.MasterDataParticipantByID, Tag: "Participants", Summary: "Delete a participant", Roles: adminRole},
+
+	// absences
+	{Method: http.MethodPost, Path: api + config.AbsencePost, Tag: "Absences", Summary: "Create the absences of a trainer's sessions", Roles: adminRole, Body: dto.TrainerNameDTO{}, Response: []dto.ParticipantScheduleDTO{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: api + config.ListAbsences, Tag: "Absences", Summary: "List absences", Roles: staffRoles, Query: []string{"startDate", "endDate"}, Response: entity.Absence{}, Paged: true, Listing: true},
+	{Method: http.MethodGet, Path: api + config.ListAbsencesById, Tag: "Absences", Summary: "Get the absence of a participant", Roles: staffRoles, Response: entity.Absence{}},
+	{Method: http.MethodDelete, Path: api + config.DeleteAbsence, Tag: "Absences", Summary: "Delete the absences of a participant", Roles: adminRole},
+	{Method: http.MethodGet, Path: api + config.AbsenceByTrainerScheduleId, Tag: "Absences", Summary: "Get the absences of the trainer's sessions", Roles: trainerRole, Response: dto.AbsenceDTO{}},
+	{Method: http.MethodPut, Path: api + config.AbsenceParticipantByTrainer, Tag: "Absences", Summary: "Check a participant's attendance", Roles: trainerRole, Body: dto.AbsenceCheckDTO{}, Response: dto.AbsenceCheckDTO{}, DataOnly: true},
+
+	// questions
+	{Method: http.MethodGet, Path: api + config.QuestionGetList, Tag: "Questions", Summary: "List questions", Roles: participantRole, Response: entity.Question{}, Paged: true, Listing: true},
+	{Method: http.MethodGet, Path: api + config.QuestionGetById, Tag: "Questions", Summary: "Get a question", Roles: []string{"participant", "trainer"}, Response: entity.Question{}},
+	{Method: http.MethodPost, Path: api + config.QuestionPost, Tag: "Questions", Summary: "Ask a question", Roles: participantRole, Body: entity.Question{}, Response: entity.Question{}, Status: http.StatusCreated},
+	{Method: http.MethodPut, Path: api + config.QuestionGetById, Tag: "Questions", Summary: "Update the status of a question", Roles: trainerRole, Body: dto.QuestionStatusDTO{}, Response: entity.Question{}},
+	{Method: http.MethodDelete, Path: api + config.QuestionDelete, Tag: "Questions", Summary: "Delete a question", Roles: adminRole, Status: http.StatusNoContent},
+	{Method: http.MethodGet, Path: api + config.QuestionTrainer, Tag: "Questions", Summary: "List the questions of the trainer", Roles: trainerRole, Response: entity.Question{}, Paged: true},
+	{Method: http.MethodPost, Path: api + config.QuestionTrainer, Tag: "Questions", Summary: "Create a question for a participant", Roles: trainerRole, Body: dto.QuestionDTO{}, Response: dto.QuestionDTO{}, DataOnly: true},
+	{Method: http.MethodPut, Path: api + config.UpdateQuestionByTrainer, Tag: "Questions", Summary: "Update the status of a participant's question", Roles: trainerRole, Body: dto.QuestionDTO{}, Response: dto.QuestionDTO{}, DataOnly: true},
+	{Method: http.MethodPost, Path: api + config.ParticipantNewQuetion, Tag: "Questions", Summary: "Ask a question as the calling participant", Roles: participantRole, Body: dto.QuestionDto{}, Response: dto.QuestionDto{}, DataOnly: true},
+	{Method: http.MethodPost, Path: api + config.QuestionVote, Tag: "Questions", Summary: "Vote for a question", Roles: participantRole, Response: dto.QuestionVoteDTO{}},
+	{Method: http.MethodDelete, Path: api + config.QuestionVote, Tag: "Questions", Summary: "Remove a vote", Roles: participantRole, Response: dto.QuestionVoteDTO{}},
+	{Method: http.MethodGet, Path: api + config.QuestionBySchedule, Tag: "Questions", Summary: "List the questions of a session", Roles: allRoles, Query: []string{"sort"}, Response: []entity.Question{}},
+
+	// question attachments
+	{Method: http.MethodPost, Path: api + config.QuestionAttachments, Tag: "Question attachments", Summary: "Attach a file to a question", Roles: participantRole, File: "file", Response: entity.QuestionAttachment{}, Status: http.StatusCreated},
+	{Method: http.MethodPost, Path: api + config.AnswerAttachments, Tag: "Question attachments", Summary: "Attach a file to an answer", Roles: trainerRole, File: "file", Response: entity.QuestionAttachment{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: api + config.QuestionAttachments, Tag: "Question attachments", Summary: "List the attachments of a question", Roles: allRoles, Response: []entity.QuestionAttachment{}},
+	{Method: http.MethodGet, Path: api + config.AttachmentDownload, Tag: "Question attachments", Summary: "Download an attachment", Roles: allRoles, Produces: octetStream},
+
+	// schedules
+	{Method: http.MethodPost, Path: api + config.SchedulePost, Tag: "Schedules", Summary: "Create a schedule", Roles: adminRole, Body: dto.ScheduleDto{}, Response: dto.ScheduleDto{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: api + config.ScheduleList, Tag: "Schedules", Summary: "List schedules", Roles: adminRole, Query: []string{"startDate", "endDate"}, Response: entity.Schedule{}, Paged: true, Listing: true},
+	{Method: http.MethodGet, Path: api + config.ScheduleById, Tag: "Schedules", Summary: "List the schedules of a participant", Roles: participantRole, Response: entity.Schedule{}, Paged: true},
+	{Method: http.MethodGet, Path: api + config.ScheduleByTrainerId, Tag: "Schedules", Summary: "List the schedules of the trainer", Roles: trainerRole, Response: entity.Schedule{}, Paged: true},
+	{Method: http.MethodPut, Path: api + config.ScheduleByTrainerId, Tag: "Schedules", Summary: "Assign a trainer to schedules", Roles: adminRole, Body: dto.UpdateAdminDto{}, Response: []entity.Schedule{}, DataOnly: true},
+	{Method: http.MethodDelete, Path: api + config.ScheduleByDate, Tag: "Schedules", Summary: "Delete the schedules of a date", Roles: adminRole},
+	{Method: http.MethodPut, Path: api + config.ScheduleLesson, Tag: "Schedules", Summary: "Link a lesson to a schedule", Roles: adminRole, Body: dto.ScheduleLessonDTO{}, Response: entity.Schedule{}},
+	{Method: http.MethodGet, Path: api + config.ScheduleEvents, Tag: "Schedules", Summary: "Stream the question and absence events of a session, the token may be sent as access_token", Roles: allRoles, Query: []string{"access_token"}, Produces: eventStream},
+
+	// activity proofs
+	{Method: http.MethodPost, Path: trainers + config.UploadActivityProof, Tag: "Activity proofs", Summary: "Upload the activity proof of a session", Roles: trainerRole, FormFields: []string{"scheduleId", "caption"}, File: "image", Response: dto.ScheduleImagesDTO{}},
+	{Method: http.MethodGet, Path: trainers + config.ActivityProofs, Tag: "Activity proofs", Summary: "List the activity proofs of the trainer", Roles: trainerRole, Response: dto.ScheduleImagesDTO{}, Paged: true, Listing: true},
+	{Method: http.MethodGet, Path: admin + config.ActivityProofs, Tag: "Activity proofs", Summary: "List activity proofs", Roles: adminRole, Filter: dto.ActivityProofFilterDTO{}, Response: dto.ScheduleImagesDTO{}, Paged: true, Listing: true},
+	{Method: http.MethodGet, Path: admin + config.ActivityProofMissing, Tag: "Activity proofs", Summary: "List past sessions without an activity proof", Roles: adminRole, Response: entity.Schedule{}, Paged: true, Listing: true},
+	{Method: http.MethodPut, Path: admin + config.ActivityProofReview, Tag: "Activity proofs", Summary: "Review an activity proof", Roles: adminRole, Body: dto.ActivityProofReviewDTO{}, Response: dto.ScheduleImagesDTO{}},
+	{Method: http.MethodDelete, Path: admin + config.ActivityProofByID, Tag: "Activity proofs", Summary: "Delete an activity proof", Roles: adminRole},
+	{Method: http.MethodGet, Path: api + config.ActivityProofByID, Tag: "Activity proofs", Summary: "Get an activity proof", Roles: staffRoles, Response: dto.ScheduleImagesDTO{}},
+	{Method: http.MethodGet, Path: api + config.ActivityProofImage, Tag: "Activity proofs", Summary: "Get the image of an activity proof", Roles: staffRoles, Produces: image},
+	{Method: http.MethodGet, Path: api + config.ActivityProofThumbnail, Tag: "Activity proofs", Summary: "Get the thumbnail of an activity proof", Roles: staffRoles, Produces: image},
+
+	// specializations
+	{Method: http.MethodGet, Path: api + config.Specializations, Tag: "Specializations", Summary: "List specializations", Roles: staffRoles, Query: []string{"trainerId"}, Response: []entity.Specialization{}},
+	{Method: http.MethodPost, Path: api + config.Specializations, Tag: "Specializations", Summary: "Add a specialization", Roles: staffRoles, Body: dto.SpecializationDTO{}, Response: entity.Specialization{}, Status: http.StatusCreated},
+	{Method: http.MethodPut, Path: api + config.SpecializationByID, Tag: "Specializations", Summary: "Update a specialization", Roles: staffRoles, Body: dto.SpecializationDTO{}, Response: entity.Specialization{}},
+	{Method: http.MethodDelete, Path: api + config.SpecializationByID, Tag: "Specializations", Summary: "Delete a specialization", Roles: staffRoles},
+	{Method: http.MethodGet, Path: admin + config.TrainerSuggestions, Tag: "Specializations", Summary: "Suggest trainers for an activity", Roles: adminRole, Query: []string{"activity", "scheduleId"}, Response: []dto.TrainerSuggestionDTO{}},
+
+	// availability
+	{Method: http.MethodGet, Path: api + config.Availability, Tag: "Availability", Summary: "List availability slots", Roles: staffRoles, Query: []string{"trainerId"}, Response: []entity.TrainerAvailability{}},
+	{Method: http.MethodPost, Path: api + config.Availability, Tag: "Availability", Summary: "Add an availability slot", Roles: staffRoles, Body: dto.AvailabilitySlotDTO{}, Response: entity.TrainerAvailability{}, Status: http.StatusCreated},
+	{Method: http.MethodDelete, Path: api + config.AvailabilityByID, Tag: "Availability", Summary: "Delete an availability slot", Roles: staffRoles},
+	{Method: http.MethodGet, Path: api + config.TimeOff, Tag: "Availability", Summary: "List time off", Roles: staffRoles, Query: []string{"trainerId"}, Response: []entity.TrainerTimeOff{}},
+	{Method: http.MethodPost, Path: api + config.TimeOff, Tag: "Availability", Summary: "Add time off", Roles: staffRoles, Body: dto.TimeOffDTO{}, Response: entity.TrainerTimeOff{}, Status: http.StatusCreated},
+	{Method: http.MethodDelete, Path: api + config.TimeOffByID, Tag: "Availability", Summary: "Delete time off", Roles: staffRoles},
+	{Method: http.MethodGet, Path: admin + config.AutoAssignPreview, Tag: "Availability", Summary: "Preview the automatic trainer assignment", Roles: adminRole, Filter: dto.AutoAssignRequestDTO{}, Response: dto.AutoAssignResultDTO{}},
+	{Method: http.MethodPost, Path: admin + config.AutoAssign, Tag: "Availability", Summary: "Assign trainers automatically", Roles: adminRole, Body: dto.AutoAssignRequestDTO{}, BodyOptional: true, Response: dto.AutoAssignResultDTO{}},
+
+	// reports
+	{Method: http.MethodGet, Path: admin + config.ReportTrainerWorkload, Tag: "Reports", Summary: "Trainer workload and honorarium", Roles: adminRole, Filter: dto.TrainerWorkloadFilterDTO{}, Response: dto.TrainerWorkloadReportDTO{}},
+	{Method: http.MethodGet, Path: admin + config.ReportTrainerWorkloadCsv, Tag: "Reports", Summary: "Trainer workload as CSV", Roles: adminRole, Filter: dto.TrainerWorkloadFilterDTO{}, Produces: csv},
+	{Method: http.MethodGet, Path: admin + config.TrainerRates, Tag: "Reports", Summary: "List trainer rates", Roles: adminRole, Response: []dto.TrainerRateDTO{}},
+	{Method: http.MethodPut, Path: admin + config.TrainerRateByTrainerID, Tag: "Reports", Summary: "Set the rate of a trainer", Roles: adminRole, Body: dto.TrainerRateDTO{}, Response: dto.TrainerRateDTO{}},
+	{Method: http.MethodDelete, Path: admin + config.TrainerRateByTrainerID, Tag: "Reports", Summary: "Reset the rate of a trainer to the default", Roles: adminRole},
+
+	// feedback
+	{Method: http.MethodPost, Path: participants + config.Feedback, Tag: "Feedback", Summary: "Rate a session", Roles: participantRole, Body: dto.FeedbackDTO{}, Response: entity.SessionFeedback{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: trainers + config.Feedback, Tag: "Feedback", Summary: "List the anonymous feedback of the trainer", Roles: trainerRole, Response: dto.AnonymousFeedbackDTO{}, Paged: true, Listing: true},
+	{Method: http.MethodGet, Path: admin + config.ReportTrainerRatings, Tag: "Feedback", Summary: "Trainer rating summary", Roles: adminRole, Filter: dto.RatingSummaryFilterDTO{}, Response: []dto.TrainerRatingDTO{}},
+
+	// cohorts
+	{Method: http.MethodGet, Path: admin + config.Cohorts, Tag: "Cohorts", Summary: "List cohorts", Roles: adminRole, Response: entity.Cohort{}, Paged: true, Listing: true},
+	{Method: http.MethodPost, Path: admin + config.Cohorts, Tag: "Cohorts", Summary: "Create a cohort", Roles: adminRole, Body: dto.CohortDTO{}, Response: entity.Cohort{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: admin + config.CohortByID, Tag: "Cohorts", Summary: "Get a cohort", Roles: adminRole, Response: entity.Cohort{}},
+	{Method: http.MethodPut, Path: admin + config.CohortByID, Tag: "Cohorts", Summary: "Update a cohort", Roles: adminRole, Body: dto.CohortDTO{}, Response: entity.Cohort{}},
+	{Method: http.MethodDelete, Path: admin + config.CohortByID, Tag: "Cohorts", Summary: "Delete a cohort", Roles: adminRole},
+	{Method: http.MethodGet, Path: admin + config.CohortEnrollments, Tag: "Cohorts", Summary: "List the enrollments of a cohort", Roles: adminRole, Response: []entity.CohortEnrollment{}},
+	{Method: http.MethodPost, Path: admin + config.CohortEnrollments, Tag: "Cohorts", Summary: "Enroll participants, the ones over capacity are waitlisted", Roles: adminRole, Body: dto.CohortEnrollDTO{}, Response: entity.CohortRoster{}, Status: http.StatusCreated},
+	{Method: http.MethodDelete, Path: admin + config.CohortEnrollment, Tag: "Cohorts", Summary: "Remove a participant from a cohort", Roles: adminRole},
+	{Method: http.MethodGet, Path: admin + config.CohortWaitlist, Tag: "Cohorts", Summary: "List the waitlist of a cohort", Roles: adminRole, Response: []entity.CohortWaitlistEntry{}},
+	{Method: http.MethodGet, Path: participants + config.Cohorts, Tag: "Cohorts", Summary: "List the cohorts of the participant", Roles: participantRole, Response: []entity.ParticipantCohort{}},
+	{Method: http.MethodDelete, Path: participants + config.CohortWithdrawal, Tag: "Cohorts", Summary: "Withdraw from a cohort", Roles: participantRole},
+
+	// promotions
+	{Method: http.MethodGet, Path: participants + config.ParticipantProgress, Tag: "Promotions", Summary: "Progress of the participant", Roles: participantRole, Response: dto.ParticipantProgressDTO{}},
+	{Method: http.MethodGet, Path: admin + config.PromotionCriteria, Tag: "Promotions", Summary: "Get the promotion criteria", Roles: adminRole, Response: entity.PromotionCriteria{}},
+	{Method: http.MethodPut, Path: admin + config.PromotionCriteria, Tag: "Promotions", Summary: "Update the promotion criteria", Roles: adminRole, Body: entity.PromotionCriteria{}, Response: entity.PromotionCriteria{}},
+	{Method: http.MethodGet, Path: admin + config.ProgressReport, Tag: "Promotions", Summary: "Progress of participants", Roles: adminRole, Query: []string{"participantId"}, Response: []dto.ParticipantProgressDTO{}},
+	{Method: http.MethodGet, Path: admin + config.Promotions, Tag: "Promotions", Summary: "List promotion requests", Roles: adminRole, Query: []string{"status"}, Response: entity.PromotionRequest{}, Paged: true, Listing: true},
+	{Method: http.MethodPost, Path: admin + config.PromotionEvaluate, Tag: "Promotions", Summary: "Evaluate every participant against the criteria", Roles: adminRole, Response: []entity.PromotionRequest{}},
+	{Method: http.MethodPut, Path: admin + config.PromotionReview, Tag: "Promotions", Summary: "Approve or reject a promotion request", Roles: adminRole, Body: dto.PromotionReviewDTO{}, Response: entity.PromotionRequest{}},
+	{Method: http.MethodGet, Path: admin + config.TrackHistory, Tag: "Promotions", Summary: "Track history of a participant", Roles: adminRole, Response: []entity.TrackHistory{}},
+
+	// assessments
+	{Method: http.MethodPost, Path: api + config.Assessments, Tag: "Assessments", Summary: "Create an assessment", Roles: trainerRole, Body: dto.AssessmentDTO{}, Response: entity.Assessment{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: api + config.Assessments, Tag: "Assessments", Summary: "List assessments", Roles: allRoles, Query: []string{"scheduleId"}, Response: []entity.Assessment{}},
+	{Method: http.MethodGet, Path: api + config.AssessmentByID, Tag: "Assessments", Summary: "Get an assessment", Roles: allRoles, Response: entity.Assessment{}},
+	{Method: http.MethodDelete, Path: api + config.AssessmentByID, Tag: "Assessments", Summary: "Delete an assessment", Roles: staffRoles},
+	{Method: http.MethodPost, Path: api + config.AssessmentAttempts, Tag: "Assessments", Summary: "Submit an attempt", Roles: participantRole, Body: dto.AssessmentSubmissionDTO{}, Response: entity.AssessmentAttempt{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: api + config.AssessmentAttempts, Tag: "Assessments", Summary: "List the attempts of an assessment", Roles: staffRoles, Response: []entity.AssessmentAttempt{}},
+	{Method: http.MethodGet, Path: api + config.AttemptByID, Tag: "Assessments", Summary: "Get an attempt", Roles: allRoles, Response: entity.AssessmentAttempt{}},
+	{Method: http.MethodPut, Path: api + config.AttemptAnswerGrade, Tag: "Assessments", Summary: "Grade an essay answer", Roles: staffRoles, Body: dto.GradeAnswerDTO{}, Response: entity.AssessmentAttempt{}},
+	{Method: http.MethodGet, Path: participants + config.AssessmentResults, Tag: "Assessments", Summary: "Assessment results of the participant", Roles: participantRole, Response: []entity.AssessmentAttempt{}},
+
+	// assignments
+	{Method: http.MethodPost, Path: api + config.Assignments, Tag: "Assignments", Summary: "Create an assignment", Roles: staffRoles, Body: dto.AssignmentDTO{}, Response: entity.Assignment{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: api + config.Assignments, Tag: "Assignments", Summary: "List assignments", Roles: allRoles, Query: []string{"scheduleId", "cohortId"}, Response: []entity.Assignment{}},
+	{Method: http.MethodGet, Path: api + config.AssignmentByID, Tag: "Assignments", Summary: "Get an assignment", Roles: allRoles, Response: entity.Assignment{}},
+	{Method: http.MethodDelete, Path: api + config.AssignmentByID, Tag: "Assignments", Summary: "Delete an assignment", Roles: staffRoles},
+	{Method: http.MethodPost, Path: api + config.AssignmentSubmissions, Tag: "Assignments", Summary: "Submit an assignment", Roles: participantRole, FormFields: []string{"text"}, File: "file", FileOptional: true, Response: entity.AssignmentSubmission{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: api + config.AssignmentSubmissions, Tag: "Assignments", Summary: "List the submissions of an assignment", Roles: staffRoles, Response: []entity.AssignmentSubmission{}},
+	{Method: http.MethodGet, Path: api + config.SubmissionByID, Tag: "Assignments", Summary: "Get a submission", Roles: allRoles, Response: entity.AssignmentSubmission{}},
+	{Method: http.MethodGet, Path: api + config.SubmissionFile, Tag: "Assignments", Summary: "Download the file of a submission", Roles: allRoles, Produces: octetStream},
+	{Method: http.MethodPut, Path: api + config.SubmissionGrade, Tag: "Assignments", Summary: "Grade a submission", Roles: staffRoles, Body: dto.GradeSubmissionDTO{}, Response: entity.AssignmentSubmission{}},
+	{Method: http.MethodGet, Path: api + config.CohortGradebook, Tag: "Assignments", Summary: "Gradebook of a cohort", Roles: staffRoles, Response: dto.GradebookDTO{}},
+	{Method: http.MethodGet, Path: participants + config.Assignments, Tag: "Assignments", Summary: "List the assignments of the participant", Roles: participantRole, Response: []entity.Assignment{}},
+
+	// certificates
+	{Method: http.MethodGet, Path: api + config.CertificateVerify, Tag: "Certificates", Summary: "Verify a certificate code", Response: dto.CertificateVerificationDTO{}},
+	{Method: http.MethodGet, Path: api + config.CertificatePdf, Tag: "Certificates", Summary: "Download a certificate", Roles: []string{"admin", "participant"}, Produces: pdf},
+	{Method: http.MethodGet, Path: participants + config.Certificates, Tag: "Certificates", Summary: "List the certificates of the participant", Roles: participantRole, Response: []entity.Certificate{}},
+	{Method: http.MethodGet, Path: admin + config.Certificates, Tag: "Certificates", Summary: "List certificates", Roles: adminRole, Query: []string{"cohortId"}, Response: []entity.Certificate{}},
+	{Method: http.MethodGet, Path: admin + config.CertificateEligible, Tag: "Certificates", Summary: "Certificate eligibility of a cohort", Roles: adminRole, Response: []dto.CertificateEligibilityDTO{}},
+	{Method: http.MethodPost, Path: admin + config.CohortCertificates, Tag: "Certificates", Summary: "Issue the certificates of a cohort", Roles: adminRole, Body: dto.IssueCertificateDTO{}, BodyOptional: true, Response: issuedCertificates{}, Status: http.StatusCreated},
+
+	// registrations
+	{Method: http.MethodPost, Path: api + config.Registrations, Tag: "Registrations", Summary: "Register as a participant", Body: dto.RegistrationDTO{}, Response: entity.Registration{}, Status: http.StatusCreated},
+	{Method: http.MethodPost, Path: api + config.RegistrationResend, Tag: "Registrations", Summary: "Resend the verification email", Body: dto.ResendVerificationDTO{}},
+	{Method: http.MethodGet, Path: api + config.RegistrationVerify, Tag: "Registrations", Summary: "Verify the email of a registration", Query: []string{"token"}, Response: entity.Registration{}},
+	{Method: http.MethodGet, Path: admin + config.Registrations, Tag: "Registrations", Summary: "List registrations", Roles: adminRole, Query: []string{"status"}, Response: entity.Registration{}, Paged: true, Listing: true},
+	{Method: http.MethodPut, Path: admin + config.RegistrationReview, Tag: "Registrations", Summary: "Approve or reject a registration", Roles: adminRole, Body: dto.RegistrationReviewDTO{}, Response: entity.Registration{}},
+
+	// curriculum
+	{Method: http.MethodGet, Path: api + config.Courses, Tag: "Curriculum", Summary: "List courses", Roles: allRoles, Query: []string{"track"}, Response: []entity.Course{}},
+	{Method: http.MethodGet, Path: api + config.CourseByID, Tag: "Curriculum", Summary: "Get a course with its modules and lessons", Roles: allRoles, Response: entity.Course{}},
+	{Method: http.MethodPost, Path: api + config.Courses, Tag: "Curriculum", Summary: "Create a course", Roles: adminRole, Body: dto.CourseDTO{}, Response: entity.Course{}, Status: http.StatusCreated},
+	{Method: http.MethodPut, Path: api + config.CourseByID, Tag: "Curriculum", Summary: "Update a course", Roles: adminRole, Body: dto.CourseDTO{}, Response: entity.Course{}},
+	{Method: http.MethodDelete, Path: api + config.CourseByID, Tag: "Curriculum", Summary: "Delete a course", Roles: adminRole},
+	{Method: http.MethodPost, Path: api + config.CourseModules, Tag: "Curriculum", Summary: "Add a module to a course", Roles: adminRole, Body: dto.CourseModuleDTO{}, Response: entity.CourseModule{}, Status: http.StatusCreated},
+	{Method: http.MethodPut, Path: api + config.CourseModuleOrder, Tag: "Curriculum", Summary: "Reorder the modules of a course", Roles: adminRole, Body: dto.ReorderDTO{}, Response: []entity.CourseModule{}},
+	{Method: http.MethodPut, Path: api + config.CourseModuleByID, Tag: "Curriculum", Summary: "Update a module", Roles: adminRole, Body: dto.CourseModuleDTO{}, Response: entity.CourseModule{}},
+	{Method: http.MethodDelete, Path: api + config.CourseModuleByID, Tag: "Curriculum", Summary: "Delete a module", Roles: adminRole},
+	{Method: http.MethodPost, Path: api + config.ModuleLessons, Tag: "Curriculum", Summary: "Add a lesson to a module", Roles: adminRole, Body: dto.LessonDTO{}, Response: entity.Lesson{}, Status: http.StatusCreated},
+	{Method: http.MethodPut, Path: api + config.LessonOrder, Tag: "Curriculum", Summary: "Reorder the lessons of a module", Roles: adminRole, Body: dto.ReorderDTO{}, Response: []entity.Lesson{}},
+	{Method: http.MethodGet, Path: api + config.LessonByID, Tag: "Curriculum", Summary: "Get a lesson", Roles: allRoles, Response: entity.Lesson{}},
+	{Method: http.MethodPut, Path: api + config.LessonByID, Tag: "Curriculum", Summary: "Update a lesson", Roles: adminRole, Body: dto.LessonDTO{}, Response: entity.Lesson{}},
+	{Method: http.MethodDelete, Path: api + config.LessonByID, Tag: "Curriculum", Summary: "Delete a lesson", Roles: adminRole},
+	{Method: http.MethodGet, Path: participants + config.Curriculum, Tag: "Curriculum", Summary: "Curriculum coverage of the participant", Roles: participantRole, Response: []dto.CourseCoverageDTO{}},
+	{Method: http.MethodGet, Path: admin + config.ParticipantCurriculum, Tag: "Curriculum", Summary: "Curriculum coverage of a participant", Roles: adminRole, Response: []dto.CourseCoverageDTO{}},
+
+	// materials
+	{Method: http.MethodPost, Path: api + config.Materials, Tag: "Materials", Summary: "Upload a material, as a file or a link", Roles: staffRoles, Form: dto.MaterialDTO{}, File: "file", FileOptional: true, Response: entity.Material{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: api + config.Materials, Tag: "Materials", Summary: "List materials", Roles: allRoles, Query: []string{"scheduleId", "lessonId"}, Response: []entity.Material{}},
+	{Method: http.MethodGet, Path: api + config.MaterialByID, Tag: "Materials", Summary: "Get a material with its versions", Roles: allRoles, Response: entity.Material{}},
+	{Method: http.MethodPut, Path: api + config.MaterialByID, Tag: "Materials", Summary: "Update a material", Roles: staffRoles, Body: dto.MaterialUpdateDTO{}, Response: entity.Material{}},
+	{Method: http.MethodDelete, Path: api + config.MaterialByID, Tag: "Materials", Summary: "Delete a material", Roles: staffRoles},
+	{Method: http.MethodPost, Path: api + config.MaterialVersions, Tag: "Materials", Summary: "Add a version to a material", Roles: staffRoles, Form: dto.MaterialDTO{}, File: "file", FileOptional: true, Response: entity.Material{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: api + config.MaterialDownload, Tag: "Materials", Summary: "Download a version, the latest by default", Roles: allRoles, Query: []string{"version"}, Produces: octetStream, Redirect: true},
+	{Method: http.MethodGet, Path: api + config.MaterialDownloads, Tag: "Materials", Summary: "Download statistics of a material", Roles: staffRoles, Response: dto.MaterialDownloadsDTO{}},
+	{Method: http.MethodGet, Path: participants + config.Materials, Tag: "Materials", Summary: "Materials of the participant's sessions", Roles: participantRole, Query: []string{"when"}, Response: []entity.SessionMaterials{}},
+}
@@ -0,0 +1,113 @@
+// Command fetch downloads a swagger-ui-dist release from the npm registry, checks the tarball
+// against the integrity the registry publishes for it and writes the files the docs page needs.
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const registry = "https://registry.npmjs.org/swagger-ui-dist/"
+
+// files are copied out of the tarball, LICENSE goes along since the files are redistributed.
+var files = []string{"swagger-ui.css", "swagger-ui-bundle.js", "LICENSE"}
+
+func main() {
+	version := flag.String("version", "", "swagger-ui-dist version to fetch")
+	out := flag.String("out", "dist", "directory to write the files to")
+	flag.Parse()
+	if *version == "" {
+		log.Fatal("-version is required")
+	}
+
+	var release struct {
+		Dist struct {
+			Tarball   string `json:"tarball"`
+			Integrity string `json:"integrity"`
+		} `json:"dist"`
+	}
+	metadata, err := get(registry + *version)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := json.Unmarshal(metadata, &release); err != nil {
+		log.Fatal(err)
+	}
+
+	tarball, err := get(release.Dist.Tarball)
+	if err != nil {
+		log.Fatal(err)
+	}
+	sum := sha512.Sum512(tarball)
+	if got := "sha512-" + base64.StdEncoding.EncodeToString(sum[:]); got != release.Dist.Integrity {
+		log.Fatalf("%s has integrity %s, the registry published %s", release.Dist.Tarball, got, release.Dist.Integrity)
+	}
+
+	if err := extract(tarball, *out); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func get(url string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func extract(tarball []byte, out string) error {
+	gz, err := gzip.NewReader(bytes.NewReader(tarball))
+	if err != nil {
+		return err
+	}
+	wanted := map[string]bool{}
+	for _, file := range files {
+		wanted["package/"+file] = true
+	}
+
+	archive := tar.NewReader(gz)
+	for len(wanted) > 0 {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			var missing []string
+			for name := range wanted {
+				missing = append(missing, name)
+			}
+			return fmt.Errorf("tarball has no %s", strings.Join(missing, ", "))
+		}
+		if err != nil {
+			return err
+		}
+		if !wanted[header.Name] {
+			continue
+		}
+		delete(wanted, header.Name)
+
+		content, err := io.ReadAll(archive)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(out, strings.TrimPrefix(header.Name, "package/")), content, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+// Package swaggerui serves the Swagger UI files of the docs page from the binary, so the page
+// doesn't run scripts from a CDN. The files are fetched once per Version bump with
+// "go generate ./delivery/docs/swaggerui" and committed.
+package swaggerui
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:generate go run ./fetch -version 5.17.14 -out dist
+
+// Version is the swagger-ui-dist release in dist, keep it in line with the go:generate line.
+const Version = "5.17.14"
+
+// Files are the ones the docs page loads.
+var Files = []string{"swagger-ui.css", "swagger-ui-bundle.js"}
+
+//go:embed dist
+var dist embed.FS
+
+// Open returns one of Files, it fails with fs.ErrNotExist for anything else.
+func Open(name string) ([]byte, error) {
+	for _, file := range Files {
+		if file == name {
+			return dist.ReadFile("dist/" + name)
+		}
+	}
+	return nil, fs.ErrNotExist
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"instructor-led-app/repository"
+	"instructor-led-app/shared/common"
 	"instructor-led-app/shared/service"
 	"log"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 
 type AuthMiddleware interface {
 	RequireToken(roles ...string) gin.HandlerFunc
+	RequireStreamToken(roles ...string) gin.HandlerFunc
 }
 
 type authMiddleware struct {
@@ -28,42 +30,59 @@ func (a *authMiddleware) RequireToken(roles ...string) gin.HandlerFunc {
 		var autHeader AuthHeader
 		if err := ctx.ShouldBindHeader(&autHeader); err != nil {
 			log.Printf("RequireToken.autHeader: %v \n", err.Error())
-			ctx.AbortWithStatus(http.StatusUnauthorized)
+			common.SendErrorResponse(ctx, http.StatusUnauthorized, "missing authorization header")
 			return
 		}
 
 		tokenHeader := strings.Replace(autHeader.AuthorizationHeader, "Bearer ", "", -1)
+		a.authorize(ctx, tokenHeader, roles)
+	}
+}
+
+// RequireStreamToken is RequireToken for EventSource clients, which can't send headers,
+// so the token may also come from the access_token query.
+func (a *authMiddleware) RequireStreamToken(roles ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		tokenHeader := strings.Replace(ctx.GetHeader("Authorization"), "Bearer ", "", -1)
 		if tokenHeader == "" {
-			log.Printf("RequireToken.tokenHeader \n")
-			ctx.AbortWithStatus(http.StatusUnauthorized)
-			return
+			tokenHeader = ctx.Query("access_token")
 		}
+		a.authorize(ctx, tokenHeader, roles)
+	}
+}
 
-		claims, err := a.jwtService.ParseToken(tokenHeader)
-		if err != nil {
-			log.Printf("RequireToken.ParseToken: %v \n", err.Error())
-			ctx.AbortWithStatus(http.StatusUnauthorized)
-			return
-		}
-		ctx.Set("userID", claims["userId"])
+func (a *authMiddleware) authorize(ctx *gin.Context, tokenHeader string, roles []string) {
+	if tokenHeader == "" {
+		log.Printf("RequireToken.tokenHeader \n")
+		common.SendErrorResponse(ctx, http.StatusUnauthorized, "missing token")
+		return
+	}
 
-		validRole := false
-		// admin, user, other....
-		for _, role := range roles {
-			if role == claims["role"] {
-				validRole = true
-				break
-			}
-		}
+	claims, err := a.jwtService.ParseToken(tokenHeader)
+	if err != nil {
+		log.Printf("RequireToken.ParseToken: %v \n", err.Error())
+		common.SendErrorResponse(ctx, http.StatusUnauthorized, "invalid or expired token")
+		return
+	}
+	ctx.Set("userID", claims["userId"])
+	ctx.Set("role", claims["role"])
 
-		if !validRole {
-			log.Printf("RequireToken.validRole\n")
-			ctx.AbortWithStatus(http.StatusForbidden)
-			return
+	validRole := false
+	// admin, user, other....
+	for _, role := range roles {
+		if role == claims["role"] {
+			validRole = true
+			break
 		}
+	}
 
-		ctx.Next()
+	if !validRole {
+		log.Printf("RequireToken.validRole\n")
+		common.SendErrorResponse(ctx, http.StatusForbidden, "you don't have access to this resource")
+		return
 	}
+
+	ctx.Next()
 }
 
 func NewAuthMiddleware(jwtService service.JwtService) AuthMiddleware {
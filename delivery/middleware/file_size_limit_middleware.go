@@ -7,16 +7,43 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func FileSizeLimitMiddleware(maxSize int64) gin.HandlerFunc {
+// FileSizeLimitMiddleware rejects requests carrying a file bigger than maxSize.
+// When fields are given only those are checked, otherwise every uploaded file is.
+func FileSizeLimitMiddleware(maxSize int64, fields ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		file, _ := c.FormFile("image")
+		// leave room for the other form fields and multipart boundaries
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+(1<<20))
 
-		if file != nil && file.Size > maxSize {
-			common.SendErrorResponse(c, http.StatusBadRequest, "File size exceeds the limit")
-			c.Abort()
+		form, err := c.MultipartForm()
+		if err != nil {
+			common.SendErrorResponse(c, http.StatusBadRequest, "Invalid or too large upload")
 			return
 		}
 
+		for field, files := range form.File {
+			if !isCheckedField(field, fields) {
+				continue
+			}
+			for _, file := range files {
+				if file.Size > maxSize {
+					common.SendErrorResponse(c, http.StatusRequestEntityTooLarge, "File size exceeds the limit")
+					return
+				}
+			}
+		}
+
 		c.Next()
 	}
 }
+
+func isCheckedField(field string, fields []string) bool {
+	if len(fields) == 0 {
+		return true
+	}
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
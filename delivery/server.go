@@ -8,6 +8,7 @@ import (
 	"instructor-led-app/delivery/middleware"
 	"instructor-led-app/repository"
 	"instructor-led-app/shared/service"
+	"instructor-led-app/shared/validation"
 	"instructor-led-app/usecase"
 	"log"
 
@@ -24,7 +25,21 @@ type Server struct {
 	absenceUC            usecase.AbsenceUseCase
 	scheduleUC           usecase.ScheduleUseCase
 	scheduleImageUseCase usecase.ScheduleImageUseCase
+	attachmentUC         usecase.QuestionAttachmentUseCase
+	specializationUC     usecase.SpecializationUseCase
+	availabilityUC       usecase.AvailabilityUseCase
+	reportUC             usecase.ReportUseCase
+	feedbackUC           usecase.FeedbackUseCase
+	cohortUC             usecase.CohortUseCase
+	promotionUC          usecase.PromotionUseCase
+	assessmentUC         usecase.AssessmentUseCase
+	assignmentUC         usecase.AssignmentUseCase
+	certificateUC        usecase.CertificateUseCase
+	registrationUC       usecase.RegistrationUseCase
+	curriculumUC         usecase.CurriculumUseCase
+	materialUC           usecase.MaterialUseCase
 	jwtService           service.JwtService
+	eventBroker          service.EventBroker
 	engine               *gin.Engine
 	port                 string
 }
@@ -41,6 +56,28 @@ func (s *Server) initRoute() {
 	controller.NewQuestionController(s.questionUc, s.scheduleUC, s.trainerUseCase, s.participantUseCase, s.userUc, rg, authMiddleware).Route()
 	controller.NewScheduleController(s.scheduleUC, s.userUc, s.trainerUseCase, rg, authMiddleware).Route()
 	controller.NewScheduleImageController(s.scheduleImageUseCase, authMiddleware, rg).Route()
+	controller.NewQuestionAttachmentController(s.attachmentUC, rg, authMiddleware).Route()
+	controller.NewEventController(s.scheduleUC, s.eventBroker, rg, authMiddleware).Route()
+	controller.NewSpecializationController(s.specializationUC, rg, authMiddleware).Route()
+	controller.NewAvailabilityController(s.availabilityUC, rg, authMiddleware).Route()
+	controller.NewReportController(s.reportUC, rg, authMiddleware).Route()
+	controller.NewFeedbackController(s.feedbackUC, rg, authMiddleware).Route()
+	controller.NewCohortController(s.cohortUC, rg, authMiddleware).Route()
+	controller.NewPromotionController(s.promotionUC, rg, authMiddleware).Route()
+	controller.NewAssessmentController(s.assessmentUC, rg, authMiddleware).Route()
+	controller.NewAssignmentController(s.assignmentUC, rg, authMiddleware).Route()
+	controller.NewCertificateController(s.certificateUC, rg, authMiddleware).Route()
+	controller.NewRegistrationController(s.registrationUC, rg, authMiddleware).Route()
+	controller.NewCurriculumController(s.curriculumUC, rg, authMiddleware).Route()
+	controller.NewMaterialController(s.materialUC, rg, authMiddleware).Route()
+	controller.NewDocsController(s.engine.Routes, rg).Route()
+}
+
+// Routes lists the routes the server registers without connecting to its dependencies.
+func Routes() gin.RoutesInfo {
+	s := &Server{engine: gin.New()}
+	s.initRoute()
+	return s.engine.Routes()
 }
 
 func (s *Server) Run() {
@@ -65,21 +102,59 @@ func NewServer() *Server {
 	trainerRepo := repository.NewTrainerRepository(db)
 
 	jwtService := service.NewJwtService(config.TokenConfig)
+	eventBroker := service.NewEventBroker()
+	fileStorage, err := service.NewFileStorage(config.StorageConfig)
+	if err != nil {
+		log.Fatalf("storage can't be initialized: %v", err)
+	}
+	mailer, err := service.NewMailer(config.MailConfig)
+	if err != nil {
+		log.Fatalf("mailer can't be initialized: %v", err)
+	}
 	participantRepository := repository.NewParticipantRepository(db)
 	questionRepo := repository.NewQuestionRepository(db)
 	scheduleImageRepository := repository.NewScheduleImagesRepository(db)
+	attachmentRepo := repository.NewQuestionAttachmentRepository(db)
+	specializationRepo := repository.NewSpecializationRepository(db)
+	availabilityRepo := repository.NewAvailabilityRepository(db)
+	reportRepo := repository.NewReportRepository(db)
+	feedbackRepo := repository.NewFeedbackRepository(db)
+	cohortRepo := repository.NewCohortRepository(db)
+	promotionRepo := repository.NewPromotionRepository(db)
+	assessmentRepo := repository.NewAssessmentRepository(db)
+	assignmentRepo := repository.NewAssignmentRepository(db)
+	certificateRepo := repository.NewCertificateRepository(db)
+	registrationRepo := repository.NewRegistrationRepository(db)
+	curriculumRepo := repository.NewCurriculumRepository(db)
+	materialRepo := repository.NewMaterialRepository(db)
 	// usecase
 	trainerUseCase := usecase.NewTrainerUseCase(trainerRepo)
-	absenceUC := usecase.NewAbsenceUseCase(absenceRepo, participantRepository, scheduleRepo, userRepo, trainerRepo)
 	participantUseCase := usecase.NewParticipantUseCase(participantRepository)
+	cohortUC := usecase.NewCohortUseCase(cohortRepo, participantUseCase, mailer)
+	promotionUC := usecase.NewPromotionUseCase(promotionRepo, cohortRepo, cohortUC, participantUseCase)
+	absenceUC := usecase.NewAbsenceUseCase(absenceRepo, participantRepository, scheduleRepo, userRepo, trainerRepo, eventBroker, promotionUC)
 	UserUsecase := usecase.NewUserUsecase(userRepo)
-	questionUsecase := usecase.NewQuestionUseCase(questionRepo, participantRepository, scheduleRepo, userRepo, trainerRepo, participantUseCase, trainerUseCase)
-	scheduleUC := usecase.NewScheduleUseCase(scheduleRepo, trainerUseCase)
-	scheduleImageUseCase := usecase.NewScheduleImageUseCase(scheduleImageRepository, scheduleUC)
+	availabilityUC := usecase.NewAvailabilityUseCase(availabilityRepo, scheduleRepo, trainerUseCase, config.SessionConfig)
+	scheduleUC := usecase.NewScheduleUseCase(scheduleRepo, trainerUseCase, participantUseCase, availabilityUC, cohortRepo, curriculumRepo)
+	questionUsecase := usecase.NewQuestionUseCase(questionRepo, participantRepository, scheduleRepo, userRepo, trainerRepo, participantUseCase, trainerUseCase, scheduleUC, eventBroker)
+	scheduleImageUseCase := usecase.NewScheduleImageUseCase(scheduleImageRepository, scheduleUC, trainerUseCase, fileStorage, service.NewImageProcessor(), config.SessionConfig)
+	attachmentUC := usecase.NewQuestionAttachmentUseCase(attachmentRepo, questionRepo, scheduleRepo, trainerUseCase, participantUseCase, fileStorage)
+	specializationUC := usecase.NewSpecializationUseCase(specializationRepo, trainerUseCase, scheduleUC)
+	reportUC := usecase.NewReportUseCase(reportRepo, trainerUseCase, config.SessionConfig, config.HonorariumConfig)
+	feedbackUC := usecase.NewFeedbackUseCase(feedbackRepo, scheduleUC, participantUseCase, trainerUseCase, config.SessionConfig)
+	assessmentUC := usecase.NewAssessmentUseCase(assessmentRepo, scheduleUC, trainerUseCase, participantUseCase, promotionUC)
+	assignmentUC := usecase.NewAssignmentUseCase(assignmentRepo, cohortRepo, scheduleUC, trainerUseCase, participantUseCase, fileStorage)
+	certificateUC := usecase.NewCertificateUseCase(certificateRepo, cohortRepo, participantUseCase, service.NewCertificateRenderer(), config.CertificateConfig)
+	registrationUC := usecase.NewRegistrationUseCase(registrationRepo, mailer, config.RegistrationConfig)
+	curriculumUC := usecase.NewCurriculumUseCase(curriculumRepo, participantUseCase)
+	materialUC := usecase.NewMaterialUseCase(materialRepo, scheduleUC, curriculumUC, participantUseCase, fileStorage)
 
 	authUc := usecase.NewAuthUseCase(UserUsecase, jwtService)
 
 	engine := gin.Default()
+	if err := validation.Register(); err != nil {
+		log.Fatalf("validation can't be initialized: %v", err)
+	}
 	port := fmt.Sprintf(":%s", config.ApiPort)
 	return &Server{
 		trainerUseCase,
@@ -90,7 +165,21 @@ func NewServer() *Server {
 		absenceUC,
 		scheduleUC,
 		scheduleImageUseCase,
+		attachmentUC,
+		specializationUC,
+		availabilityUC,
+		reportUC,
+		feedbackUC,
+		cohortUC,
+		promotionUC,
+		assessmentUC,
+		assignmentUC,
+		certificateUC,
+		registrationUC,
+		curriculumUC,
+		materialUC,
 		jwtService,
+		eventBroker,
 		engine,
 		port,
 	}
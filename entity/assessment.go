@@ -0,0 +1,51 @@
+package entity
+
+import "time"
+
+type Assessment struct {
+	ID          string               `json:"id"`
+	ScheduleID  string               `json:"scheduleId"`
+	TrainerID   string               `json:"trainerId"`
+	Title       string               `json:"title"`
+	Description string               `json:"description"`
+	OpensAt     time.Time            `json:"opensAt"`
+	ClosesAt    time.Time            `json:"closesAt"`
+	Questions   []AssessmentQuestion `json:"questions,omitempty"`
+	CreatedAt   time.Time            `json:"createdAt"`
+	UpdatedAt   time.Time            `json:"updatedAt"`
+}
+
+// AssessmentQuestion.CorrectOption is hidden from participants.
+type AssessmentQuestion struct {
+	ID            string   `json:"id"`
+	AssessmentID  string   `json:"assessmentId"`
+	Position      int      `json:"position"`
+	Kind          string   `json:"kind"`
+	Prompt        string   `json:"prompt"`
+	Options       []string `json:"options,omitempty"`
+	CorrectOption *int     `json:"correctOption,omitempty"`
+	Points        float64  `json:"points"`
+}
+
+type AssessmentAttempt struct {
+	ID              string             `json:"id"`
+	AssessmentID    string             `json:"assessmentId"`
+	ParticipantID   string             `json:"participantId"`
+	ParticipantName string             `json:"participantName"`
+	Status          string             `json:"status"`
+	Score           *float64           `json:"score"`
+	MaxScore        float64            `json:"maxScore"`
+	SubmittedAt     time.Time          `json:"submittedAt"`
+	Answers         []AssessmentAnswer `json:"answers,omitempty"`
+}
+
+type AssessmentAnswer struct {
+	ID             string     `json:"id"`
+	AttemptID      string     `json:"attemptId"`
+	QuestionID     string     `json:"questionId"`
+	SelectedOption *int       `json:"selectedOption,omitempty"`
+	AnswerText     string     `json:"answerText,omitempty"`
+	Points         *float64   `json:"points"`
+	Feedback       string     `json:"feedback,omitempty"`
+	GradedAt       *time.Time `json:"gradedAt,omitempty"`
+}
@@ -0,0 +1,49 @@
+package entity
+
+import "time"
+
+type Assignment struct {
+	ID           string            `json:"id"`
+	ScheduleID   string            `json:"scheduleId,omitempty"`
+	CohortID     string            `json:"cohortId,omitempty"`
+	TrainerID    string            `json:"trainerId"`
+	Title        string            `json:"title"`
+	Instructions string            `json:"instructions"`
+	DueAt        time.Time         `json:"dueAt"`
+	MaxScore     float64           `json:"maxScore"`
+	Rubric       []RubricCriterion `json:"rubric"`
+	CreatedAt    time.Time         `json:"createdAt"`
+	UpdatedAt    time.Time         `json:"updatedAt"`
+}
+
+type RubricCriterion struct {
+	ID        string  `json:"id"`
+	Position  int     `json:"position"`
+	Name      string  `json:"name"`
+	MaxPoints float64 `json:"maxPoints"`
+}
+
+type AssignmentSubmission struct {
+	ID              string        `json:"id"`
+	AssignmentID    string        `json:"assignmentId"`
+	ParticipantID   string        `json:"participantId"`
+	ParticipantName string        `json:"participantName"`
+	Text            string        `json:"text,omitempty"`
+	FileName        string        `json:"fileName,omitempty"`
+	StorageKey      string        `json:"-"`
+	Size            int64         `json:"size,omitempty"`
+	MimeType        string        `json:"mimeType,omitempty"`
+	IsLate          bool          `json:"isLate"`
+	Status          string        `json:"status"`
+	Score           *float64      `json:"score"`
+	Feedback        string        `json:"feedback,omitempty"`
+	RubricScores    []RubricScore `json:"rubricScores,omitempty"`
+	GradedAt        *time.Time    `json:"gradedAt,omitempty"`
+	SubmittedAt     time.Time     `json:"submittedAt"`
+}
+
+type RubricScore struct {
+	CriterionID string  `json:"criterionId"`
+	Points      float64 `json:"points"`
+	Comment     string  `json:"comment,omitempty"`
+}
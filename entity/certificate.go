@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+type Certificate struct {
+	ID              string    `json:"id"`
+	Code            string    `json:"code"`
+	ParticipantID   string    `json:"participantId"`
+	CohortID        string    `json:"cohortId"`
+	ParticipantName string    `json:"participantName"`
+	Track           string    `json:"track"`
+	CohortName      string    `json:"cohortName"`
+	TrainerNames    string    `json:"trainerNames"`
+	StartDate       time.Time `json:"startDate"`
+	EndDate         time.Time `json:"endDate"`
+	AttendanceRate  float64   `json:"attendanceRate"`
+	Score           *float64  `json:"score"`
+	IssuedAt        time.Time `json:"issuedAt"`
+}
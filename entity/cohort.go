@@ -0,0 +1,49 @@
+package entity
+
+import "time"
+
+type Cohort struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Track      string    `json:"track"`
+	Capacity   int       `json:"capacity"`
+	Members    int       `json:"members"`
+	Waitlisted int       `json:"waitlisted"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+type CohortEnrollment struct {
+	ID            string    `json:"id"`
+	CohortID      string    `json:"cohortId"`
+	ParticipantID string    `json:"participantId"`
+	Name          string    `json:"name"`
+	Track         string    `json:"track"`
+	EnrolledAt    time.Time `json:"enrolledAt"`
+}
+
+type CohortWaitlistEntry struct {
+	ID            string    `json:"id"`
+	CohortID      string    `json:"cohortId"`
+	ParticipantID string    `json:"participantId"`
+	Name          string    `json:"name"`
+	Track         string    `json:"track"`
+	Position      int       `json:"position"`
+	JoinedAt      time.Time `json:"joinedAt"`
+}
+
+// CohortRoster is who holds a seat in the cohort and who is waiting for one.
+type CohortRoster struct {
+	Enrollments []CohortEnrollment    `json:"enrollments"`
+	Waitlist    []CohortWaitlistEntry `json:"waitlist"`
+}
+
+// ParticipantCohort is one of a participant's cohorts, Position is only set while Waitlisted.
+type ParticipantCohort struct {
+	CohortID string    `json:"cohortId"`
+	Name     string    `json:"name"`
+	Track    string    `json:"track"`
+	Status   string    `json:"status"`
+	Position int       `json:"position,omitempty"`
+	Since    time.Time `json:"since"`
+}
@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+type Course struct {
+	ID          string         `json:"id"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Track       string         `json:"track,omitempty"`
+	Modules     []CourseModule `json:"modules,omitempty"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+}
+
+type CourseModule struct {
+	ID          string    `json:"id"`
+	CourseID    string    `json:"courseId"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Position    int       `json:"position"`
+	Lessons     []Lesson  `json:"lessons,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type Lesson struct {
+	ID              string    `json:"id"`
+	ModuleID        string    `json:"moduleId"`
+	Title           string    `json:"title"`
+	Objectives      []string  `json:"objectives"`
+	DurationMinutes int       `json:"durationMinutes"`
+	Position        int       `json:"position"`
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
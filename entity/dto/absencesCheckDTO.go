@@ -4,9 +4,9 @@ import "time"
 
 type AbsenceCheckDTO struct {
 	ID             string    `json:"id"`
-	Name           string    `json:"name"`
+	Name           string    `json:"name" binding:"required"`
 	Information    string    `json:"information"`
-	Absence_status string    `json:"absenceStatus"`
+	Absence_status string    `json:"absenceStatus" binding:"required,oneof='Present' 'Not Present'"`
 	Absence_time   time.Time `json:"absenceTime"`
 	Updated_at     time.Time `json:"updatedAt"`
 }
@@ -0,0 +1,36 @@
+package dto
+
+import "time"
+
+type AssessmentDTO struct {
+	ScheduleID  string                  `json:"scheduleId" binding:"required,uuid"`
+	Title       string                  `json:"title" binding:"required,max=150"`
+	Description string                  `json:"description"`
+	OpensAt     time.Time               `json:"opensAt" binding:"required"`
+	ClosesAt    time.Time               `json:"closesAt" binding:"required,gtfield=OpensAt"`
+	Questions   []AssessmentQuestionDTO `json:"questions" binding:"required,min=1,dive"`
+}
+
+// AssessmentQuestionDTO.CorrectOption is the zero-based index into Options.
+type AssessmentQuestionDTO struct {
+	Kind          string   `json:"kind" binding:"required,oneof=multiple_choice short_answer"`
+	Prompt        string   `json:"prompt" binding:"required"`
+	Options       []string `json:"options"`
+	CorrectOption *int     `json:"correctOption" binding:"omitempty,min=0"`
+	Points        float64  `json:"points" binding:"gte=0"`
+}
+
+type AssessmentSubmissionDTO struct {
+	Answers []AssessmentAnswerDTO `json:"answers" binding:"dive"`
+}
+
+type AssessmentAnswerDTO struct {
+	QuestionID     string `json:"questionId" binding:"required,uuid"`
+	SelectedOption *int   `json:"selectedOption" binding:"omitempty,min=0"`
+	AnswerText     string `json:"answerText"`
+}
+
+type GradeAnswerDTO struct {
+	Points   float64 `json:"points" binding:"gte=0"`
+	Feedback string  `json:"feedback"`
+}
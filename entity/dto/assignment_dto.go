@@ -0,0 +1,61 @@
+package dto
+
+import "time"
+
+// AssignmentDTO targets either ScheduleID or CohortID. TrainerID is only read
+// from admins creating cohort work; trainers always own what they create.
+type AssignmentDTO struct {
+	ScheduleID   string               `json:"scheduleId" binding:"required_without=CohortID,excluded_with=CohortID,omitempty,uuid"`
+	CohortID     string               `json:"cohortId" binding:"omitempty,uuid"`
+	TrainerID    string               `json:"trainerId" binding:"omitempty,uuid"`
+	Title        string               `json:"title" binding:"required,max=150"`
+	Instructions string               `json:"instructions"`
+	DueAt        time.Time            `json:"dueAt" binding:"required"`
+	Rubric       []RubricCriterionDTO `json:"rubric" binding:"required,min=1,dive"`
+}
+
+type RubricCriterionDTO struct {
+	Name      string  `json:"name" binding:"required,max=100"`
+	MaxPoints float64 `json:"maxPoints" binding:"gt=0"`
+}
+
+type GradeSubmissionDTO struct {
+	Scores   []RubricScoreDTO `json:"scores" binding:"required,min=1,dive"`
+	Feedback string           `json:"feedback"`
+}
+
+type RubricScoreDTO struct {
+	CriterionID string  `json:"criterionId" binding:"required,uuid"`
+	Points      float64 `json:"points" binding:"gte=0"`
+	Comment     string  `json:"comment"`
+}
+
+type GradebookDTO struct {
+	CohortID    string               `json:"cohortId"`
+	Assignments []GradebookColumnDTO `json:"assignments"`
+	Rows        []GradebookRowDTO    `json:"rows"`
+}
+
+type GradebookColumnDTO struct {
+	AssignmentID string    `json:"assignmentId"`
+	Title        string    `json:"title"`
+	DueAt        time.Time `json:"dueAt"`
+	MaxScore     float64   `json:"maxScore"`
+}
+
+// GradebookRowDTO.Average is the mean percentage over graded work only.
+type GradebookRowDTO struct {
+	ParticipantID string              `json:"participantId"`
+	Name          string              `json:"name"`
+	Grades        []GradebookEntryDTO `json:"grades"`
+	Average       *float64            `json:"average"`
+	Missing       int                 `json:"missing"`
+	Late          int                 `json:"late"`
+}
+
+type GradebookEntryDTO struct {
+	AssignmentID string   `json:"assignmentId"`
+	Status       string   `json:"status"`
+	Score        *float64 `json:"score"`
+	IsLate       bool     `json:"isLate"`
+}
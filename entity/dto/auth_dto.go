@@ -1,8 +1,8 @@
 package dto
 
 type AuthRequestDto struct {
-	Email        string `json:"email"`
-	HashPassword string `json:"hashPassword"`
+	Email        string `json:"email" binding:"required,email"`
+	HashPassword string `json:"hashPassword" binding:"required"`
 }
 
 type AuthResponseDto struct {
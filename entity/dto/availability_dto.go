@@ -0,0 +1,48 @@
+package dto
+
+import "time"
+
+type AvailabilitySlotDTO struct {
+	TrainerID string `json:"trainerId" binding:"omitempty,uuid"`
+	DayOfWeek int    `json:"dayOfWeek" binding:"min=0,max=6"`
+	StartTime string `json:"startTime" binding:"required,clock"`
+	EndTime   string `json:"endTime" binding:"required,clock"`
+}
+
+type TimeOffDTO struct {
+	TrainerID string `json:"trainerId" binding:"omitempty,uuid"`
+	StartDate string `json:"startDate" binding:"required,date"`
+	EndDate   string `json:"endDate" binding:"omitempty,date"`
+	Reason    string `json:"reason"`
+}
+
+type AutoAssignRequestDTO struct {
+	StartDate string `json:"startDate" form:"startDate" binding:"omitempty,date"`
+	EndDate   string `json:"endDate" form:"endDate" binding:"omitempty,date"`
+}
+
+// SessionDTO is one session, a schedule row.
+type SessionDTO struct {
+	ScheduleID string    `json:"scheduleId"`
+	Date       time.Time `json:"date"`
+	TrainerID  string    `json:"trainerId"`
+	Activity   string    `json:"activity"`
+	CohortID   string    `json:"cohortId,omitempty"`
+}
+
+type AutoAssignmentDTO struct {
+	ScheduleID       string    `json:"scheduleId"`
+	Date             time.Time `json:"date"`
+	Activity         string    `json:"activity"`
+	CohortID         string    `json:"cohortId,omitempty"`
+	CurrentTrainerID string    `json:"currentTrainerId"`
+	TrainerID        string    `json:"trainerId,omitempty"`
+	Status           string    `json:"status"`
+	Reason           string    `json:"reason"`
+}
+
+type AutoAssignResultDTO struct {
+	Committed   bool                `json:"committed"`
+	Assignments []AutoAssignmentDTO `json:"assignments"`
+	Workload    map[string]int      `json:"workload"`
+}
@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+type CertificateEligibilityDTO struct {
+	ParticipantID    string   `json:"participantId"`
+	Name             string   `json:"name"`
+	Track            string   `json:"track"`
+	SessionsAttended int      `json:"sessionsAttended"`
+	SessionsRecorded int      `json:"sessionsRecorded"`
+	AttendanceRate   float64  `json:"attendanceRate"`
+	Score            *float64 `json:"score"`
+	AlreadyIssued    bool     `json:"alreadyIssued"`
+	Eligible         bool     `json:"eligible"`
+	Unmet            []string `json:"unmet,omitempty"`
+}
+
+// IssueCertificateDTO issues to every eligible participant when ParticipantIDs is empty.
+type IssueCertificateDTO struct {
+	ParticipantIDs []string `json:"participantIds" binding:"omitempty,dive,uuid"`
+}
+
+// CertificateVerificationDTO is all the public verify endpoint reveals.
+type CertificateVerificationDTO struct {
+	Valid           bool      `json:"valid"`
+	Code            string    `json:"code"`
+	ParticipantName string    `json:"participantName,omitempty"`
+	Track           string    `json:"track,omitempty"`
+	CohortName      string    `json:"cohortName,omitempty"`
+	TrainerNames    string    `json:"trainerNames,omitempty"`
+	StartDate       string    `json:"startDate,omitempty"`
+	EndDate         string    `json:"endDate,omitempty"`
+	IssuedAt        time.Time `json:"issuedAt,omitempty"`
+}
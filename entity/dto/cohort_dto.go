@@ -0,0 +1,12 @@
+package dto
+
+// CohortDTO leaves the cohort unlimited when Capacity is 0.
+type CohortDTO struct {
+	Name     string `json:"name" binding:"required,max=100"`
+	Track    string `json:"track" binding:"omitempty,oneof=Basic Advance"`
+	Capacity int    `json:"capacity" binding:"min=0"`
+}
+
+type CohortEnrollDTO struct {
+	ParticipantIDs []string `json:"participantIds" binding:"required,min=1,dive,uuid"`
+}
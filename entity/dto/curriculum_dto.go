@@ -0,0 +1,71 @@
+package dto
+
+import "time"
+
+type CourseDTO struct {
+	Title       string `json:"title" binding:"required,max=150"`
+	Description string `json:"description"`
+	Track       string `json:"track" binding:"omitempty,oneof=Basic Advance"`
+}
+
+// CourseModuleDTO is used for new and updated modules; new ones are appended at the end of the course.
+type CourseModuleDTO struct {
+	Title       string `json:"title" binding:"required,max=150"`
+	Description string `json:"description"`
+}
+
+type LessonDTO struct {
+	Title           string   `json:"title" binding:"required,max=150"`
+	Objectives      []string `json:"objectives"`
+	DurationMinutes int      `json:"durationMinutes" binding:"gt=0"`
+}
+
+// ReorderDTO lists every module of a course, or every lesson of a module, in the new order.
+type ReorderDTO struct {
+	IDs []string `json:"ids" binding:"required,min=1,dive,uuid"`
+}
+
+type ScheduleLessonDTO struct {
+	LessonID string `json:"lessonId" binding:"omitempty,uuid"`
+}
+
+// LessonCoverageDTO tells whether a participant already attended a session delivering the lesson.
+// Status is Covered, Scheduled when an upcoming session delivers it, or Pending.
+type LessonCoverageDTO struct {
+	LessonID        string     `json:"lessonId"`
+	Title           string     `json:"title"`
+	Position        int        `json:"position"`
+	DurationMinutes int        `json:"durationMinutes"`
+	Status          string     `json:"status"`
+	CoveredOn       *time.Time `json:"coveredOn,omitempty"`
+	NextSessionOn   *time.Time `json:"nextSessionOn,omitempty"`
+}
+
+type ModuleCoverageDTO struct {
+	ModuleID string              `json:"moduleId"`
+	Title    string              `json:"title"`
+	Position int                 `json:"position"`
+	Lessons  []LessonCoverageDTO `json:"lessons"`
+}
+
+type CourseCoverageDTO struct {
+	CourseID       string              `json:"courseId"`
+	Title          string              `json:"title"`
+	TotalLessons   int                 `json:"totalLessons"`
+	CoveredLessons int                 `json:"coveredLessons"`
+	PendingLessons int                 `json:"pendingLessons"`
+	CoveredMinutes int                 `json:"coveredMinutes"`
+	TotalMinutes   int                 `json:"totalMinutes"`
+	Progress       float64             `json:"progress"`
+	Modules        []ModuleCoverageDTO `json:"modules"`
+}
+
+// CoverageRowDTO is one lesson of the coverage query together with its course and module.
+type CoverageRowDTO struct {
+	CourseID    string
+	CourseTitle string
+	ModuleID    string
+	ModuleTitle string
+	ModulePos   int
+	Lesson      LessonCoverageDTO
+}
@@ -0,0 +1,37 @@
+package dto
+
+import "time"
+
+type FeedbackDTO struct {
+	ScheduleID string `json:"scheduleId" binding:"required,uuid"`
+	Rating     int    `json:"rating" binding:"min=1,max=5"`
+	Comment    string `json:"comment" binding:"max=1000"`
+}
+
+// AnonymousFeedbackDTO is what trainers get to see: the session's activity and date,
+// never who of the cohort gave the rating.
+type AnonymousFeedbackDTO struct {
+	ID           string    `json:"id"`
+	Activity     string    `json:"activity"`
+	ScheduleDate time.Time `json:"scheduleDate"`
+	Rating       int       `json:"rating"`
+	Comment      string    `json:"comment"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+type RatingSummaryFilterDTO struct {
+	StartDate string `form:"startDate" binding:"omitempty,date"`
+	EndDate   string `form:"endDate" binding:"omitempty,date"`
+	TrainerID string `form:"trainerId" binding:"omitempty,uuid"`
+	Interval  string `form:"interval" binding:"omitempty,oneof=week month year"`
+}
+
+type TrainerRatingDTO struct {
+	TrainerID     string    `json:"trainerId"`
+	TrainerName   string    `json:"trainerName"`
+	Period        time.Time `json:"period"`
+	Responses     int       `json:"responses"`
+	AverageRating float64   `json:"averageRating"`
+	LowestRating  int       `json:"lowestRating"`
+	HighestRating int       `json:"highestRating"`
+}
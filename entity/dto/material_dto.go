@@ -0,0 +1,39 @@
+package dto
+
+import "time"
+
+// MaterialDTO is read from the multipart form; a material is either a file or a URL.
+type MaterialDTO struct {
+	ScheduleID  string `form:"scheduleId" binding:"omitempty,uuid"`
+	LessonID    string `form:"lessonId" binding:"omitempty,uuid"`
+	Title       string `form:"title" binding:"max=150"`
+	Description string `form:"description"`
+	URL         string `form:"url" binding:"omitempty,url"`
+	Note        string `form:"note"`
+}
+
+type MaterialUpdateDTO struct {
+	Title       string `json:"title" binding:"required,max=150"`
+	Description string `json:"description"`
+}
+
+type MaterialVersionDownloadsDTO struct {
+	Version   int `json:"version"`
+	Downloads int `json:"downloads"`
+}
+
+type MaterialUserDownloadsDTO struct {
+	UserID         string    `json:"userId"`
+	Name           string    `json:"name"`
+	Role           string    `json:"role"`
+	Downloads      int       `json:"downloads"`
+	LastDownloaded time.Time `json:"lastDownloaded"`
+}
+
+type MaterialDownloadsDTO struct {
+	MaterialID     string                        `json:"materialId"`
+	TotalDownloads int                           `json:"totalDownloads"`
+	UniqueUsers    int                           `json:"uniqueUsers"`
+	ByVersion      []MaterialVersionDownloadsDTO `json:"byVersion"`
+	Users          []MaterialUserDownloadsDTO    `json:"users"`
+}
@@ -1,6 +1,6 @@
 package dto
 
 type ParticipantRoleDTO struct {
-	Name string `json:"name"`
-	Role string `json:"role"`
+	Name string `json:"name" binding:"required"`
+	Role string `json:"role" binding:"required,oneof=Basic Advance"`
 }
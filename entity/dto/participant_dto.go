@@ -2,11 +2,11 @@ package dto
 
 type ParticipantDTO struct {
 	ID            string        `json:"id"`
-	DateOfBirth   string        `json:"dateOfBirth"`
-	PlaceOfBirth  string        `json:"placeOfBirth"`
-	LastEducation string        `json:"lastEducation"`
-	UserID        string        `json:"userId"`
-	Role          string        `json:"role"`
+	DateOfBirth   string        `json:"dateOfBirth" binding:"omitempty,date"`
+	PlaceOfBirth  string        `json:"placeOfBirth" binding:"max=100"`
+	LastEducation string        `json:"lastEducation" binding:"max=100"`
+	UserID        string        `json:"userId" binding:"omitempty,uuid"`
+	Role          string        `json:"role" binding:"omitempty,oneof=Basic Advance"`
 	Schedules     []ScheduleDto `json:"schedule"`
 }
 
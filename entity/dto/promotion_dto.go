@@ -0,0 +1,21 @@
+package dto
+
+// ParticipantProgressDTO is what promotion is judged on. AssessmentScore stays
+// nil while the participant has no graded assessment.
+type ParticipantProgressDTO struct {
+	ParticipantID     string   `json:"participantId"`
+	Name              string   `json:"name"`
+	Track             string   `json:"track"`
+	SessionsCompleted int      `json:"sessionsCompleted"`
+	SessionsRecorded  int      `json:"sessionsRecorded"`
+	AttendanceRate    float64  `json:"attendanceRate"`
+	AssessmentScore   *float64 `json:"assessmentScore"`
+	Eligible          bool     `json:"eligible"`
+	Unmet             []string `json:"unmet,omitempty"`
+}
+
+type PromotionReviewDTO struct {
+	Status   string `json:"status" binding:"required,oneof=Approved Rejected"`
+	Reason   string `json:"reason" binding:"required_if=Status Rejected"`
+	CohortID string `json:"cohortId" binding:"omitempty,uuid"`
+}
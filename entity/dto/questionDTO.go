@@ -4,10 +4,10 @@ import "time"
 
 type QuestionDTO struct {
 	ID              string    `json:"id"`
-	ParticipantName string    `json:"participantName"`
+	ParticipantName string    `json:"participantName" binding:"required"`
 	Question        string    `json:"question"`
-	Answer          string    `json:"answer"`
-	Status          string    `json:"status"`
+	Answer          string    `json:"answer" binding:"required"`
+	Status          string    `json:"status" binding:"required,oneof=Finished Process"`
 	TrainerID       string    `json:"trainerId"`
 	ParticipantID   string    `json:"participantId"`
 	ScheduleID      string    `json:"ScheduleId"`
@@ -16,10 +16,21 @@ type QuestionDTO struct {
 }
 type QuestionDto struct {
 	ID            string    `json:"id"`
-	Question      string    `json:"question"`
+	Question      string    `json:"question" binding:"required"`
 	TrainerID     string    `json:"trainerId"`
 	ParticipantID string    `json:"participantId"`
 	ScheduleID    string    `json:"ScheduleId"`
+	IsAnonymous   bool      `json:"isAnonymous"`
 	CreatedAt     time.Time `json:"createdAt"`
 	UpdatedAt     time.Time `json:"updatedAt"`
 }
+
+// QuestionStatusDTO is the trainer's update of a question, the status is all it changes.
+type QuestionStatusDTO struct {
+	Status string `json:"status" binding:"required,oneof=Finished Process"`
+}
+
+type QuestionVoteDTO struct {
+	QuestionID string `json:"questionId"`
+	Votes      int    `json:"votes"`
+}
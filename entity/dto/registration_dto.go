@@ -0,0 +1,23 @@
+package dto
+
+type RegistrationDTO struct {
+	Name          string `json:"name" binding:"required,max=100"`
+	Email         string `json:"email" binding:"required,email,max=100"`
+	Username      string `json:"username" binding:"required,max=100"`
+	Password      string `json:"password" binding:"required,min=8"`
+	Address       string `json:"address"`
+	DateOfBirth   string `json:"dateOfBirth" binding:"omitempty,date"`
+	PlaceOfBirth  string `json:"placeOfBirth" binding:"max=100"`
+	LastEducation string `json:"lastEducation" binding:"max=100"`
+}
+
+type ResendVerificationDTO struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// RegistrationReviewDTO approves a registration into a track, or rejects it with a reason.
+type RegistrationReviewDTO struct {
+	Status string `json:"status" binding:"required,oneof=Approved Rejected"`
+	Track  string `json:"track" binding:"required_if=Status Approved,omitempty,oneof=Basic Advance"`
+	Reason string `json:"reason" binding:"required_if=Status Rejected"`
+}
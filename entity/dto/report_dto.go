@@ -0,0 +1,37 @@
+package dto
+
+import "time"
+
+type TrainerWorkloadFilterDTO struct {
+	StartDate string `form:"startDate" binding:"omitempty,date"`
+	EndDate   string `form:"endDate" binding:"omitempty,date"`
+	TrainerID string `form:"trainerId" binding:"omitempty,uuid"`
+}
+
+type TrainerWorkloadDTO struct {
+	TrainerID          string  `json:"trainerId"`
+	TrainerName        string  `json:"trainerName"`
+	SessionsScheduled  int     `json:"sessionsScheduled"`
+	SessionsDelivered  int     `json:"sessionsDelivered"`
+	AttendanceSessions int     `json:"attendanceSessions"`
+	AttendanceRecords  int     `json:"attendanceRecords"`
+	QuestionsAnswered  int     `json:"questionsAnswered"`
+	TeachingHours      float64 `json:"teachingHours"`
+	RatePerSession     float64 `json:"ratePerSession"`
+	CustomRate         bool    `json:"customRate"`
+	PayableAmount      float64 `json:"payableAmount"`
+}
+
+type TrainerWorkloadReportDTO struct {
+	StartDate time.Time            `json:"startDate"`
+	EndDate   time.Time            `json:"endDate"`
+	Trainers  []TrainerWorkloadDTO `json:"trainers"`
+	Total     float64              `json:"totalPayable"`
+}
+
+type TrainerRateDTO struct {
+	TrainerID      string    `json:"trainerId" binding:"omitempty,uuid"`
+	TrainerName    string    `json:"trainerName,omitempty"`
+	RatePerSession float64   `json:"ratePerSession" binding:"min=0"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
@@ -1,6 +1,6 @@
 package dto
 
 type UpdateAdminDto struct {
-	TrainerName string `json:"trainerName"`
+	TrainerName string `json:"trainerName" binding:"required"`
 	CodeDate    int    `json:"CodeDate"`
 }
@@ -4,10 +4,12 @@ import "time"
 
 type ScheduleDto struct {
 	ID            string        `json:"id"`
-	Activity      string        `json:"activity"`
-	Date          string        `json:"date"`
-	TrainerID     string        `json:"trainerId"`
-	ParticipantID string        `json:"participantId"`
+	Activity      string        `json:"activity" binding:"required,max=45"`
+	Date          string        `json:"date" binding:"required,date"`
+	TrainerID     string        `json:"trainerId" binding:"required,uuid"`
+	ParticipantID string        `json:"participantId,omitempty" binding:"required_without=CohortID,omitempty,uuid"`
+	CohortID      string        `json:"cohortId,omitempty" binding:"omitempty,uuid"`
+	LessonID      string        `json:"lessonId,omitempty" binding:"omitempty,uuid"`
 	Day           string        `json:"day"`
 	Question      []QuestionDTO `json:"question"`
 	CreatedAt     time.Time     `json:"createdAt"`
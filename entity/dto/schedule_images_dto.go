@@ -1,7 +1,40 @@
 package dto
 
+import "time"
+
 type ScheduleImagesDTO struct {
-	ID         string `json:"id"`
-	ScheduleID string `json:"scheduleId"`
-	FileName   string `json:"filename"`
+	ID               string     `json:"id"`
+	ScheduleID       string     `json:"scheduleId"`
+	TrainerID        string     `json:"trainerId,omitempty"`
+	ScheduleDate     *time.Time `json:"scheduleDate,omitempty"`
+	FileName         string     `json:"filename"`
+	StorageKey       string     `json:"storageKey"`
+	Size             int64      `json:"size"`
+	MimeType         string     `json:"mimeType"`
+	Checksum         string     `json:"checksum"`
+	SourceChecksum   string     `json:"-"`
+	ThumbnailKey     string     `json:"thumbnailKey"`
+	Caption          string     `json:"caption"`
+	CapturedAt       *time.Time `json:"capturedAt,omitempty"`
+	Latitude         *float64   `json:"latitude,omitempty"`
+	Longitude        *float64   `json:"longitude,omitempty"`
+	Verification     string     `json:"verification"`
+	VerificationNote string     `json:"verificationNote"`
+	ReviewStatus     string     `json:"reviewStatus"`
+	ReviewReason     string     `json:"reviewReason"`
+	ReviewedBy       string     `json:"reviewedBy,omitempty"`
+	ReviewedAt       *time.Time `json:"reviewedAt,omitempty"`
+	CreatedAt        time.Time  `json:"createdAt"`
+}
+
+type ActivityProofFilterDTO struct {
+	Status       string `form:"status" binding:"omitempty,oneof=Pending Approved Rejected"`
+	ScheduleID   string `form:"scheduleId" binding:"omitempty,uuid"`
+	TrainerID    string `form:"trainerId" binding:"omitempty,uuid"`
+	Verification string `form:"verification" binding:"omitempty,oneof=match mismatch no_metadata"`
+}
+
+type ActivityProofReviewDTO struct {
+	Status string `json:"status" binding:"required,oneof=Approved Rejected"`
+	Reason string `json:"reason" binding:"required_if=Status Rejected"`
 }
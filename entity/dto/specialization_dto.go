@@ -0,0 +1,14 @@
+package dto
+
+type SpecializationDTO struct {
+	TrainerID string `json:"trainerId" binding:"omitempty,uuid"`
+	Name      string `json:"name" binding:"required,max=100"`
+}
+
+type TrainerSuggestionDTO struct {
+	TrainerID       string   `json:"trainerId"`
+	Name            string   `json:"name"`
+	PhoneNumber     string   `json:"phoneNumber"`
+	Specializations []string `json:"specializations"`
+	MatchCount      int      `json:"matchCount"`
+}
@@ -1,5 +1,5 @@
 package dto
 
 type TrainerNameDTO struct {
-	Name string `json:"name"`
+	Name string `json:"name" binding:"required"`
 }
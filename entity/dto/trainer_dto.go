@@ -2,6 +2,6 @@ package dto
 
 type TrainerDTO struct {
 	ID          string `json:"id"`
-	PhoneNumber string `json:"phoneNumber"`
-	UserID      string `json:"userId"`
+	PhoneNumber string `json:"phoneNumber" binding:"omitempty,max=13,phone"`
+	UserID      string `json:"userId" binding:"omitempty,uuid"`
 }
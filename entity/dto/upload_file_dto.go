@@ -0,0 +1,8 @@
+package dto
+
+type UploadFileDTO struct {
+	FileName    string
+	ContentType string
+	Size        int64
+	Content     []byte
+}
@@ -0,0 +1,11 @@
+package dto
+
+// UserUpdateDTO only changes the fields that are set.
+type UserUpdateDTO struct {
+	Name         string `json:"name" binding:"max=100"`
+	Email        string `json:"email" binding:"omitempty,email,max=100"`
+	Username     string `json:"username" binding:"max=100"`
+	Address      string `json:"address"`
+	Hashpassword string `json:"hashPassword"`
+	Role         string `json:"role" binding:"omitempty,oneof=admin trainer participant"`
+}
@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+// Material describes the file or link of its current version; older versions stay downloadable.
+type Material struct {
+	ID             string            `json:"id"`
+	ScheduleID     string            `json:"scheduleId,omitempty"`
+	LessonID       string            `json:"lessonId,omitempty"`
+	Title          string            `json:"title"`
+	Description    string            `json:"description"`
+	Kind           string            `json:"kind"`
+	CurrentVersion int               `json:"currentVersion"`
+	FileName       string            `json:"fileName,omitempty"`
+	Size           int64             `json:"size,omitempty"`
+	MimeType       string            `json:"mimeType,omitempty"`
+	URL            string            `json:"url,omitempty"`
+	UploadedBy     string            `json:"uploadedBy"`
+	Downloads      int               `json:"downloads"`
+	Versions       []MaterialVersion `json:"versions,omitempty"`
+	CreatedAt      time.Time         `json:"createdAt"`
+	UpdatedAt      time.Time         `json:"updatedAt"`
+}
+
+type MaterialVersion struct {
+	ID         string    `json:"id"`
+	MaterialID string    `json:"materialId"`
+	Version    int       `json:"version"`
+	Kind       string    `json:"kind"`
+	FileName   string    `json:"fileName,omitempty"`
+	StorageKey string    `json:"-"`
+	Size       int64     `json:"size,omitempty"`
+	MimeType   string    `json:"mimeType,omitempty"`
+	Checksum   string    `json:"checksum,omitempty"`
+	URL        string    `json:"url,omitempty"`
+	Note       string    `json:"note,omitempty"`
+	UploadedBy string    `json:"uploadedBy"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// SessionMaterials groups what a participant can read for one of their sessions.
+type SessionMaterials struct {
+	ScheduleID string     `json:"scheduleId"`
+	Activity   string     `json:"activity"`
+	Date       time.Time  `json:"date"`
+	Upcoming   bool       `json:"upcoming"`
+	Materials  []Material `json:"materials"`
+}
@@ -0,0 +1,37 @@
+package entity
+
+import "time"
+
+type PromotionCriteria struct {
+	MinAttendanceRate    float64   `json:"minAttendanceRate" binding:"min=0,max=100"`
+	MinAssessmentScore   float64   `json:"minAssessmentScore" binding:"min=0,max=100"`
+	MinSessionsCompleted int       `json:"minSessionsCompleted" binding:"min=0"`
+	UpdatedAt            time.Time `json:"updatedAt"`
+}
+
+type PromotionRequest struct {
+	ID                string     `json:"id"`
+	ParticipantID     string     `json:"participantId"`
+	ParticipantName   string     `json:"participantName"`
+	FromTrack         string     `json:"fromTrack"`
+	ToTrack           string     `json:"toTrack"`
+	AttendanceRate    float64    `json:"attendanceRate"`
+	AssessmentScore   *float64   `json:"assessmentScore"`
+	SessionsCompleted int        `json:"sessionsCompleted"`
+	Status            string     `json:"status"`
+	Reason            string     `json:"reason"`
+	ReviewedBy        string     `json:"reviewedBy,omitempty"`
+	ReviewedAt        *time.Time `json:"reviewedAt,omitempty"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	Waitlisted        bool       `json:"waitlisted,omitempty"` // the approval's cohort was full
+}
+
+type TrackHistory struct {
+	ID            string    `json:"id"`
+	ParticipantID string    `json:"participantId"`
+	FromTrack     string    `json:"fromTrack"`
+	ToTrack       string    `json:"toTrack"`
+	Source        string    `json:"source"`
+	PromotionID   string    `json:"promotionId,omitempty"`
+	ChangedAt     time.Time `json:"changedAt"`
+}
@@ -4,12 +4,14 @@ import "time"
 
 type Question struct {
 	ID            string    `json:"id"`
-	Question      string    `json:"question"`
+	Question      string    `json:"question" binding:"required"`
 	Answer        string    `json:"answer"`
-	Status        string    `json:"status"`
-	ParticipantID string    `json:"participantId"`
-	TrainerID     string    `json:"trainerId"`
-	ScheduleID    string    `json:"scheduleId"`
+	Status        string    `json:"status" binding:"omitempty,oneof=Finished Process"`
+	ParticipantID string    `json:"participantId" binding:"omitempty,uuid"`
+	TrainerID     string    `json:"trainerId" binding:"omitempty,uuid"`
+	ScheduleID    string    `json:"scheduleId" binding:"omitempty,uuid"`
+	IsAnonymous   bool      `json:"isAnonymous"`
+	Votes         int       `json:"votes"`
 	CreatedAt     time.Time `json:"createdAt"`
 	UpdatedAt     time.Time `json:"updatedAt"`
 }
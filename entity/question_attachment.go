@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+type QuestionAttachment struct {
+	ID           string    `json:"id"`
+	QuestionID   string    `json:"questionId"`
+	Kind         string    `json:"kind"`
+	OriginalName string    `json:"originalName"`
+	StorageKey   string    `json:"-"`
+	Size         int64     `json:"size,omitempty"`
+	MimeType     string    `json:"mimeType,omitempty"`
+	UploadedBy   string    `json:"uploadedBy"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
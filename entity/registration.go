@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+type Registration struct {
+	ID            string     `json:"id"`
+	Name          string     `json:"name"`
+	Email         string     `json:"email"`
+	Username      string     `json:"username"`
+	Address       string     `json:"address"`
+	DateOfBirth   string     `json:"dateOfBirth"`
+	PlaceOfBirth  string     `json:"placeOfBirth"`
+	LastEducation string     `json:"lastEducation"`
+	Status        string     `json:"status"`
+	VerifiedAt    *time.Time `json:"verifiedAt,omitempty"`
+	Track         string     `json:"track,omitempty"`
+	Reason        string     `json:"reason,omitempty"`
+	UserID        string     `json:"userId,omitempty"`
+	ReviewedBy    string     `json:"reviewedBy,omitempty"`
+	ReviewedAt    *time.Time `json:"reviewedAt,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
@@ -7,7 +7,9 @@ type Schedule struct {
 	Activity      string    `json:"activity"`
 	Date          time.Time `json:"date"`
 	TrainerID     string    `json:"trainerId"`
-	ParticipantID string    `json:"participantId"`
+	ParticipantID string    `json:"participantId,omitempty"`
+	CohortID      string    `json:"cohortId,omitempty"`
+	LessonID      string    `json:"lessonId,omitempty"`
 	Day           string    `json:"day"`
 	CreatedAt     time.Time `json:"createdAt"`
 	UpdatedAt     time.Time `json:"updatedAt"`
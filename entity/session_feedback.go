@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+type SessionFeedback struct {
+	ID            string    `json:"id"`
+	ScheduleID    string    `json:"scheduleId"`
+	TrainerID     string    `json:"trainerId"`
+	ParticipantID string    `json:"participantId"`
+	Rating        int       `json:"rating"`
+	Comment       string    `json:"comment"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+type TrainerAvailability struct {
+	ID        string    `json:"id"`
+	TrainerID string    `json:"trainerId"`
+	DayOfWeek int       `json:"dayOfWeek"`
+	StartTime string    `json:"startTime"`
+	EndTime   string    `json:"endTime"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type TrainerTimeOff struct {
+	ID        string    `json:"id"`
+	TrainerID string    `json:"trainerId"`
+	StartDate time.Time `json:"startDate"`
+	EndDate   time.Time `json:"endDate"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"createdAt"`
+}
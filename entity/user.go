@@ -4,12 +4,12 @@ import "time"
 
 type User struct {
 	Id           string    `json:"id"`
-	Name         string    `json:"name"`
-	Email        string    `json:"email"`
-	Username     string    `json:"username"`
-	Address      string    `json:"address"`
-	Hashpassword string    `json:"hashPassword"`
-	Role         string    `json:"role"`
+	Name         string    `json:"name" binding:"required,max=100"`
+	Email        string    `json:"email" binding:"required,email,max=100"`
+	Username     string    `json:"username" binding:"required,max=100"`
+	Address      string    `json:"address" binding:"required"`
+	Hashpassword string    `json:"hashPassword" binding:"required"`
+	Role         string    `json:"role" binding:"required,oneof=admin trainer participant"`
 	CreatedAt    time.Time `json:"createdAt"`
 	UpdatedAt    time.Time `json:"updatedAt"`
 }
@@ -2,20 +2,18 @@ package repository
 
 import (
 	"database/sql"
-	"errors"
 	"fmt"
 	"instructor-led-app/config"
 	"instructor-led-app/entity"
 	"instructor-led-app/entity/dto"
+	"instructor-led-app/shared/apperror"
 	"instructor-led-app/shared/model"
+	"instructor-led-app/shared/query"
 	"log"
-	"math"
-	"time"
 )
 
 type AbsenceRepository interface {
-	List(page, size int) ([]entity.Absence, model.Paging, error)
-	ListByDate(startDate, endDate time.Time, page, size int) ([]entity.Absence, model.Paging, error)
+	List(spec query.Spec) ([]entity.Absence, model.Paging, error)
 	GetAbsencesByParticipantID(id string) (entity.Absence, error)
 	GetAbsencesByScheduleID(id string) (dto.AbsenceDTO, error)
 	Create(payload []dto.ParticipantScheduleDTO) ([]dto.ParticipantScheduleDTO, error)
@@ -74,45 +72,12 @@ func (a *absenceRepository) GetAbsencesByParticipantID(id string) (entity.Absenc
 	return absence, nil
 }
 
-// ListByDate implements AbsenceRepository.
-func (a *absenceRepository) ListByDate(startDate time.Time, endDate time.Time, page int, size int) ([]entity.Absence, model.Paging, error) {
-	var absences []entity.Absence
-	offset := (page - 1) * size
-	rows, err := a.db.Query(config.ListAbsencebyDate, startDate, endDate, size, offset)
-	if err != nil {
-		return nil, model.Paging{}, err
-	}
-
-	for rows.Next() {
-		var absence entity.Absence
-		err := rows.Scan(&absence.ID, &absence.Date, &absence.Information, &absence.Absence_status, &absence.Absence_time, &absence.Participant_id, &absence.Created_at, &absence.Updated_at)
-		if err != nil {
-			return nil, model.Paging{}, err
-		}
-		absences = append(absences, absence)
-	}
-
-	totalRows := 0
-	if err := a.db.QueryRow("SELECT COUNT(*) FROM absences").Scan(&totalRows); err != nil {
-		return nil, model.Paging{}, err
-	}
-
-	paging := model.Paging{
-		Page:        page,
-		RowsPerPage: size,
-		TotalRows:   totalRows,
-		TotalPages:  int(math.Ceil(float64(totalRows) / float64(size))),
-	}
-
-	return absences, paging, nil
-}
-
 // Create implements AbsenceRepository.
 func (a *absenceRepository) Create(payload []dto.ParticipantScheduleDTO) ([]dto.ParticipantScheduleDTO, error) {
 	for _, participantID := range payload {
 		if len(participantID.ScheduleID) != len(participantID.Date) {
 			fmt.Println("Error: ScheduleID and date slices must have the same length")
-			return nil, errors.New("invalid data structure")
+			return nil, apperror.BadRequest("scheduleId and date must have the same length")
 		}
 		for i := 0; i < len(participantID.ScheduleID); i++ {
 			schedule := participantID.ScheduleID[i]
@@ -143,36 +108,50 @@ func (a *absenceRepository) Delete(id string) error {
 	return nil
 }
 
+// absenceList is what the absence list can be filtered and sorted on.
+var absenceList = query.Table{
+	Fields: map[string]query.Field{
+		"date":          {Column: "date", Type: query.Date, Sortable: true},
+		"absenceStatus": {Column: "absence_status::text", Type: query.Text},
+		"participantId": {Column: "participant_id", Type: query.UUID},
+		"trainerId":     {Column: "trainer_id", Type: query.UUID},
+		"scheduleId":    {Column: "schedule_id", Type: query.UUID},
+		"createdAt":     {Column: "created_at", Type: query.Timestamp, Sortable: true},
+		"updatedAt":     {Column: "updated_at", Type: query.Timestamp, Sortable: true},
+	},
+	Key:         "id",
+	DefaultSort: []query.Sort{{Field: "createdAt", Desc: true}},
+}
+
 // List implements AbsenceRepository.
-func (a *absenceRepository) List(page int, size int) ([]entity.Absence, model.Paging, error) {
-	var absences []entity.Absence
-	offset := (page - 1) * size
-	rows, err := a.db.Query(config.ListAbsence, size, offset)
+func (a *absenceRepository) List(spec query.Spec) ([]entity.Absence, model.Paging, error) {
+	stmt, err := absenceList.Select(config.ListAbsence, spec)
 	if err != nil {
 		return nil, model.Paging{}, err
 	}
+	rows, err := a.db.Query(stmt.Query, stmt.Args...)
+	if err != nil {
+		return nil, model.Paging{}, err
+	}
+	defer rows.Close()
 
-	for rows.Next() {
+	var absences []entity.Absence
+	for stmt.Next(rows) {
 		var absence entity.Absence
-		err := rows.Scan(&absence.ID, &absence.Date, &absence.Information, &absence.Absence_status, &absence.Absence_time, &absence.Participant_id, &absence.Created_at, &absence.Updated_at)
+		err := stmt.Scan(rows, &absence.ID, &absence.Date, &absence.Information, &absence.Absence_status, &absence.Absence_time, &absence.Participant_id, &absence.Created_at, &absence.Updated_at)
 		if err != nil {
 			return nil, model.Paging{}, err
 		}
 		absences = append(absences, absence)
 	}
-
-	totalRows := 0
-	if err := a.db.QueryRow("SELECT COUNT(*) FROM absences").Scan(&totalRows); err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, model.Paging{}, err
 	}
 
-	paging := model.Paging{
-		Page:        page,
-		RowsPerPage: size,
-		TotalRows:   totalRows,
-		TotalPages:  int(math.Ceil(float64(totalRows) / float64(size))),
+	paging, err := stmt.Paging(a.db)
+	if err != nil {
+		return nil, model.Paging{}, err
 	}
-
 	return absences, paging, nil
 }
 
@@ -0,0 +1,298 @@
+package repository
+
+import (
+	"database/sql"
+	"instructor-led-app/config"
+	"instructor-led-app/entity"
+	"log"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type AssessmentRepository interface {
+	Create(assessment entity.Assessment) (entity.Assessment, error)
+	List(scheduleId string) ([]entity.Assessment, error)
+	Get(id string) (entity.Assessment, error)
+	Delete(id string) error
+	CountAttempts(assessmentId string) (int, error)
+	HasAttempt(assessmentId, participantId string) (bool, error)
+	CreateAttempt(attempt entity.AssessmentAttempt) (entity.AssessmentAttempt, error)
+	ListAttempts(assessmentId string) ([]entity.AssessmentAttempt, error)
+	ListAttemptsByParticipant(participantId string) ([]entity.AssessmentAttempt, error)
+	GetAttempt(id string) (entity.AssessmentAttempt, error)
+	GradeAnswer(attemptId, answerId string, payload entity.AssessmentAnswer, gradedBy string) error
+}
+
+type assessmentRepository struct {
+	db *sql.DB
+}
+
+func scanAssessment(row rowScanner) (entity.Assessment, error) {
+	var assessment entity.Assessment
+	err := row.Scan(&assessment.ID, &assessment.ScheduleID, &assessment.TrainerID, &assessment.Title, &assessment.Description,
+		&assessment.OpensAt, &assessment.ClosesAt, &assessment.CreatedAt, &assessment.UpdatedAt)
+	return assessment, err
+}
+
+func scanAttempt(row rowScanner) (entity.AssessmentAttempt, error) {
+	var attempt entity.AssessmentAttempt
+	var score sql.NullFloat64
+	err := row.Scan(&attempt.ID, &attempt.AssessmentID, &attempt.ParticipantID, &attempt.ParticipantName, &attempt.Status, &score, &attempt.MaxScore, &attempt.SubmittedAt)
+	if score.Valid {
+		attempt.Score = &score.Float64
+	}
+	return attempt, err
+}
+
+// nullInt keeps an unset option index NULL in the database.
+func nullInt(value *int) sql.NullInt64 {
+	if value == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(*value), Valid: true}
+}
+
+func nullFloat(value *float64) sql.NullFloat64 {
+	if value == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *value, Valid: true}
+}
+
+// Create implements AssessmentRepository. The assessment and its questions are saved together.
+func (a *assessmentRepository) Create(assessment entity.Assessment) (entity.Assessment, error) {
+	tx, err := a.db.Begin()
+	if err != nil {
+		return entity.Assessment{}, err
+	}
+
+	if err := tx.QueryRow(config.InsertAssessment, assessment.ScheduleID, assessment.TrainerID, assessment.Title, assessment.Description, assessment.OpensAt, assessment.ClosesAt).
+		Scan(&assessment.ID, &assessment.CreatedAt, &assessment.UpdatedAt); err != nil {
+		tx.Rollback()
+		log.Println("assessmentRepository.Create:", err.Error())
+		return entity.Assessment{}, err
+	}
+	for i := range assessment.Questions {
+		question := &assessment.Questions[i]
+		question.AssessmentID = assessment.ID
+		if err := tx.QueryRow(config.InsertAssessmentQuestion, assessment.ID, question.Position, question.Kind, question.Prompt, pq.Array(question.Options), nullInt(question.CorrectOption), question.Points).
+			Scan(&question.ID); err != nil {
+			tx.Rollback()
+			log.Println("assessmentRepository.Create:", err.Error())
+			return entity.Assessment{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return entity.Assessment{}, err
+	}
+	return assessment, nil
+}
+
+// List implements AssessmentRepository. Questions are not loaded.
+func (a *assessmentRepository) List(scheduleId string) ([]entity.Assessment, error) {
+	rows, err := a.db.Query(config.ListAssessmentsBySchedule, scheduleId)
+	if err != nil {
+		log.Println("assessmentRepository.List:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var assessments []entity.Assessment
+	for rows.Next() {
+		assessment, err := scanAssessment(rows)
+		if err != nil {
+			return nil, err
+		}
+		assessments = append(assessments, assessment)
+	}
+	return assessments, nil
+}
+
+// Get implements AssessmentRepository.
+func (a *assessmentRepository) Get(id string) (entity.Assessment, error) {
+	assessment, err := scanAssessment(a.db.QueryRow(config.GetAssessmentByID, id))
+	if err != nil {
+		log.Println("assessmentRepository.Get:", err.Error())
+		return entity.Assessment{}, err
+	}
+
+	rows, err := a.db.Query(config.ListAssessmentQuestions, id)
+	if err != nil {
+		return entity.Assessment{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var question entity.AssessmentQuestion
+		var correct sql.NullInt64
+		if err := rows.Scan(&question.ID, &question.AssessmentID, &question.Position, &question.Kind, &question.Prompt, pq.Array(&question.Options), &correct, &question.Points); err != nil {
+			return entity.Assessment{}, err
+		}
+		if correct.Valid {
+			index := int(correct.Int64)
+			question.CorrectOption = &index
+		}
+		assessment.Questions = append(assessment.Questions, question)
+	}
+	return assessment, nil
+}
+
+// Delete implements AssessmentRepository.
+func (a *assessmentRepository) Delete(id string) error {
+	if _, err := a.db.Exec(config.DeleteAssessmentByID, id); err != nil {
+		log.Println("assessmentRepository.Delete:", err.Error())
+		return err
+	}
+	return nil
+}
+
+// CountAttempts implements AssessmentRepository.
+func (a *assessmentRepository) CountAttempts(assessmentId string) (int, error) {
+	var total int
+	if err := a.db.QueryRow(config.CountAttemptsByAssessment, assessmentId).Scan(&total); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// HasAttempt implements AssessmentRepository.
+func (a *assessmentRepository) HasAttempt(assessmentId, participantId string) (bool, error) {
+	var total int
+	if err := a.db.QueryRow(config.CountAttemptByParticipant, assessmentId, participantId).Scan(&total); err != nil {
+		return false, err
+	}
+	return total > 0, nil
+}
+
+// CreateAttempt implements AssessmentRepository. Answers without points wait for the trainer.
+func (a *assessmentRepository) CreateAttempt(attempt entity.AssessmentAttempt) (entity.AssessmentAttempt, error) {
+	tx, err := a.db.Begin()
+	if err != nil {
+		return entity.AssessmentAttempt{}, err
+	}
+
+	if err := tx.QueryRow(config.InsertAssessmentAttempt, attempt.AssessmentID, attempt.ParticipantID, attempt.Status, nullFloat(attempt.Score), attempt.MaxScore, attempt.SubmittedAt).
+		Scan(&attempt.ID); err != nil {
+		tx.Rollback()
+		log.Println("assessmentRepository.CreateAttempt:", err.Error())
+		return entity.AssessmentAttempt{}, err
+	}
+	for i := range attempt.Answers {
+		answer := &attempt.Answers[i]
+		answer.AttemptID = attempt.ID
+		var gradedAt interface{}
+		if answer.GradedAt != nil {
+			gradedAt = *answer.GradedAt
+		}
+		if err := tx.QueryRow(config.InsertAssessmentAnswer, attempt.ID, answer.QuestionID, nullInt(answer.SelectedOption), answer.AnswerText, nullFloat(answer.Points), gradedAt).
+			Scan(&answer.ID); err != nil {
+			tx.Rollback()
+			log.Println("assessmentRepository.CreateAttempt:", err.Error())
+			return entity.AssessmentAttempt{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return entity.AssessmentAttempt{}, err
+	}
+	return attempt, nil
+}
+
+func (a *assessmentRepository) listAttempts(query, arg string) ([]entity.AssessmentAttempt, error) {
+	rows, err := a.db.Query(query, arg)
+	if err != nil {
+		log.Println("assessmentRepository.listAttempts:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attempts []entity.AssessmentAttempt
+	for rows.Next() {
+		attempt, err := scanAttempt(rows)
+		if err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, attempt)
+	}
+	return attempts, nil
+}
+
+// ListAttempts implements AssessmentRepository.
+func (a *assessmentRepository) ListAttempts(assessmentId string) ([]entity.AssessmentAttempt, error) {
+	return a.listAttempts(config.ListAttemptsByAssessment, assessmentId)
+}
+
+// ListAttemptsByParticipant implements AssessmentRepository.
+func (a *assessmentRepository) ListAttemptsByParticipant(participantId string) ([]entity.AssessmentAttempt, error) {
+	return a.listAttempts(config.ListAttemptsByParticipant, participantId)
+}
+
+// GetAttempt implements AssessmentRepository.
+func (a *assessmentRepository) GetAttempt(id string) (entity.AssessmentAttempt, error) {
+	attempt, err := scanAttempt(a.db.QueryRow(config.GetAttemptByID, id))
+	if err != nil {
+		log.Println("assessmentRepository.GetAttempt:", err.Error())
+		return entity.AssessmentAttempt{}, err
+	}
+
+	rows, err := a.db.Query(config.ListAttemptAnswers, id)
+	if err != nil {
+		return entity.AssessmentAttempt{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var answer entity.AssessmentAnswer
+		var selected sql.NullInt64
+		var points sql.NullFloat64
+		var gradedAt sql.NullTime
+		if err := rows.Scan(&answer.ID, &answer.AttemptID, &answer.QuestionID, &selected, &answer.AnswerText, &points, &answer.Feedback, &gradedAt); err != nil {
+			return entity.AssessmentAttempt{}, err
+		}
+		if selected.Valid {
+			index := int(selected.Int64)
+			answer.SelectedOption = &index
+		}
+		if points.Valid {
+			answer.Points = &points.Float64
+		}
+		if gradedAt.Valid {
+			answer.GradedAt = &gradedAt.Time
+		}
+		attempt.Answers = append(attempt.Answers, answer)
+	}
+	return attempt, nil
+}
+
+// GradeAnswer implements AssessmentRepository. The attempt score is recomputed in the same transaction.
+func (a *assessmentRepository) GradeAnswer(attemptId, answerId string, payload entity.AssessmentAnswer, gradedBy string) error {
+	tx, err := a.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	gradedAt := time.Now()
+	if payload.GradedAt != nil {
+		gradedAt = *payload.GradedAt
+	}
+	result, err := tx.Exec(config.GradeAssessmentAnswer, attemptId, answerId, nullFloat(payload.Points), payload.Feedback, gradedBy, gradedAt)
+	if err != nil {
+		tx.Rollback()
+		log.Println("assessmentRepository.GradeAnswer:", err.Error())
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		tx.Rollback()
+		return sql.ErrNoRows
+	}
+	if _, err := tx.Exec(config.RefreshAttemptScore, attemptId); err != nil {
+		tx.Rollback()
+		log.Println("assessmentRepository.GradeAnswer:", err.Error())
+		return err
+	}
+	return tx.Commit()
+}
+
+func NewAssessmentRepository(db *sql.DB) AssessmentRepository {
+	return &assessmentRepository{db: db}
+}
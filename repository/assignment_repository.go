@@ -0,0 +1,265 @@
+package repository
+
+import (
+	"database/sql"
+	"instructor-led-app/config"
+	"instructor-led-app/entity"
+	"instructor-led-app/entity/dto"
+	"log"
+	"time"
+)
+
+type AssignmentRepository interface {
+	Create(assignment entity.Assignment) (entity.Assignment, error)
+	List(scheduleId, cohortId string) ([]entity.Assignment, error)
+	ListByCohort(cohortId string) ([]entity.Assignment, error)
+	ListByParticipant(participantId string) ([]entity.Assignment, error)
+	Get(id string) (entity.Assignment, error)
+	Delete(id string) error
+	CountSubmissions(assignmentId string) (int, error)
+	Submit(submission entity.AssignmentSubmission) (entity.AssignmentSubmission, error)
+	ListSubmissions(assignmentId string) ([]entity.AssignmentSubmission, error)
+	ListSubmissionsByCohort(cohortId string) ([]entity.AssignmentSubmission, error)
+	GetSubmission(id string) (entity.AssignmentSubmission, error)
+	GetSubmissionOf(assignmentId, participantId string) (entity.AssignmentSubmission, error)
+	Grade(id string, payload dto.GradeSubmissionDTO, score float64, gradedBy string, gradedAt time.Time) error
+}
+
+type assignmentRepository struct {
+	db *sql.DB
+}
+
+func scanSubmission(row rowScanner) (entity.AssignmentSubmission, error) {
+	var submission entity.AssignmentSubmission
+	var score sql.NullFloat64
+	var gradedAt sql.NullTime
+	err := row.Scan(&submission.ID, &submission.AssignmentID, &submission.ParticipantID, &submission.ParticipantName, &submission.Text,
+		&submission.FileName, &submission.StorageKey, &submission.Size, &submission.MimeType, &submission.IsLate, &submission.Status,
+		&score, &submission.Feedback, &gradedAt, &submission.SubmittedAt)
+	if score.Valid {
+		submission.Score = &score.Float64
+	}
+	if gradedAt.Valid {
+		submission.GradedAt = &gradedAt.Time
+	}
+	return submission, err
+}
+
+// Create implements AssignmentRepository. The assignment and its rubric are saved together.
+func (a *assignmentRepository) Create(assignment entity.Assignment) (entity.Assignment, error) {
+	tx, err := a.db.Begin()
+	if err != nil {
+		return entity.Assignment{}, err
+	}
+
+	if err := tx.QueryRow(config.InsertAssignment, assignment.ScheduleID, assignment.CohortID, assignment.TrainerID, assignment.Title, assignment.Instructions, assignment.DueAt).
+		Scan(&assignment.ID, &assignment.CreatedAt, &assignment.UpdatedAt); err != nil {
+		tx.Rollback()
+		log.Println("assignmentRepository.Create:", err.Error())
+		return entity.Assignment{}, err
+	}
+	for i := range assignment.Rubric {
+		criterion := &assignment.Rubric[i]
+		if err := tx.QueryRow(config.InsertRubricCriterion, assignment.ID, criterion.Position, criterion.Name, criterion.MaxPoints).Scan(&criterion.ID); err != nil {
+			tx.Rollback()
+			log.Println("assignmentRepository.Create:", err.Error())
+			return entity.Assignment{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return entity.Assignment{}, err
+	}
+	return assignment, nil
+}
+
+func (a *assignmentRepository) list(query string, args ...interface{}) ([]entity.Assignment, error) {
+	rows, err := a.db.Query(query, args...)
+	if err != nil {
+		log.Println("assignmentRepository.list:", err.Error())
+		return nil, err
+	}
+
+	var assignments []entity.Assignment
+	for rows.Next() {
+		var assignment entity.Assignment
+		if err := rows.Scan(&assignment.ID, &assignment.ScheduleID, &assignment.CohortID, &assignment.TrainerID, &assignment.Title, &assignment.Instructions,
+			&assignment.DueAt, &assignment.CreatedAt, &assignment.UpdatedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		assignments = append(assignments, assignment)
+	}
+	rows.Close()
+
+	for i := range assignments {
+		if err := a.loadRubric(&assignments[i]); err != nil {
+			return nil, err
+		}
+	}
+	return assignments, nil
+}
+
+func (a *assignmentRepository) loadRubric(assignment *entity.Assignment) error {
+	rows, err := a.db.Query(config.ListRubricCriteria, assignment.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	assignment.Rubric, assignment.MaxScore = nil, 0
+	for rows.Next() {
+		var criterion entity.RubricCriterion
+		if err := rows.Scan(&criterion.ID, &criterion.Position, &criterion.Name, &criterion.MaxPoints); err != nil {
+			return err
+		}
+		assignment.Rubric = append(assignment.Rubric, criterion)
+		assignment.MaxScore += criterion.MaxPoints
+	}
+	return nil
+}
+
+// List implements AssignmentRepository. Empty filters match everything.
+func (a *assignmentRepository) List(scheduleId, cohortId string) ([]entity.Assignment, error) {
+	return a.list(config.ListAssignments, scheduleId, cohortId)
+}
+
+// ListByCohort implements AssignmentRepository.
+func (a *assignmentRepository) ListByCohort(cohortId string) ([]entity.Assignment, error) {
+	return a.list(config.ListAssignmentsByCohort, cohortId)
+}
+
+// ListByParticipant implements AssignmentRepository.
+func (a *assignmentRepository) ListByParticipant(participantId string) ([]entity.Assignment, error) {
+	return a.list(config.ListAssignmentsByParticipant, participantId)
+}
+
+// Get implements AssignmentRepository.
+func (a *assignmentRepository) Get(id string) (entity.Assignment, error) {
+	assignments, err := a.list(config.GetAssignmentByID, id)
+	if err != nil {
+		return entity.Assignment{}, err
+	}
+	if len(assignments) == 0 {
+		return entity.Assignment{}, sql.ErrNoRows
+	}
+	return assignments[0], nil
+}
+
+// Delete implements AssignmentRepository.
+func (a *assignmentRepository) Delete(id string) error {
+	if _, err := a.db.Exec(config.DeleteAssignmentByID, id); err != nil {
+		log.Println("assignmentRepository.Delete:", err.Error())
+		return err
+	}
+	return nil
+}
+
+// CountSubmissions implements AssignmentRepository.
+func (a *assignmentRepository) CountSubmissions(assignmentId string) (int, error) {
+	var total int
+	if err := a.db.QueryRow(config.CountSubmissions, assignmentId).Scan(&total); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// Submit implements AssignmentRepository. It fails with sql.ErrNoRows when the earlier submission is already graded.
+func (a *assignmentRepository) Submit(submission entity.AssignmentSubmission) (entity.AssignmentSubmission, error) {
+	if err := a.db.QueryRow(config.UpsertSubmission, submission.AssignmentID, submission.ParticipantID, submission.Text, submission.FileName,
+		submission.StorageKey, submission.Size, submission.MimeType, submission.IsLate, submission.SubmittedAt).Scan(&submission.ID); err != nil {
+		log.Println("assignmentRepository.Submit:", err.Error())
+		return entity.AssignmentSubmission{}, err
+	}
+	return a.GetSubmission(submission.ID)
+}
+
+func (a *assignmentRepository) listSubmissions(query, arg string) ([]entity.AssignmentSubmission, error) {
+	rows, err := a.db.Query(query, arg)
+	if err != nil {
+		log.Println("assignmentRepository.listSubmissions:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var submissions []entity.AssignmentSubmission
+	for rows.Next() {
+		submission, err := scanSubmission(rows)
+		if err != nil {
+			return nil, err
+		}
+		submissions = append(submissions, submission)
+	}
+	return submissions, nil
+}
+
+// ListSubmissions implements AssignmentRepository.
+func (a *assignmentRepository) ListSubmissions(assignmentId string) ([]entity.AssignmentSubmission, error) {
+	return a.listSubmissions(config.ListSubmissionsByAssignment, assignmentId)
+}
+
+// ListSubmissionsByCohort implements AssignmentRepository.
+func (a *assignmentRepository) ListSubmissionsByCohort(cohortId string) ([]entity.AssignmentSubmission, error) {
+	return a.listSubmissions(config.ListSubmissionsByCohort, cohortId)
+}
+
+func (a *assignmentRepository) loadScores(submission *entity.AssignmentSubmission) error {
+	rows, err := a.db.Query(config.ListSubmissionScores, submission.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var score entity.RubricScore
+		if err := rows.Scan(&score.CriterionID, &score.Points, &score.Comment); err != nil {
+			return err
+		}
+		submission.RubricScores = append(submission.RubricScores, score)
+	}
+	return nil
+}
+
+// GetSubmission implements AssignmentRepository.
+func (a *assignmentRepository) GetSubmission(id string) (entity.AssignmentSubmission, error) {
+	submission, err := scanSubmission(a.db.QueryRow(config.GetSubmissionByID, id))
+	if err != nil {
+		log.Println("assignmentRepository.GetSubmission:", err.Error())
+		return entity.AssignmentSubmission{}, err
+	}
+	return submission, a.loadScores(&submission)
+}
+
+// GetSubmissionOf implements AssignmentRepository.
+func (a *assignmentRepository) GetSubmissionOf(assignmentId, participantId string) (entity.AssignmentSubmission, error) {
+	submission, err := scanSubmission(a.db.QueryRow(config.GetSubmissionByParticipant, assignmentId, participantId))
+	if err != nil {
+		return entity.AssignmentSubmission{}, err
+	}
+	return submission, a.loadScores(&submission)
+}
+
+// Grade implements AssignmentRepository. Rubric scores and the total are written together.
+func (a *assignmentRepository) Grade(id string, payload dto.GradeSubmissionDTO, score float64, gradedBy string, gradedAt time.Time) error {
+	tx, err := a.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, item := range payload.Scores {
+		if _, err := tx.Exec(config.UpsertSubmissionScore, id, item.CriterionID, item.Points, item.Comment); err != nil {
+			tx.Rollback()
+			log.Println("assignmentRepository.Grade:", err.Error())
+			return err
+		}
+	}
+	if _, err := tx.Exec(config.GradeSubmission, id, score, payload.Feedback, gradedBy, gradedAt); err != nil {
+		tx.Rollback()
+		log.Println("assignmentRepository.Grade:", err.Error())
+		return err
+	}
+	return tx.Commit()
+}
+
+func NewAssignmentRepository(db *sql.DB) AssignmentRepository {
+	return &assignmentRepository{db: db}
+}
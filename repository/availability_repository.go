@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"database/sql"
+	"instructor-led-app/config"
+	"instructor-led-app/entity"
+	"instructor-led-app/entity/dto"
+	"log"
+	"time"
+)
+
+type AvailabilityRepository interface {
+	CreateSlot(payload dto.AvailabilitySlotDTO) (entity.TrainerAvailability, error)
+	ListSlots(trainerId string) ([]entity.TrainerAvailability, error)
+	GetSlot(id string) (entity.TrainerAvailability, error)
+	DeleteSlot(id string) error
+	CreateTimeOff(trainerId string, startDate, endDate time.Time, reason string) (entity.TrainerTimeOff, error)
+	ListTimeOffs(trainerId string, from time.Time) ([]entity.TrainerTimeOff, error)
+	GetTimeOff(id string) (entity.TrainerTimeOff, error)
+	DeleteTimeOff(id string) error
+	ListTrainerIDs() ([]string, error)
+}
+
+type availabilityRepository struct {
+	db *sql.DB
+}
+
+// CreateSlot implements AvailabilityRepository.
+func (a *availabilityRepository) CreateSlot(payload dto.AvailabilitySlotDTO) (entity.TrainerAvailability, error) {
+	slot, err := scanAvailabilitySlot(a.db.QueryRow(config.InsertAvailabilitySlot, payload.TrainerID, payload.DayOfWeek, payload.StartTime, payload.EndTime))
+	if err != nil {
+		log.Println("availabilityRepository.CreateSlot:", err.Error())
+		return entity.TrainerAvailability{}, err
+	}
+	return slot, nil
+}
+
+// ListSlots implements AvailabilityRepository. An empty trainerId lists the slots of every trainer.
+func (a *availabilityRepository) ListSlots(trainerId string) ([]entity.TrainerAvailability, error) {
+	rows, err := a.db.Query(config.ListAvailabilitySlots, trainerId)
+	if err != nil {
+		log.Println("availabilityRepository.ListSlots:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var slots []entity.TrainerAvailability
+	for rows.Next() {
+		slot, err := scanAvailabilitySlot(rows)
+		if err != nil {
+			return nil, err
+		}
+		slots = append(slots, slot)
+	}
+	return slots, nil
+}
+
+// ListTrainerIDs implements AvailabilityRepository.
+func (a *availabilityRepository) ListTrainerIDs() ([]string, error) {
+	rows, err := a.db.Query(config.ListTrainerIDs)
+	if err != nil {
+		log.Println("availabilityRepository.ListTrainerIDs:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// GetSlot implements AvailabilityRepository.
+func (a *availabilityRepository) GetSlot(id string) (entity.TrainerAvailability, error) {
+	return scanAvailabilitySlot(a.db.QueryRow(config.GetAvailabilitySlot, id))
+}
+
+// DeleteSlot implements AvailabilityRepository.
+func (a *availabilityRepository) DeleteSlot(id string) error {
+	if _, err := a.db.Exec(config.DeleteAvailabilitySlot, id); err != nil {
+		log.Println("availabilityRepository.DeleteSlot:", err.Error())
+		return err
+	}
+	return nil
+}
+
+// CreateTimeOff implements AvailabilityRepository.
+func (a *availabilityRepository) CreateTimeOff(trainerId string, startDate, endDate time.Time, reason string) (entity.TrainerTimeOff, error) {
+	timeOff, err := scanTimeOff(a.db.QueryRow(config.InsertTimeOff, trainerId, startDate, endDate, reason))
+	if err != nil {
+		log.Println("availabilityRepository.CreateTimeOff:", err.Error())
+		return entity.TrainerTimeOff{}, err
+	}
+	return timeOff, nil
+}
+
+// ListTimeOffs implements AvailabilityRepository. Only time-off that has not ended before from is returned.
+func (a *availabilityRepository) ListTimeOffs(trainerId string, from time.Time) ([]entity.TrainerTimeOff, error) {
+	rows, err := a.db.Query(config.ListTimeOffs, trainerId, from)
+	if err != nil {
+		log.Println("availabilityRepository.ListTimeOffs:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var timeOffs []entity.TrainerTimeOff
+	for rows.Next() {
+		timeOff, err := scanTimeOff(rows)
+		if err != nil {
+			return nil, err
+		}
+		timeOffs = append(timeOffs, timeOff)
+	}
+	return timeOffs, nil
+}
+
+// GetTimeOff implements AvailabilityRepository.
+func (a *availabilityRepository) GetTimeOff(id string) (entity.TrainerTimeOff, error) {
+	return scanTimeOff(a.db.QueryRow(config.GetTimeOff, id))
+}
+
+// DeleteTimeOff implements AvailabilityRepository.
+func (a *availabilityRepository) DeleteTimeOff(id string) error {
+	if _, err := a.db.Exec(config.DeleteTimeOff, id); err != nil {
+		log.Println("availabilityRepository.DeleteTimeOff:", err.Error())
+		return err
+	}
+	return nil
+}
+
+func scanAvailabilitySlot(row rowScanner) (entity.TrainerAvailability, error) {
+	var slot entity.TrainerAvailability
+	err := row.Scan(&slot.ID, &slot.TrainerID, &slot.DayOfWeek, &slot.StartTime, &slot.EndTime, &slot.CreatedAt)
+	return slot, err
+}
+
+func scanTimeOff(row rowScanner) (entity.TrainerTimeOff, error) {
+	var timeOff entity.TrainerTimeOff
+	err := row.Scan(&timeOff.ID, &timeOff.TrainerID, &timeOff.StartDate, &timeOff.EndDate, &timeOff.Reason, &timeOff.CreatedAt)
+	return timeOff, err
+}
+
+func NewAvailabilityRepository(db *sql.DB) AvailabilityRepository {
+	return &availabilityRepository{db: db}
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"database/sql"
+	"instructor-led-app/config"
+	"instructor-led-app/entity"
+	"instructor-led-app/entity/dto"
+	"log"
+	"time"
+)
+
+type CertificateRepository interface {
+	CohortSummary(cohortId string) (start, end *time.Time, trainers string, err error)
+	Eligibility(cohortId string) ([]dto.CertificateEligibilityDTO, error)
+	Create(certificate entity.Certificate, issuedBy string) (entity.Certificate, error)
+	List(cohortId string) ([]entity.Certificate, error)
+	ListByParticipant(participantId string) ([]entity.Certificate, error)
+	Get(id string) (entity.Certificate, error)
+	GetByCode(code string) (entity.Certificate, error)
+}
+
+type certificateRepository struct {
+	db *sql.DB
+}
+
+func scanCertificate(row rowScanner) (entity.Certificate, error) {
+	var certificate entity.Certificate
+	var score sql.NullFloat64
+	err := row.Scan(&certificate.ID, &certificate.Code, &certificate.ParticipantID, &certificate.CohortID, &certificate.ParticipantName, &certificate.Track,
+		&certificate.CohortName, &certificate.TrainerNames, &certificate.StartDate, &certificate.EndDate, &certificate.AttendanceRate, &score, &certificate.IssuedAt)
+	if score.Valid {
+		certificate.Score = &score.Float64
+	}
+	return certificate, err
+}
+
+// CohortSummary implements CertificateRepository. The dates are nil while the cohort has no session.
+func (c *certificateRepository) CohortSummary(cohortId string) (*time.Time, *time.Time, string, error) {
+	var start, end sql.NullTime
+	var trainers string
+	if err := c.db.QueryRow(config.CohortSessionSummary, cohortId).Scan(&start, &end, &trainers); err != nil {
+		log.Println("certificateRepository.CohortSummary:", err.Error())
+		return nil, nil, "", err
+	}
+	if !start.Valid || !end.Valid {
+		return nil, nil, trainers, nil
+	}
+	return &start.Time, &end.Time, trainers, nil
+}
+
+// Eligibility implements CertificateRepository. It returns the raw numbers; the thresholds live in the usecase.
+func (c *certificateRepository) Eligibility(cohortId string) ([]dto.CertificateEligibilityDTO, error) {
+	rows, err := c.db.Query(config.CertificateEligibility, cohortId)
+	if err != nil {
+		log.Println("certificateRepository.Eligibility:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []dto.CertificateEligibilityDTO
+	for rows.Next() {
+		var item dto.CertificateEligibilityDTO
+		var score sql.NullFloat64
+		if err := rows.Scan(&item.ParticipantID, &item.Name, &item.Track, &item.SessionsAttended, &item.SessionsRecorded, &score, &item.AlreadyIssued); err != nil {
+			return nil, err
+		}
+		if score.Valid {
+			item.Score = &score.Float64
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+// Create implements CertificateRepository.
+func (c *certificateRepository) Create(certificate entity.Certificate, issuedBy string) (entity.Certificate, error) {
+	var score interface{}
+	if certificate.Score != nil {
+		score = *certificate.Score
+	}
+	if err := c.db.QueryRow(config.InsertCertificate, certificate.Code, certificate.ParticipantID, certificate.CohortID, certificate.ParticipantName, certificate.Track,
+		certificate.CohortName, certificate.TrainerNames, certificate.StartDate, certificate.EndDate, certificate.AttendanceRate, score, issuedBy).
+		Scan(&certificate.ID, &certificate.IssuedAt); err != nil {
+		log.Println("certificateRepository.Create:", err.Error())
+		return entity.Certificate{}, err
+	}
+	return certificate, nil
+}
+
+func (c *certificateRepository) list(query, arg string) ([]entity.Certificate, error) {
+	rows, err := c.db.Query(query, arg)
+	if err != nil {
+		log.Println("certificateRepository.list:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var certificates []entity.Certificate
+	for rows.Next() {
+		certificate, err := scanCertificate(rows)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+	return certificates, nil
+}
+
+// List implements CertificateRepository. An empty cohortId lists every certificate.
+func (c *certificateRepository) List(cohortId string) ([]entity.Certificate, error) {
+	return c.list(config.ListCertificates, cohortId)
+}
+
+// ListByParticipant implements CertificateRepository.
+func (c *certificateRepository) ListByParticipant(participantId string) ([]entity.Certificate, error) {
+	return c.list(config.ListCertificatesByParticipant, participantId)
+}
+
+// Get implements CertificateRepository.
+func (c *certificateRepository) Get(id string) (entity.Certificate, error) {
+	return scanCertificate(c.db.QueryRow(config.GetCertificateByID, id))
+}
+
+// GetByCode implements CertificateRepository.
+func (c *certificateRepository) GetByCode(code string) (entity.Certificate, error) {
+	return scanCertificate(c.db.QueryRow(config.GetCertificateByCode, code))
+}
+
+func NewCertificateRepository(db *sql.DB) CertificateRepository {
+	return &certificateRepository{db: db}
+}
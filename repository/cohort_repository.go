@@ -0,0 +1,399 @@
+package repository
+
+import (
+	"database/sql"
+	"instructor-led-app/config"
+	"instructor-led-app/entity"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/shared/model"
+	"instructor-led-app/shared/query"
+	"log"
+	"time"
+)
+
+type CohortRepository interface {
+	Create(payload dto.CohortDTO) (entity.Cohort, error)
+	List(spec query.Spec) ([]entity.Cohort, model.Paging, error)
+	Get(id string) (entity.Cohort, error)
+	Update(id string, payload dto.CohortDTO, updatedAt time.Time) error
+	Delete(id string) error
+	CountSchedules(id string) (int, error)
+	Enroll(cohortId string, participantIds []string) ([]string, error)
+	Unenroll(cohortId, participantId string) ([]string, error)
+	FillSeats(cohortId string) ([]string, error)
+	ListEnrollments(cohortId string) ([]entity.CohortEnrollment, error)
+	ListWaitlist(cohortId string) ([]entity.CohortWaitlistEntry, error)
+	ListByParticipant(participantId string) ([]entity.ParticipantCohort, error)
+	Contact(participantId string) (string, string, error)
+	SeedSessionAttendance(scheduleId string) error
+	IsEnrolled(cohortId, participantId string) (bool, error)
+	IsTeaching(cohortId, trainerId string) (bool, error)
+}
+
+type cohortRepository struct {
+	db *sql.DB
+}
+
+func scanCohort(row rowScanner) (entity.Cohort, error) {
+	var cohort entity.Cohort
+	err := row.Scan(&cohort.ID, &cohort.Name, &cohort.Track, &cohort.Capacity, &cohort.Members, &cohort.Waitlisted, &cohort.CreatedAt, &cohort.UpdatedAt)
+	return cohort, err
+}
+
+// Create implements CohortRepository.
+func (c *cohortRepository) Create(payload dto.CohortDTO) (entity.Cohort, error) {
+	cohort := entity.Cohort{Name: payload.Name, Track: payload.Track, Capacity: payload.Capacity}
+	if err := c.db.QueryRow(config.InsertCohort, payload.Name, payload.Track, payload.Capacity).Scan(&cohort.ID, &cohort.CreatedAt, &cohort.UpdatedAt); err != nil {
+		log.Println("cohortRepository.Create:", err.Error())
+		return entity.Cohort{}, err
+	}
+	return cohort, nil
+}
+
+// cohortList is what the cohort list can be filtered and sorted on.
+var cohortList = query.Table{
+	Fields: map[string]query.Field{
+		"name":      {Column: "c.name", Type: query.Text, Sortable: true},
+		"track":     {Column: "c.track::text", Type: query.Text},
+		"createdAt": {Column: "c.created_at", Type: query.Timestamp, Sortable: true},
+		"updatedAt": {Column: "c.updated_at", Type: query.Timestamp, Sortable: true},
+	},
+	Key:         "c.id",
+	DefaultSort: []query.Sort{{Field: "createdAt", Desc: true}},
+}
+
+// List implements CohortRepository.
+func (c *cohortRepository) List(spec query.Spec) ([]entity.Cohort, model.Paging, error) {
+	stmt, err := cohortList.Select(config.ListCohorts, spec)
+	if err != nil {
+		return nil, model.Paging{}, err
+	}
+	rows, err := c.db.Query(stmt.Query, stmt.Args...)
+	if err != nil {
+		log.Println("cohortRepository.List:", err.Error())
+		return nil, model.Paging{}, err
+	}
+	defer rows.Close()
+
+	var cohorts []entity.Cohort
+	for stmt.Next(rows) {
+		cohort, err := scanCohort(stmt.Row(rows))
+		if err != nil {
+			return nil, model.Paging{}, err
+		}
+		cohorts = append(cohorts, cohort)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, model.Paging{}, err
+	}
+
+	paging, err := stmt.Paging(c.db)
+	if err != nil {
+		return nil, model.Paging{}, err
+	}
+	return cohorts, paging, nil
+}
+
+// Get implements CohortRepository.
+func (c *cohortRepository) Get(id string) (entity.Cohort, error) {
+	cohort, err := scanCohort(c.db.QueryRow(config.GetCohortByID, id))
+	if err != nil {
+		log.Println("cohortRepository.Get:", err.Error())
+		return entity.Cohort{}, err
+	}
+	return cohort, nil
+}
+
+// Update implements CohortRepository.
+func (c *cohortRepository) Update(id string, payload dto.CohortDTO, updatedAt time.Time) error {
+	if _, err := c.db.Exec(config.UpdateCohortByID, id, payload.Name, payload.Track, payload.Capacity, updatedAt); err != nil {
+		log.Println("cohortRepository.Update:", err.Error())
+		return err
+	}
+	return nil
+}
+
+// Delete implements CohortRepository.
+func (c *cohortRepository) Delete(id string) error {
+	if _, err := c.db.Exec(config.DeleteCohortByID, id); err != nil {
+		log.Println("cohortRepository.Delete:", err.Error())
+		return err
+	}
+	return nil
+}
+
+// CountSchedules implements CohortRepository.
+func (c *cohortRepository) CountSchedules(id string) (int, error) {
+	var total int
+	if err := c.db.QueryRow(config.CountSchedulesByCohort, id).Scan(&total); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// seat enrolls the participant and gives them an attendance row for every upcoming
+// session of the cohort. It reports false when they were already enrolled. Either way
+// they leave the waitlist, so fillSeats never meets the same head twice.
+func seat(tx *sql.Tx, cohortId, participantId string) (bool, error) {
+	if _, err := tx.Exec(config.DeleteCohortWaitlist, cohortId, participantId); err != nil {
+		return false, err
+	}
+	result, err := tx.Exec(config.InsertCohortEnrollment, cohortId, participantId)
+	if err != nil {
+		return false, err
+	}
+	if inserted, _ := result.RowsAffected(); inserted == 0 {
+		return false, nil
+	}
+	if _, err := tx.Exec(config.SeedParticipantAttendance, cohortId, participantId); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// fillSeats promotes the head of the waitlist until the cohort is full.
+func fillSeats(tx *sql.Tx, cohortId string) ([]string, error) {
+	var capacity, members int
+	if err := tx.QueryRow(config.LockCohortSeats, cohortId).Scan(&capacity, &members); err != nil {
+		return nil, err
+	}
+
+	var promoted []string
+	for capacity == 0 || members < capacity {
+		var participantId string
+		err := tx.QueryRow(config.NextCohortWaitlist, cohortId).Scan(&participantId)
+		if err == sql.ErrNoRows {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		seated, err := seat(tx, cohortId, participantId)
+		if err != nil {
+			return nil, err
+		}
+		if seated {
+			promoted = append(promoted, participantId)
+			members++
+		}
+	}
+	return promoted, nil
+}
+
+// enroll locks the cohort's seats, seats the participants while it has room and puts the
+// rest at the end of the waitlist in the given order; it returns the waitlisted ones.
+func enroll(tx *sql.Tx, cohortId string, participantIds []string) ([]string, error) {
+	var capacity, members int
+	if err := tx.QueryRow(config.LockCohortSeats, cohortId).Scan(&capacity, &members); err != nil {
+		return nil, err
+	}
+
+	var waitlisted []string
+	for _, participantId := range participantIds {
+		if capacity > 0 && members >= capacity {
+			enrolled, err := isEnrolled(tx, cohortId, participantId)
+			if err == nil && !enrolled {
+				_, err = tx.Exec(config.InsertCohortWaitlist, cohortId, participantId)
+			}
+			if err != nil {
+				return nil, err
+			}
+			if !enrolled {
+				waitlisted = append(waitlisted, participantId)
+			}
+			continue
+		}
+		seated, err := seat(tx, cohortId, participantId)
+		if err != nil {
+			return nil, err
+		}
+		if seated {
+			members++
+		}
+	}
+	return waitlisted, nil
+}
+
+// Enroll implements CohortRepository. Participants are seated while the cohort has room,
+// the rest join the end of the waitlist in the given order; it returns the waitlisted ones.
+func (c *cohortRepository) Enroll(cohortId string, participantIds []string) ([]string, error) {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	waitlisted, err := enroll(tx, cohortId, participantIds)
+	if err != nil {
+		tx.Rollback()
+		log.Println("cohortRepository.Enroll:", err.Error())
+		return nil, err
+	}
+	return waitlisted, tx.Commit()
+}
+
+func isEnrolled(tx *sql.Tx, cohortId, participantId string) (bool, error) {
+	var total int
+	if err := tx.QueryRow(config.CountCohortEnrollment, cohortId, participantId).Scan(&total); err != nil {
+		return false, err
+	}
+	return total > 0, nil
+}
+
+// Unenroll implements CohortRepository. It takes the participant off the cohort or its waitlist,
+// keeps attendance already taken and returns who got the freed seat. It fails with
+// sql.ErrNoRows when the participant was neither enrolled nor waiting.
+func (c *cohortRepository) Unenroll(cohortId, participantId string) ([]string, error) {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	var capacity, members int
+	if err := tx.QueryRow(config.LockCohortSeats, cohortId).Scan(&capacity, &members); err != nil {
+		tx.Rollback()
+		log.Println("cohortRepository.Unenroll:", err.Error())
+		return nil, err
+	}
+	if _, err := tx.Exec(config.DeleteUpcomingAttendance, cohortId, participantId); err != nil {
+		tx.Rollback()
+		log.Println("cohortRepository.Unenroll:", err.Error())
+		return nil, err
+	}
+	var removed int64
+	for _, query := range []string{config.DeleteCohortEnrollment, config.DeleteCohortWaitlist} {
+		result, err := tx.Exec(query, cohortId, participantId)
+		if err != nil {
+			tx.Rollback()
+			log.Println("cohortRepository.Unenroll:", err.Error())
+			return nil, err
+		}
+		affected, _ := result.RowsAffected()
+		removed += affected
+	}
+	if removed == 0 {
+		tx.Rollback()
+		return nil, sql.ErrNoRows
+	}
+
+	promoted, err := fillSeats(tx, cohortId)
+	if err != nil {
+		tx.Rollback()
+		log.Println("cohortRepository.Unenroll:", err.Error())
+		return nil, err
+	}
+	return promoted, tx.Commit()
+}
+
+// FillSeats implements CohortRepository.
+func (c *cohortRepository) FillSeats(cohortId string) ([]string, error) {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	promoted, err := fillSeats(tx, cohortId)
+	if err != nil {
+		tx.Rollback()
+		log.Println("cohortRepository.FillSeats:", err.Error())
+		return nil, err
+	}
+	return promoted, tx.Commit()
+}
+
+// ListEnrollments implements CohortRepository.
+func (c *cohortRepository) ListEnrollments(cohortId string) ([]entity.CohortEnrollment, error) {
+	rows, err := c.db.Query(config.ListCohortEnrollments, cohortId)
+	if err != nil {
+		log.Println("cohortRepository.ListEnrollments:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var enrollments []entity.CohortEnrollment
+	for rows.Next() {
+		var enrollment entity.CohortEnrollment
+		if err := rows.Scan(&enrollment.ID, &enrollment.CohortID, &enrollment.ParticipantID, &enrollment.Name, &enrollment.Track, &enrollment.EnrolledAt); err != nil {
+			return nil, err
+		}
+		enrollments = append(enrollments, enrollment)
+	}
+	return enrollments, nil
+}
+
+// ListWaitlist implements CohortRepository.
+func (c *cohortRepository) ListWaitlist(cohortId string) ([]entity.CohortWaitlistEntry, error) {
+	rows, err := c.db.Query(config.ListCohortWaitlist, cohortId)
+	if err != nil {
+		log.Println("cohortRepository.ListWaitlist:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var waitlist []entity.CohortWaitlistEntry
+	for rows.Next() {
+		var entry entity.CohortWaitlistEntry
+		if err := rows.Scan(&entry.ID, &entry.CohortID, &entry.ParticipantID, &entry.Name, &entry.Track, &entry.Position, &entry.JoinedAt); err != nil {
+			return nil, err
+		}
+		waitlist = append(waitlist, entry)
+	}
+	return waitlist, nil
+}
+
+// ListByParticipant implements CohortRepository.
+func (c *cohortRepository) ListByParticipant(participantId string) ([]entity.ParticipantCohort, error) {
+	rows, err := c.db.Query(config.ListParticipantCohorts, participantId)
+	if err != nil {
+		log.Println("cohortRepository.ListByParticipant:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cohorts []entity.ParticipantCohort
+	for rows.Next() {
+		var cohort entity.ParticipantCohort
+		if err := rows.Scan(&cohort.CohortID, &cohort.Name, &cohort.Track, &cohort.Status, &cohort.Position, &cohort.Since); err != nil {
+			return nil, err
+		}
+		cohorts = append(cohorts, cohort)
+	}
+	return cohorts, nil
+}
+
+// Contact implements CohortRepository. It returns the participant's name and email.
+func (c *cohortRepository) Contact(participantId string) (string, string, error) {
+	var name, email string
+	if err := c.db.QueryRow(config.GetParticipantContact, participantId).Scan(&name, &email); err != nil {
+		log.Println("cohortRepository.Contact:", err.Error())
+		return "", "", err
+	}
+	return name, email, nil
+}
+
+// SeedSessionAttendance implements CohortRepository.
+func (c *cohortRepository) SeedSessionAttendance(scheduleId string) error {
+	if _, err := c.db.Exec(config.SeedSessionAttendance, scheduleId); err != nil {
+		log.Println("cohortRepository.SeedSessionAttendance:", err.Error())
+		return err
+	}
+	return nil
+}
+
+// IsEnrolled implements CohortRepository.
+func (c *cohortRepository) IsEnrolled(cohortId, participantId string) (bool, error) {
+	var total int
+	if err := c.db.QueryRow(config.CountCohortEnrollment, cohortId, participantId).Scan(&total); err != nil {
+		return false, err
+	}
+	return total > 0, nil
+}
+
+// IsTeaching implements CohortRepository. A trainer teaches a cohort when they run one of its sessions.
+func (c *cohortRepository) IsTeaching(cohortId, trainerId string) (bool, error) {
+	var total int
+	if err := c.db.QueryRow(config.CountTrainerCohortSessions, cohortId, trainerId).Scan(&total); err != nil {
+		return false, err
+	}
+	return total > 0, nil
+}
+
+func NewCohortRepository(db *sql.DB) CohortRepository {
+	return &cohortRepository{db: db}
+}
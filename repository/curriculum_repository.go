@@ -0,0 +1,328 @@
+package repository
+
+import (
+	"database/sql"
+	"instructor-led-app/config"
+	"instructor-led-app/entity"
+	"instructor-led-app/entity/dto"
+	"log"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type CurriculumRepository interface {
+	CreateCourse(payload dto.CourseDTO) (entity.Course, error)
+	ListCourses(track string) ([]entity.Course, error)
+	GetCourse(id string) (entity.Course, error)
+	UpdateCourse(id string, payload dto.CourseDTO, updatedAt time.Time) error
+	DeleteCourse(id string) error
+	CreateModule(courseId string, payload dto.CourseModuleDTO) (entity.CourseModule, error)
+	ListModules(courseId string) ([]entity.CourseModule, error)
+	GetModule(id string) (entity.CourseModule, error)
+	UpdateModule(id string, payload dto.CourseModuleDTO, updatedAt time.Time) error
+	DeleteModule(id string) error
+	ReorderModules(courseId string, ids []string) error
+	CreateLesson(moduleId string, payload dto.LessonDTO) (entity.Lesson, error)
+	ListLessons(moduleId string) ([]entity.Lesson, error)
+	ListCourseLessons(courseId string) ([]entity.Lesson, error)
+	GetLesson(id string) (entity.Lesson, error)
+	UpdateLesson(id string, payload dto.LessonDTO, updatedAt time.Time) error
+	DeleteLesson(id string) error
+	ReorderLessons(moduleId string, ids []string) error
+	CountSchedules(courseId, moduleId, lessonId string) (int, error)
+	AssignScheduleLesson(scheduleId, lessonId string, updatedAt time.Time) error
+	Coverage(participantId, track string) ([]dto.CoverageRowDTO, error)
+}
+
+type curriculumRepository struct {
+	db *sql.DB
+}
+
+func scanCourseModule(row rowScanner) (entity.CourseModule, error) {
+	var module entity.CourseModule
+	err := row.Scan(&module.ID, &module.CourseID, &module.Title, &module.Description, &module.Position, &module.CreatedAt, &module.UpdatedAt)
+	return module, err
+}
+
+func scanLesson(row rowScanner) (entity.Lesson, error) {
+	var lesson entity.Lesson
+	err := row.Scan(&lesson.ID, &lesson.ModuleID, &lesson.Title, pq.Array(&lesson.Objectives), &lesson.DurationMinutes, &lesson.Position, &lesson.CreatedAt, &lesson.UpdatedAt)
+	return lesson, err
+}
+
+// CreateCourse implements CurriculumRepository.
+func (c *curriculumRepository) CreateCourse(payload dto.CourseDTO) (entity.Course, error) {
+	course := entity.Course{Title: payload.Title, Description: payload.Description, Track: payload.Track}
+	if err := c.db.QueryRow(config.InsertCourse, payload.Title, payload.Description, payload.Track).Scan(&course.ID, &course.CreatedAt, &course.UpdatedAt); err != nil {
+		log.Println("curriculumRepository.CreateCourse:", err.Error())
+		return entity.Course{}, err
+	}
+	return course, nil
+}
+
+// ListCourses implements CurriculumRepository.
+func (c *curriculumRepository) ListCourses(track string) ([]entity.Course, error) {
+	rows, err := c.db.Query(config.ListCourses, track)
+	if err != nil {
+		log.Println("curriculumRepository.ListCourses:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var courses []entity.Course
+	for rows.Next() {
+		var course entity.Course
+		if err := rows.Scan(&course.ID, &course.Title, &course.Description, &course.Track, &course.CreatedAt, &course.UpdatedAt); err != nil {
+			return nil, err
+		}
+		courses = append(courses, course)
+	}
+	return courses, nil
+}
+
+// GetCourse implements CurriculumRepository.
+func (c *curriculumRepository) GetCourse(id string) (entity.Course, error) {
+	var course entity.Course
+	err := c.db.QueryRow(config.GetCourseByID, id).Scan(&course.ID, &course.Title, &course.Description, &course.Track, &course.CreatedAt, &course.UpdatedAt)
+	if err != nil {
+		log.Println("curriculumRepository.GetCourse:", err.Error())
+		return entity.Course{}, err
+	}
+	return course, nil
+}
+
+// UpdateCourse implements CurriculumRepository.
+func (c *curriculumRepository) UpdateCourse(id string, payload dto.CourseDTO, updatedAt time.Time) error {
+	if _, err := c.db.Exec(config.UpdateCourse, id, payload.Title, payload.Description, payload.Track, updatedAt); err != nil {
+		log.Println("curriculumRepository.UpdateCourse:", err.Error())
+		return err
+	}
+	return nil
+}
+
+// DeleteCourse implements CurriculumRepository. Modules and lessons go with it.
+func (c *curriculumRepository) DeleteCourse(id string) error {
+	if _, err := c.db.Exec(config.DeleteCourse, id); err != nil {
+		log.Println("curriculumRepository.DeleteCourse:", err.Error())
+		return err
+	}
+	return nil
+}
+
+// CreateModule implements CurriculumRepository.
+func (c *curriculumRepository) CreateModule(courseId string, payload dto.CourseModuleDTO) (entity.CourseModule, error) {
+	module := entity.CourseModule{CourseID: courseId, Title: payload.Title, Description: payload.Description}
+	err := c.db.QueryRow(config.InsertCourseModule, courseId, payload.Title, payload.Description).Scan(&module.ID, &module.Position, &module.CreatedAt, &module.UpdatedAt)
+	if err != nil {
+		log.Println("curriculumRepository.CreateModule:", err.Error())
+		return entity.CourseModule{}, err
+	}
+	return module, nil
+}
+
+// ListModules implements CurriculumRepository.
+func (c *curriculumRepository) ListModules(courseId string) ([]entity.CourseModule, error) {
+	rows, err := c.db.Query(config.ListCourseModules, courseId)
+	if err != nil {
+		log.Println("curriculumRepository.ListModules:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var modules []entity.CourseModule
+	for rows.Next() {
+		module, err := scanCourseModule(rows)
+		if err != nil {
+			return nil, err
+		}
+		modules = append(modules, module)
+	}
+	return modules, nil
+}
+
+// GetModule implements CurriculumRepository.
+func (c *curriculumRepository) GetModule(id string) (entity.CourseModule, error) {
+	module, err := scanCourseModule(c.db.QueryRow(config.GetCourseModuleByID, id))
+	if err != nil {
+		log.Println("curriculumRepository.GetModule:", err.Error())
+		return entity.CourseModule{}, err
+	}
+	return module, nil
+}
+
+// UpdateModule implements CurriculumRepository.
+func (c *curriculumRepository) UpdateModule(id string, payload dto.CourseModuleDTO, updatedAt time.Time) error {
+	if _, err := c.db.Exec(config.UpdateCourseModule, id, payload.Title, payload.Description, updatedAt); err != nil {
+		log.Println("curriculumRepository.UpdateModule:", err.Error())
+		return err
+	}
+	return nil
+}
+
+// DeleteModule implements CurriculumRepository. The modules after it move up one position.
+func (c *curriculumRepository) DeleteModule(id string) error {
+	return c.deleteOrdered(config.DeleteCourseModule, config.CloseCourseModuleGap, id)
+}
+
+// ReorderModules implements CurriculumRepository.
+func (c *curriculumRepository) ReorderModules(courseId string, ids []string) error {
+	return c.reorder(config.MoveCourseModule, courseId, ids)
+}
+
+// CreateLesson implements CurriculumRepository.
+func (c *curriculumRepository) CreateLesson(moduleId string, payload dto.LessonDTO) (entity.Lesson, error) {
+	lesson := entity.Lesson{ModuleID: moduleId, Title: payload.Title, Objectives: payload.Objectives, DurationMinutes: payload.DurationMinutes}
+	err := c.db.QueryRow(config.InsertLesson, moduleId, payload.Title, pq.Array(payload.Objectives), payload.DurationMinutes).
+		Scan(&lesson.ID, &lesson.Position, &lesson.CreatedAt, &lesson.UpdatedAt)
+	if err != nil {
+		log.Println("curriculumRepository.CreateLesson:", err.Error())
+		return entity.Lesson{}, err
+	}
+	return lesson, nil
+}
+
+// ListLessons implements CurriculumRepository.
+func (c *curriculumRepository) ListLessons(moduleId string) ([]entity.Lesson, error) {
+	return c.listLessons(config.ListLessonsByModule, moduleId)
+}
+
+// ListCourseLessons implements CurriculumRepository. Lessons come in module order.
+func (c *curriculumRepository) ListCourseLessons(courseId string) ([]entity.Lesson, error) {
+	return c.listLessons(config.ListLessonsByCourse, courseId)
+}
+
+// GetLesson implements CurriculumRepository.
+func (c *curriculumRepository) GetLesson(id string) (entity.Lesson, error) {
+	lesson, err := scanLesson(c.db.QueryRow(config.GetLessonByID, id))
+	if err != nil {
+		log.Println("curriculumRepository.GetLesson:", err.Error())
+		return entity.Lesson{}, err
+	}
+	return lesson, nil
+}
+
+// UpdateLesson implements CurriculumRepository.
+func (c *curriculumRepository) UpdateLesson(id string, payload dto.LessonDTO, updatedAt time.Time) error {
+	if _, err := c.db.Exec(config.UpdateLesson, id, payload.Title, pq.Array(payload.Objectives), payload.DurationMinutes, updatedAt); err != nil {
+		log.Println("curriculumRepository.UpdateLesson:", err.Error())
+		return err
+	}
+	return nil
+}
+
+// DeleteLesson implements CurriculumRepository. The lessons after it move up one position.
+func (c *curriculumRepository) DeleteLesson(id string) error {
+	return c.deleteOrdered(config.DeleteLesson, config.CloseLessonGap, id)
+}
+
+// ReorderLessons implements CurriculumRepository.
+func (c *curriculumRepository) ReorderLessons(moduleId string, ids []string) error {
+	return c.reorder(config.MoveLesson, moduleId, ids)
+}
+
+// CountSchedules implements CurriculumRepository. Empty ids don't filter.
+func (c *curriculumRepository) CountSchedules(courseId, moduleId, lessonId string) (int, error) {
+	var total int
+	if err := c.db.QueryRow(config.CountCurriculumSchedules, courseId, moduleId, lessonId).Scan(&total); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// AssignScheduleLesson implements CurriculumRepository. An empty lessonId unlinks the schedule.
+func (c *curriculumRepository) AssignScheduleLesson(scheduleId, lessonId string, updatedAt time.Time) error {
+	if _, err := c.db.Exec(config.UpdateScheduleLesson, scheduleId, lessonId, updatedAt); err != nil {
+		log.Println("curriculumRepository.AssignScheduleLesson:", err.Error())
+		return err
+	}
+	return nil
+}
+
+// Coverage implements CurriculumRepository.
+func (c *curriculumRepository) Coverage(participantId, track string) ([]dto.CoverageRowDTO, error) {
+	rows, err := c.db.Query(config.CurriculumCoverage, participantId, track)
+	if err != nil {
+		log.Println("curriculumRepository.Coverage:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var coverage []dto.CoverageRowDTO
+	for rows.Next() {
+		var row dto.CoverageRowDTO
+		var coveredOn, nextSessionOn sql.NullTime
+		if err := rows.Scan(&row.CourseID, &row.CourseTitle, &row.ModuleID, &row.ModuleTitle, &row.ModulePos, &row.Lesson.LessonID, &row.Lesson.Title,
+			&row.Lesson.Position, &row.Lesson.DurationMinutes, &coveredOn, &nextSessionOn); err != nil {
+			return nil, err
+		}
+		if coveredOn.Valid {
+			row.Lesson.CoveredOn = &coveredOn.Time
+		}
+		if nextSessionOn.Valid {
+			row.Lesson.NextSessionOn = &nextSessionOn.Time
+		}
+		coverage = append(coverage, row)
+	}
+	return coverage, nil
+}
+
+func (c *curriculumRepository) listLessons(query, parentId string) ([]entity.Lesson, error) {
+	rows, err := c.db.Query(query, parentId)
+	if err != nil {
+		log.Println("curriculumRepository.listLessons:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lessons []entity.Lesson
+	for rows.Next() {
+		lesson, err := scanLesson(rows)
+		if err != nil {
+			return nil, err
+		}
+		lessons = append(lessons, lesson)
+	}
+	return lessons, nil
+}
+
+func (c *curriculumRepository) deleteOrdered(deleteQuery, closeGapQuery, id string) error {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	var parentId string
+	var position int
+	if err := tx.QueryRow(deleteQuery, id).Scan(&parentId, &position); err != nil {
+		tx.Rollback()
+		log.Println("curriculumRepository.deleteOrdered:", err.Error())
+		return err
+	}
+	if _, err := tx.Exec(closeGapQuery, parentId, position); err != nil {
+		tx.Rollback()
+		log.Println("curriculumRepository.deleteOrdered:", err.Error())
+		return err
+	}
+	return tx.Commit()
+}
+
+// reorder relies on the position constraints being deferred until commit.
+func (c *curriculumRepository) reorder(moveQuery, parentId string, ids []string) error {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	for i, id := range ids {
+		if _, err := tx.Exec(moveQuery, parentId, id, i+1); err != nil {
+			tx.Rollback()
+			log.Println("curriculumRepository.reorder:", err.Error())
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func NewCurriculumRepository(db *sql.DB) CurriculumRepository {
+	return &curriculumRepository{db: db}
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"database/sql"
+	"instructor-led-app/config"
+	"instructor-led-app/entity"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/shared/model"
+	"instructor-led-app/shared/query"
+	"log"
+	"time"
+)
+
+type FeedbackRepository interface {
+	Create(feedback entity.SessionFeedback) (entity.SessionFeedback, error)
+	Exists(scheduleId, participantId string) (bool, error)
+	ListByTrainer(trainerId string, spec query.Spec) ([]dto.AnonymousFeedbackDTO, model.Paging, error)
+	RatingSummary(interval string, startDate, endDate time.Time, trainerId string) ([]dto.TrainerRatingDTO, error)
+}
+
+type feedbackRepository struct {
+	db *sql.DB
+}
+
+// Create implements FeedbackRepository.
+func (f *feedbackRepository) Create(feedback entity.SessionFeedback) (entity.SessionFeedback, error) {
+	var created entity.SessionFeedback
+	if err := f.db.QueryRow(config.InsertFeedback, feedback.ScheduleID, feedback.TrainerID, feedback.ParticipantID, feedback.Rating, feedback.Comment).Scan(
+		&created.ID,
+		&created.ScheduleID,
+		&created.TrainerID,
+		&created.ParticipantID,
+		&created.Rating,
+		&created.Comment,
+		&created.CreatedAt); err != nil {
+		log.Println("feedbackRepository.Create:", err.Error())
+		return entity.SessionFeedback{}, err
+	}
+	return created, nil
+}
+
+// Exists implements FeedbackRepository.
+func (f *feedbackRepository) Exists(scheduleId, participantId string) (bool, error) {
+	total := 0
+	if err := f.db.QueryRow(config.CountFeedbackByParticipant, scheduleId, participantId).Scan(&total); err != nil {
+		return false, err
+	}
+	return total > 0, nil
+}
+
+// feedbackList is what the feedback of a trainer can be filtered and sorted on. Nothing about
+// the participant is a field, the feedback is anonymous.
+var feedbackList = query.Table{
+	Fields: map[string]query.Field{
+		"trainerId":    {Column: "f.trainer_id", Type: query.UUID},
+		"scheduleId":   {Column: "f.schedule_id", Type: query.UUID},
+		"activity":     {Column: "s.activity", Type: query.Text},
+		"scheduleDate": {Column: "s.date", Type: query.Date, Sortable: true},
+		"rating":       {Column: "f.rating", Type: query.Number, Sortable: true},
+		"createdAt":    {Column: "f.created_at", Type: query.Timestamp, Sortable: true},
+	},
+	Key:         "f.id",
+	DefaultSort: []query.Sort{{Field: "createdAt", Desc: true}},
+}
+
+// ListByTrainer implements FeedbackRepository.
+func (f *feedbackRepository) ListByTrainer(trainerId string, spec query.Spec) ([]dto.AnonymousFeedbackDTO, model.Paging, error) {
+	spec.Where("trainerId", query.Eq, trainerId)
+	stmt, err := feedbackList.Select(config.ListTrainerFeedback, spec)
+	if err != nil {
+		return nil, model.Paging{}, err
+	}
+	rows, err := f.db.Query(stmt.Query, stmt.Args...)
+	if err != nil {
+		log.Println("feedbackRepository.ListByTrainer:", err.Error())
+		return nil, model.Paging{}, err
+	}
+	defer rows.Close()
+
+	var feedbacks []dto.AnonymousFeedbackDTO
+	for stmt.Next(rows) {
+		var feedback dto.AnonymousFeedbackDTO
+		if err := stmt.Scan(rows, &feedback.ID, &feedback.Activity, &feedback.ScheduleDate, &feedback.Rating, &feedback.Comment, &feedback.CreatedAt); err != nil {
+			return nil, model.Paging{}, err
+		}
+		feedbacks = append(feedbacks, feedback)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, model.Paging{}, err
+	}
+
+	paging, err := stmt.Paging(f.db)
+	if err != nil {
+		return nil, model.Paging{}, err
+	}
+	return feedbacks, paging, nil
+}
+
+// RatingSummary implements FeedbackRepository.
+func (f *feedbackRepository) RatingSummary(interval string, startDate, endDate time.Time, trainerId string) ([]dto.TrainerRatingDTO, error) {
+	rows, err := f.db.Query(config.TrainerRatingSummary, interval, startDate, endDate, trainerId)
+	if err != nil {
+		log.Println("feedbackRepository.RatingSummary:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ratings []dto.TrainerRatingDTO
+	for rows.Next() {
+		var rating dto.TrainerRatingDTO
+		if err := rows.Scan(&rating.TrainerID, &rating.TrainerName, &rating.Period, &rating.Responses, &rating.AverageRating, &rating.LowestRating, &rating.HighestRating); err != nil {
+			return nil, err
+		}
+		ratings = append(ratings, rating)
+	}
+	return ratings, nil
+}
+
+func NewFeedbackRepository(db *sql.DB) FeedbackRepository {
+	return &feedbackRepository{db: db}
+}
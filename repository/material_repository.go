@@ -0,0 +1,255 @@
+package repository
+
+import (
+	"database/sql"
+	"instructor-led-app/config"
+	"instructor-led-app/entity"
+	"instructor-led-app/entity/dto"
+	"log"
+	"time"
+)
+
+type MaterialRepository interface {
+	Create(material entity.Material, version entity.MaterialVersion) (entity.Material, error)
+	AddVersion(version entity.MaterialVersion) (entity.MaterialVersion, error)
+	List(scheduleId, lessonId string) ([]entity.Material, error)
+	Get(id string) (entity.Material, error)
+	Update(id string, payload dto.MaterialUpdateDTO, updatedAt time.Time) error
+	Delete(id string) error
+	ListVersions(materialId string) ([]entity.MaterialVersion, error)
+	GetVersion(materialId string, version int) (entity.MaterialVersion, error)
+	RecordDownload(materialId, versionId, userId string) error
+	Downloads(materialId string) (dto.MaterialDownloadsDTO, error)
+	HasLessonSession(lessonId, participantId string) (bool, error)
+	ListForParticipant(participantId, when string) ([]entity.SessionMaterials, error)
+}
+
+type materialRepository struct {
+	db *sql.DB
+}
+
+// materialFields are the scan destinations of config.materialColumns.
+func materialFields(material *entity.Material) []interface{} {
+	return []interface{}{&material.ID, &material.ScheduleID, &material.LessonID, &material.Title, &material.Description, &material.Kind, &material.CurrentVersion,
+		&material.FileName, &material.Size, &material.MimeType, &material.URL, &material.UploadedBy, &material.Downloads, &material.CreatedAt, &material.UpdatedAt}
+}
+
+func scanMaterialVersion(row rowScanner) (entity.MaterialVersion, error) {
+	var version entity.MaterialVersion
+	err := row.Scan(&version.ID, &version.MaterialID, &version.Version, &version.Kind, &version.FileName, &version.StorageKey, &version.Size, &version.MimeType,
+		&version.Checksum, &version.URL, &version.Note, &version.UploadedBy, &version.CreatedAt)
+	return version, err
+}
+
+func insertMaterialVersion(tx *sql.Tx, version *entity.MaterialVersion) error {
+	return tx.QueryRow(config.InsertMaterialVersion, version.MaterialID, version.Version, version.Kind, version.FileName, version.StorageKey, version.Size,
+		version.MimeType, version.Checksum, version.URL, version.Note, version.UploadedBy).Scan(&version.ID, &version.CreatedAt)
+}
+
+// Create implements MaterialRepository. The material is saved with its first version.
+func (m *materialRepository) Create(material entity.Material, version entity.MaterialVersion) (entity.Material, error) {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return entity.Material{}, err
+	}
+
+	err = tx.QueryRow(config.InsertMaterial, material.ScheduleID, material.LessonID, material.Title, material.Description, material.Kind, material.UploadedBy).
+		Scan(&material.ID, &material.CurrentVersion, &material.CreatedAt, &material.UpdatedAt)
+	if err != nil {
+		tx.Rollback()
+		log.Println("materialRepository.Create:", err.Error())
+		return entity.Material{}, err
+	}
+
+	version.MaterialID, version.Version = material.ID, material.CurrentVersion
+	if err := insertMaterialVersion(tx, &version); err != nil {
+		tx.Rollback()
+		log.Println("materialRepository.Create:", err.Error())
+		return entity.Material{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return entity.Material{}, err
+	}
+
+	material.FileName, material.Size, material.MimeType, material.URL = version.FileName, version.Size, version.MimeType, version.URL
+	material.Versions = []entity.MaterialVersion{version}
+	return material, nil
+}
+
+// AddVersion implements MaterialRepository. The new version becomes the current one.
+func (m *materialRepository) AddVersion(version entity.MaterialVersion) (entity.MaterialVersion, error) {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return entity.MaterialVersion{}, err
+	}
+
+	if err := tx.QueryRow(config.BumpMaterialVersion, version.MaterialID, version.Kind, time.Now()).Scan(&version.Version); err != nil {
+		tx.Rollback()
+		log.Println("materialRepository.AddVersion:", err.Error())
+		return entity.MaterialVersion{}, err
+	}
+	if err := insertMaterialVersion(tx, &version); err != nil {
+		tx.Rollback()
+		log.Println("materialRepository.AddVersion:", err.Error())
+		return entity.MaterialVersion{}, err
+	}
+	return version, tx.Commit()
+}
+
+// List implements MaterialRepository.
+func (m *materialRepository) List(scheduleId, lessonId string) ([]entity.Material, error) {
+	rows, err := m.db.Query(config.ListMaterials, scheduleId, lessonId)
+	if err != nil {
+		log.Println("materialRepository.List:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var materials []entity.Material
+	for rows.Next() {
+		var material entity.Material
+		if err := rows.Scan(materialFields(&material)...); err != nil {
+			return nil, err
+		}
+		materials = append(materials, material)
+	}
+	return materials, nil
+}
+
+// Get implements MaterialRepository.
+func (m *materialRepository) Get(id string) (entity.Material, error) {
+	var material entity.Material
+	if err := m.db.QueryRow(config.GetMaterialByID, id).Scan(materialFields(&material)...); err != nil {
+		log.Println("materialRepository.Get:", err.Error())
+		return entity.Material{}, err
+	}
+	return material, nil
+}
+
+// Update implements MaterialRepository.
+func (m *materialRepository) Update(id string, payload dto.MaterialUpdateDTO, updatedAt time.Time) error {
+	if _, err := m.db.Exec(config.UpdateMaterial, id, payload.Title, payload.Description, updatedAt); err != nil {
+		log.Println("materialRepository.Update:", err.Error())
+		return err
+	}
+	return nil
+}
+
+// Delete implements MaterialRepository. Stored files are content addressed and may be shared, so they are kept.
+func (m *materialRepository) Delete(id string) error {
+	if _, err := m.db.Exec(config.DeleteMaterial, id); err != nil {
+		log.Println("materialRepository.Delete:", err.Error())
+		return err
+	}
+	return nil
+}
+
+// ListVersions implements MaterialRepository. The newest version comes first.
+func (m *materialRepository) ListVersions(materialId string) ([]entity.MaterialVersion, error) {
+	rows, err := m.db.Query(config.ListMaterialVersions, materialId)
+	if err != nil {
+		log.Println("materialRepository.ListVersions:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions []entity.MaterialVersion
+	for rows.Next() {
+		version, err := scanMaterialVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
+
+// GetVersion implements MaterialRepository.
+func (m *materialRepository) GetVersion(materialId string, version int) (entity.MaterialVersion, error) {
+	return scanMaterialVersion(m.db.QueryRow(config.GetMaterialVersion, materialId, version))
+}
+
+// RecordDownload implements MaterialRepository.
+func (m *materialRepository) RecordDownload(materialId, versionId, userId string) error {
+	if _, err := m.db.Exec(config.InsertMaterialDownload, materialId, versionId, userId); err != nil {
+		log.Println("materialRepository.RecordDownload:", err.Error())
+		return err
+	}
+	return nil
+}
+
+// Downloads implements MaterialRepository.
+func (m *materialRepository) Downloads(materialId string) (dto.MaterialDownloadsDTO, error) {
+	result := dto.MaterialDownloadsDTO{MaterialID: materialId, ByVersion: []dto.MaterialVersionDownloadsDTO{}, Users: []dto.MaterialUserDownloadsDTO{}}
+
+	rows, err := m.db.Query(config.MaterialDownloadsByVersion, materialId)
+	if err != nil {
+		log.Println("materialRepository.Downloads:", err.Error())
+		return dto.MaterialDownloadsDTO{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var item dto.MaterialVersionDownloadsDTO
+		if err := rows.Scan(&item.Version, &item.Downloads); err != nil {
+			return dto.MaterialDownloadsDTO{}, err
+		}
+		result.TotalDownloads += item.Downloads
+		result.ByVersion = append(result.ByVersion, item)
+	}
+
+	userRows, err := m.db.Query(config.MaterialDownloadsByUser, materialId)
+	if err != nil {
+		log.Println("materialRepository.Downloads:", err.Error())
+		return dto.MaterialDownloadsDTO{}, err
+	}
+	defer userRows.Close()
+	for userRows.Next() {
+		var item dto.MaterialUserDownloadsDTO
+		if err := userRows.Scan(&item.UserID, &item.Name, &item.Role, &item.Downloads, &item.LastDownloaded); err != nil {
+			return dto.MaterialDownloadsDTO{}, err
+		}
+		result.Users = append(result.Users, item)
+	}
+	result.UniqueUsers = len(result.Users)
+	return result, nil
+}
+
+// HasLessonSession implements MaterialRepository.
+func (m *materialRepository) HasLessonSession(lessonId, participantId string) (bool, error) {
+	var total int
+	if err := m.db.QueryRow(config.CountParticipantLessonSessions, lessonId, participantId).Scan(&total); err != nil {
+		return false, err
+	}
+	return total > 0, nil
+}
+
+// ListForParticipant implements MaterialRepository. when is "upcoming", "past" or empty for both;
+// sessions come oldest first.
+func (m *materialRepository) ListForParticipant(participantId, when string) ([]entity.SessionMaterials, error) {
+	rows, err := m.db.Query(config.ListParticipantMaterials, participantId, when)
+	if err != nil {
+		log.Println("materialRepository.ListForParticipant:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []entity.SessionMaterials{}
+	for rows.Next() {
+		var session entity.SessionMaterials
+		var material entity.Material
+		dest := append([]interface{}{&session.ScheduleID, &session.Activity, &session.Date}, materialFields(&material)...)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		if len(sessions) == 0 || sessions[len(sessions)-1].ScheduleID != session.ScheduleID {
+			sessions = append(sessions, session)
+		}
+		last := &sessions[len(sessions)-1]
+		last.Materials = append(last.Materials, material)
+	}
+	return sessions, nil
+}
+
+func NewMaterialRepository(db *sql.DB) MaterialRepository {
+	return &materialRepository{db: db}
+}
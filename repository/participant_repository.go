@@ -3,11 +3,11 @@ package repository
 import (
 	"database/sql"
 	"errors"
-	"fmt"
 	"instructor-led-app/config"
 	"time"
 
 	"instructor-led-app/entity/dto"
+	"instructor-led-app/shared/apperror"
 	"instructor-led-app/shared/model"
 	"log"
 	"math"
@@ -29,10 +29,21 @@ type participantRepository struct {
 	db *sql.DB
 }
 
-// UpdateByRole implements ParticipantRepository.
+// UpdateByRole implements ParticipantRepository. The change is kept in the track history.
 func (r *participantRepository) UpdateByRole(role string, id string) error {
-	r.db.Exec(config.UpdateParticipantByRole, id, role)
-	return nil
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(config.InsertManualTrackHistory, id, role); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.Exec(config.UpdateParticipantByRole, id, role); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
 }
 
 // GetParticipantByUserId implements ParticipantRepository.
@@ -47,7 +58,7 @@ func (r *participantRepository) GetParticipantByUserId(id string) (dto.Participa
 		&participant.UserID,
 	); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return dto.ParticipantDTO{}, fmt.Errorf("participant with id '%s' not found", id)
+			return dto.ParticipantDTO{}, apperror.NotFound("participant with id '%s' not found", id)
 		}
 
 		return dto.ParticipantDTO{}, err
@@ -159,13 +170,13 @@ func (p *participantRepository) GetScheduleById(id string) ([]dto.ParticipantDTO
 			// query basic
 			rows, err = p.db.Query(config.SelectParticipantRoleBasic, id)
 		} else {
-			return nil, errors.New("tidak ada jadwal")
+			return nil, apperror.NotFound("no schedule today")
 		}
 	} else {
 		if time.Now().Weekday() == time.Tuesday {
 			rows, err = p.db.Query(config.SelectParticipantRoleAdvance, id)
 		} else {
-			return nil, errors.New("tidak ada jadwal")
+			return nil, apperror.NotFound("no schedule today")
 		}
 	}
 
@@ -281,7 +292,7 @@ func (r *participantRepository) FindByID(id string) (dto.ParticipantDTO, error)
 		&participant.Role,
 	); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return dto.ParticipantDTO{}, fmt.Errorf("participant with id '%s' not found", id)
+			return dto.ParticipantDTO{}, apperror.NotFound("participant with id '%s' not found", id)
 		}
 
 		return dto.ParticipantDTO{}, err
@@ -308,7 +319,7 @@ func (r *participantRepository) DeleteByID(id string) error {
 
 	rowsAffected, _ := result.RowsAffected()
 	if rowsAffected == 0 {
-		return fmt.Errorf("participant with id '%s' not found", id)
+		return apperror.NotFound("participant with id '%s' not found", id)
 	}
 
 	return nil
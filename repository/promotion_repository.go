@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"database/sql"
+	"instructor-led-app/config"
+	"instructor-led-app/entity"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/shared/model"
+	"instructor-led-app/shared/query"
+	"log"
+	"time"
+)
+
+type PromotionRepository interface {
+	GetCriteria() (entity.PromotionCriteria, error)
+	UpdateCriteria(payload entity.PromotionCriteria) (entity.PromotionCriteria, error)
+	ListProgress(participantId, track string) ([]dto.ParticipantProgressDTO, error)
+	HasOpenRequest(participantId string, sessionsCompleted int) (bool, error)
+	CreateRequest(payload entity.PromotionRequest) (entity.PromotionRequest, error)
+	ListRequests(spec query.Spec) ([]entity.PromotionRequest, model.Paging, error)
+	GetRequest(id string) (entity.PromotionRequest, error)
+	Reject(id, reason, reviewedBy string, reviewedAt time.Time) error
+	Approve(id, reason, reviewedBy, cohortId string, reviewedAt time.Time) (bool, error)
+	ListHistory(participantId string) ([]entity.TrackHistory, error)
+}
+
+type promotionRepository struct {
+	db *sql.DB
+}
+
+func scanPromotionRequest(row rowScanner) (entity.PromotionRequest, error) {
+	var request entity.PromotionRequest
+	var score sql.NullFloat64
+	var reviewedAt sql.NullTime
+	err := row.Scan(&request.ID, &request.ParticipantID, &request.ParticipantName, &request.FromTrack, &request.ToTrack, &request.AttendanceRate, &score, &request.SessionsCompleted,
+		&request.Status, &request.Reason, &request.ReviewedBy, &reviewedAt, &request.CreatedAt)
+	if score.Valid {
+		request.AssessmentScore = &score.Float64
+	}
+	if reviewedAt.Valid {
+		request.ReviewedAt = &reviewedAt.Time
+	}
+	return request, err
+}
+
+// GetCriteria implements PromotionRepository.
+func (p *promotionRepository) GetCriteria() (entity.PromotionCriteria, error) {
+	var criteria entity.PromotionCriteria
+	if err := p.db.QueryRow(config.GetPromotionCriteria).Scan(&criteria.MinAttendanceRate, &criteria.MinAssessmentScore, &criteria.MinSessionsCompleted, &criteria.UpdatedAt); err != nil {
+		log.Println("promotionRepository.GetCriteria:", err.Error())
+		return entity.PromotionCriteria{}, err
+	}
+	return criteria, nil
+}
+
+// UpdateCriteria implements PromotionRepository.
+func (p *promotionRepository) UpdateCriteria(payload entity.PromotionCriteria) (entity.PromotionCriteria, error) {
+	var criteria entity.PromotionCriteria
+	if err := p.db.QueryRow(config.UpdatePromotionCriteria, payload.MinAttendanceRate, payload.MinAssessmentScore, payload.MinSessionsCompleted, payload.UpdatedAt).
+		Scan(&criteria.MinAttendanceRate, &criteria.MinAssessmentScore, &criteria.MinSessionsCompleted, &criteria.UpdatedAt); err != nil {
+		log.Println("promotionRepository.UpdateCriteria:", err.Error())
+		return entity.PromotionCriteria{}, err
+	}
+	return criteria, nil
+}
+
+// ListProgress implements PromotionRepository. Empty arguments match every participant.
+func (p *promotionRepository) ListProgress(participantId, track string) ([]dto.ParticipantProgressDTO, error) {
+	rows, err := p.db.Query(config.ListParticipantProgress, participantId, track)
+	if err != nil {
+		log.Println("promotionRepository.ListProgress:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var progress []dto.ParticipantProgressDTO
+	for rows.Next() {
+		var item dto.ParticipantProgressDTO
+		var score sql.NullFloat64
+		if err := rows.Scan(&item.ParticipantID, &item.Name, &item.Track, &item.SessionsCompleted, &item.SessionsRecorded, &score); err != nil {
+			return nil, err
+		}
+		if score.Valid {
+			item.AssessmentScore = &score.Float64
+		}
+		progress = append(progress, item)
+	}
+	return progress, nil
+}
+
+// HasOpenRequest implements PromotionRepository.
+func (p *promotionRepository) HasOpenRequest(participantId string, sessionsCompleted int) (bool, error) {
+	var total int
+	if err := p.db.QueryRow(config.CountOpenPromotion, participantId, sessionsCompleted).Scan(&total); err != nil {
+		return false, err
+	}
+	return total > 0, nil
+}
+
+// CreateRequest implements PromotionRepository.
+func (p *promotionRepository) CreateRequest(payload entity.PromotionRequest) (entity.PromotionRequest, error) {
+	if err := p.db.QueryRow(config.InsertPromotionRequest, payload.ParticipantID, payload.FromTrack, payload.ToTrack, payload.AttendanceRate, payload.AssessmentScore, payload.SessionsCompleted).
+		Scan(&payload.ID, &payload.Status, &payload.CreatedAt); err != nil {
+		log.Println("promotionRepository.CreateRequest:", err.Error())
+		return entity.PromotionRequest{}, err
+	}
+	return payload, nil
+}
+
+// promotionList is what the promotion requests can be filtered and sorted on.
+var promotionList = query.Table{
+	Fields: map[string]query.Field{
+		"status":        {Column: "r.status::text", Type: query.Text},
+		"participantId": {Column: "r.participant_id", Type: query.UUID},
+		"fromTrack":     {Column: "r.from_track::text", Type: query.Text},
+		"toTrack":       {Column: "r.to_track::text", Type: query.Text},
+		"createdAt":     {Column: "r.created_at", Type: query.Timestamp, Sortable: true},
+	},
+	Key:         "r.id",
+	DefaultSort: []query.Sort{{Field: "createdAt", Desc: true}},
+}
+
+// ListRequests implements PromotionRepository.
+func (p *promotionRepository) ListRequests(spec query.Spec) ([]entity.PromotionRequest, model.Paging, error) {
+	stmt, err := promotionList.Select(config.ListPromotionRequests, spec)
+	if err != nil {
+		return nil, model.Paging{}, err
+	}
+	rows, err := p.db.Query(stmt.Query, stmt.Args...)
+	if err != nil {
+		log.Println("promotionRepository.ListRequests:", err.Error())
+		return nil, model.Paging{}, err
+	}
+	defer rows.Close()
+
+	var requests []entity.PromotionRequest
+	for stmt.Next(rows) {
+		request, err := scanPromotionRequest(stmt.Row(rows))
+		if err != nil {
+			return nil, model.Paging{}, err
+		}
+		requests = append(requests, request)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, model.Paging{}, err
+	}
+
+	paging, err := stmt.Paging(p.db)
+	if err != nil {
+		return nil, model.Paging{}, err
+	}
+	return requests, paging, nil
+}
+
+// GetRequest implements PromotionRepository.
+func (p *promotionRepository) GetRequest(id string) (entity.PromotionRequest, error) {
+	request, err := scanPromotionRequest(p.db.QueryRow(config.GetPromotionRequestByID, id))
+	if err != nil {
+		log.Println("promotionRepository.GetRequest:", err.Error())
+		return entity.PromotionRequest{}, err
+	}
+	return request, nil
+}
+
+// Reject implements PromotionRepository.
+func (p *promotionRepository) Reject(id, reason, reviewedBy string, reviewedAt time.Time) error {
+	var participantId, fromTrack, toTrack string
+	if err := p.db.QueryRow(config.DecidePromotionRequest, id, "Rejected", reason, reviewedBy, reviewedAt).Scan(&participantId, &fromTrack, &toTrack); err != nil {
+		log.Println("promotionRepository.Reject:", err.Error())
+		return err
+	}
+	return nil
+}
+
+// Approve implements PromotionRepository. The participant changes track, the change is
+// recorded, and they leave the cohorts of the old track for cohortId. It reports true when
+// cohortId is full and they were put on its waitlist instead.
+func (p *promotionRepository) Approve(id, reason, reviewedBy, cohortId string, reviewedAt time.Time) (bool, error) {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	var participantId, fromTrack, toTrack string
+	if err := tx.QueryRow(config.DecidePromotionRequest, id, "Approved", reason, reviewedBy, reviewedAt).Scan(&participantId, &fromTrack, &toTrack); err != nil {
+		tx.Rollback()
+		log.Println("promotionRepository.Approve:", err.Error())
+		return false, err
+	}
+
+	statements := [][]interface{}{
+		{config.UpdateParticipantByRole, participantId, toTrack},
+		{config.InsertTrackHistory, participantId, fromTrack, toTrack, "promotion", id},
+		{config.DeleteUpcomingTrackAttendance, participantId, fromTrack},
+		{config.DeleteTrackEnrollments, participantId, fromTrack},
+		{config.DeleteTrackWaitlist, participantId, fromTrack},
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(statement[0].(string), statement[1:]...); err != nil {
+			tx.Rollback()
+			log.Println("promotionRepository.Approve:", err.Error())
+			return false, err
+		}
+	}
+
+	var waitlisted []string
+	if cohortId != "" {
+		if waitlisted, err = enroll(tx, cohortId, []string{participantId}); err != nil {
+			tx.Rollback()
+			log.Println("promotionRepository.Approve:", err.Error())
+			return false, err
+		}
+	}
+	return len(waitlisted) > 0, tx.Commit()
+}
+
+// ListHistory implements PromotionRepository.
+func (p *promotionRepository) ListHistory(participantId string) ([]entity.TrackHistory, error) {
+	rows, err := p.db.Query(config.ListTrackHistory, participantId)
+	if err != nil {
+		log.Println("promotionRepository.ListHistory:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []entity.TrackHistory
+	for rows.Next() {
+		var item entity.TrackHistory
+		if err := rows.Scan(&item.ID, &item.ParticipantID, &item.FromTrack, &item.ToTrack, &item.Source, &item.PromotionID, &item.ChangedAt); err != nil {
+			return nil, err
+		}
+		history = append(history, item)
+	}
+	return history, nil
+}
+
+func NewPromotionRepository(db *sql.DB) PromotionRepository {
+	return &promotionRepository{db: db}
+}
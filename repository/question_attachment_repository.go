@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"database/sql"
+	"instructor-led-app/config"
+	"instructor-led-app/entity"
+	"log"
+)
+
+type QuestionAttachmentRepository interface {
+	Create(payload entity.QuestionAttachment) (entity.QuestionAttachment, error)
+	ListByQuestionID(questionId string) ([]entity.QuestionAttachment, error)
+	Get(id string) (entity.QuestionAttachment, error)
+}
+
+type questionAttachmentRepository struct {
+	db *sql.DB
+}
+
+// Create implements QuestionAttachmentRepository.
+func (r *questionAttachmentRepository) Create(payload entity.QuestionAttachment) (entity.QuestionAttachment, error) {
+	attachment := payload
+	if err := r.db.QueryRow(config.InsertQuestionAttachment,
+		payload.QuestionID,
+		payload.Kind,
+		payload.OriginalName,
+		payload.StorageKey,
+		payload.Size,
+		payload.MimeType,
+		payload.UploadedBy).Scan(&attachment.ID, &attachment.CreatedAt); err != nil {
+		log.Println("questionAttachmentRepository.Create:", err.Error())
+		return entity.QuestionAttachment{}, err
+	}
+	return attachment, nil
+}
+
+// ListByQuestionID implements QuestionAttachmentRepository.
+func (r *questionAttachmentRepository) ListByQuestionID(questionId string) ([]entity.QuestionAttachment, error) {
+	rows, err := r.db.Query(config.ListQuestionAttachments, questionId)
+	if err != nil {
+		log.Println("questionAttachmentRepository.ListByQuestionID:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attachments []entity.QuestionAttachment
+	for rows.Next() {
+		var attachment entity.QuestionAttachment
+		if err := rows.Scan(&attachment.ID, &attachment.QuestionID, &attachment.Kind, &attachment.OriginalName, &attachment.StorageKey, &attachment.Size, &attachment.MimeType, &attachment.UploadedBy, &attachment.CreatedAt); err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments, nil
+}
+
+// Get implements QuestionAttachmentRepository.
+func (r *questionAttachmentRepository) Get(id string) (entity.QuestionAttachment, error) {
+	var attachment entity.QuestionAttachment
+	if err := r.db.QueryRow(config.GetQuestionAttachment, id).Scan(&attachment.ID, &attachment.QuestionID, &attachment.Kind, &attachment.OriginalName, &attachment.StorageKey, &attachment.Size, &attachment.MimeType, &attachment.UploadedBy, &attachment.CreatedAt); err != nil {
+		log.Println("questionAttachmentRepository.Get:", err.Error())
+		return entity.QuestionAttachment{}, err
+	}
+	return attachment, nil
+}
+
+func NewQuestionAttachmentRepository(db *sql.DB) QuestionAttachmentRepository {
+	return &questionAttachmentRepository{db: db}
+}
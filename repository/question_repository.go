@@ -6,29 +6,97 @@ import (
 	"instructor-led-app/entity"
 	"instructor-led-app/entity/dto"
 	"instructor-led-app/shared/model"
+	"instructor-led-app/shared/query"
 	"log"
 	"math"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
 
 type QuestionRepository interface {
-	List(page, size int) ([]entity.Question, model.Paging, error)
+	List(spec query.Spec) ([]entity.Question, model.Paging, error)
 	Get(id string) (entity.Question, error)
 	Create(payload entity.Question) (entity.Question, error)
 	Delete(id string) error
-	Update(id string, payload entity.Question) (entity.Question, error)
+	Update(id, status string, updatedAt time.Time) error
 	GetQuestionByTrainerId(id string, page, size int) ([]entity.Question, model.Paging, error)
 	CreateQuestionByTrainer(participantId string, payload dto.QuestionDTO) (dto.QuestionDTO, error)
 	UpdateQuestionStatusByTrainer(participantId string, payload dto.QuestionDTO) (dto.QuestionDTO, error)
 	GetQuestionByScheduleIdandParticipantId(scheduleId, participantId string) (entity.Question, error)
 	CreateQuestionByParticipant(participantId string, payload dto.QuestionDto) (dto.QuestionDto, error)
+	Vote(questionId, participantId string) (int, error)
+	Unvote(questionId, participantId string) (int, error)
+	ListBySession(scheduleId string, sortByVotes bool) ([]entity.Question, error)
 }
 
 type questionRepository struct {
 	db *sql.DB
 }
 
+// Vote implements QuestionRepository.
+func (q *questionRepository) Vote(questionId, participantId string) (int, error) {
+	if _, err := q.db.Exec(config.InsertQuestionVote, questionId, participantId); err != nil {
+		log.Println("questionRepository.Vote:", err.Error())
+		return 0, err
+	}
+	return q.countVotes(questionId)
+}
+
+// Unvote implements QuestionRepository.
+func (q *questionRepository) Unvote(questionId, participantId string) (int, error) {
+	if _, err := q.db.Exec(config.DeleteQuestionVote, questionId, participantId); err != nil {
+		log.Println("questionRepository.Unvote:", err.Error())
+		return 0, err
+	}
+	return q.countVotes(questionId)
+}
+
+func (q *questionRepository) countVotes(questionId string) (int, error) {
+	votes := 0
+	if err := q.db.QueryRow(config.CountQuestionVotes, questionId).Scan(&votes); err != nil {
+		return 0, err
+	}
+	return votes, nil
+}
+
+// ListBySession implements QuestionRepository.
+func (q *questionRepository) ListBySession(scheduleId string, sortByVotes bool) ([]entity.Question, error) {
+	query := config.SelectSessionQuestions + config.OrderQuestionsByTime
+	if sortByVotes {
+		query = config.SelectSessionQuestions + config.OrderQuestionsByVotes
+	}
+
+	rows, err := q.db.Query(query, scheduleId)
+	if err != nil {
+		log.Println("questionRepository.ListBySession:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var questions []entity.Question
+	for rows.Next() {
+		var question entity.Question
+		if err := rows.Scan(
+			&question.ID,
+			&question.Question,
+			&question.Answer,
+			&question.Status,
+			&question.ParticipantID,
+			&question.TrainerID,
+			&question.ScheduleID,
+			&question.IsAnonymous,
+			&question.Votes,
+			&question.CreatedAt,
+			&question.UpdatedAt); err != nil {
+			log.Println("questionRepository.ListBySession.Scan:", err.Error())
+			return nil, err
+		}
+		questions = append(questions, question)
+	}
+	return questions, nil
+}
+
 // Create Quetion Boleh gw Nihhhh
 func (q *questionRepository) CreateQuestionByParticipant(participantId string, payload dto.QuestionDto) (dto.QuestionDto, error) {
 	var question dto.QuestionDto
@@ -38,7 +106,8 @@ func (q *questionRepository) CreateQuestionByParticipant(participantId string, p
 		payload.Question,
 		status,
 		participantId,
-		payload.ScheduleID).Scan(
+		payload.ScheduleID,
+		payload.IsAnonymous).Scan(
 		&question.ID,
 		&question.ParticipantID,
 		&question.ScheduleID,
@@ -51,6 +120,7 @@ func (q *questionRepository) CreateQuestionByParticipant(participantId string, p
 
 	question.Question = payload.Question
 	question.ScheduleID = payload.ScheduleID
+	question.IsAnonymous = payload.IsAnonymous
 
 	return question, nil
 }
@@ -66,7 +136,7 @@ func (q *questionRepository) GetQuestionByTrainerId(id string, page, size int) (
 
 	for rows.Next() {
 		var question entity.Question
-		err := rows.Scan(&question.ID, &question.Question, &question.Status, &question.ParticipantID, &question.TrainerID, &question.ScheduleID, &question.CreatedAt, &question.UpdatedAt)
+		err := rows.Scan(&question.ID, &question.Question, &question.Status, &question.ParticipantID, &question.TrainerID, &question.ScheduleID, &question.IsAnonymous, &question.CreatedAt, &question.UpdatedAt)
 		if err != nil {
 			return nil, model.Paging{}, err
 		}
@@ -202,6 +272,7 @@ func (q *questionRepository) Get(id string) (entity.Question, error) {
 		&question.ParticipantID,
 		&question.TrainerID,
 		&question.ScheduleID,
+		&question.IsAnonymous,
 		&question.CreatedAt,
 		&question.UpdatedAt,
 	)
@@ -230,52 +301,73 @@ func (q *questionRepository) Delete(id string) error {
 	return nil
 }
 
-func (q *questionRepository) Update(id string, payload entity.Question) (entity.Question, error) {
-	_, err := q.db.Exec(config.UpdateQuestion, id, payload.Status)
+func (q *questionRepository) Update(id, status string, updatedAt time.Time) error {
+	result, err := q.db.Exec(config.UpdateQuestion, id, status, updatedAt)
 	if err != nil {
 		log.Println("questionRepository.Exec:", err.Error())
-		return entity.Question{}, err
+		return err
+	}
+	if updated, _ := result.RowsAffected(); updated == 0 {
+		return sql.ErrNoRows
 	}
-	return payload, nil
+	return nil
 }
 
-func (q *questionRepository) List(page, size int) ([]entity.Question, model.Paging, error) {
-	var questions []entity.Question
-	offset := (page - 1) * size
-	rows, err := q.db.Query(config.SelectQuestionList, size, offset)
+// questionList is what the question list can be filtered and sorted on. The asker isn't a
+// field, filtering on it would reveal who asked the anonymous questions.
+var questionList = query.Table{
+	Fields: map[string]query.Field{
+		"question":    {Column: "question", Type: query.Text},
+		"status":      {Column: "status::text", Type: query.Text},
+		"trainerId":   {Column: "trainer_id", Type: query.UUID},
+		"scheduleId":  {Column: "schedule_id", Type: query.UUID},
+		"isAnonymous": {Column: "is_anonymous", Type: query.Bool},
+		"createdAt":   {Column: "created_at", Type: query.Timestamp, Sortable: true},
+		"updatedAt":   {Column: "updated_at", Type: query.Timestamp, Sortable: true},
+	},
+	Key:         "id",
+	DefaultSort: []query.Sort{{Field: "createdAt", Desc: true}},
+}
+
+func (q *questionRepository) List(spec query.Spec) ([]entity.Question, model.Paging, error) {
+	stmt, err := questionList.Select(config.SelectQuestionList, spec)
 	if err != nil {
-		log.Println("taskRepository.Query:", err.Error())
 		return nil, model.Paging{}, err
 	}
-	for rows.Next() {
+	rows, err := q.db.Query(stmt.Query, stmt.Args...)
+	if err != nil {
+		log.Println("questionRepository.Query:", err.Error())
+		return nil, model.Paging{}, err
+	}
+	defer rows.Close()
+
+	var questions []entity.Question
+	for stmt.Next(rows) {
 		var question entity.Question
-		err := rows.Scan(
+		err := stmt.Scan(rows,
 			&question.ID,
 			&question.Question,
 			&question.Status,
 			&question.ParticipantID,
 			&question.TrainerID,
 			&question.ScheduleID,
+			&question.IsAnonymous,
 			&question.CreatedAt,
 			&question.UpdatedAt)
 		if err != nil {
-			log.Println("taskRepository.Rows.Next():", err.Error())
+			log.Println("questionRepository.Rows.Next():", err.Error())
 			return nil, model.Paging{}, err
 		}
 
 		questions = append(questions, question)
 	}
-	totalRows := 0
-
-	if err := q.db.QueryRow("SELECT COUNT(*) FROM questions").Scan(&totalRows); err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, model.Paging{}, err
 	}
 
-	paging := model.Paging{
-		Page:        page,
-		RowsPerPage: size,
-		TotalRows:   totalRows,
-		TotalPages:  int(math.Ceil(float64(totalRows) / float64(size))),
+	paging, err := stmt.Paging(q.db)
+	if err != nil {
+		return nil, model.Paging{}, err
 	}
 	return questions, paging, nil
 }
@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"database/sql"
+	"instructor-led-app/config"
+	"instructor-led-app/entity"
+	"instructor-led-app/shared/model"
+	"instructor-led-app/shared/query"
+	"log"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type RegistrationRepository interface {
+	IdentityTaken(email, username string) (bool, error)
+	Create(payload entity.Registration, password, tokenHash string, expiresAt time.Time) (entity.Registration, error)
+	List(spec query.Spec) ([]entity.Registration, model.Paging, error)
+	Get(id string) (entity.Registration, error)
+	GetUnverified(email string) (entity.Registration, error)
+	RefreshToken(id, tokenHash string, expiresAt time.Time) error
+	Verify(tokenHash string) (string, error)
+	Reject(id, reason, reviewedBy string, reviewedAt time.Time) error
+	Approve(id, track, reason, reviewedBy string, reviewedAt time.Time) error
+}
+
+type registrationRepository struct {
+	db *sql.DB
+}
+
+func scanRegistration(row rowScanner) (entity.Registration, error) {
+	var registration entity.Registration
+	var verifiedAt, reviewedAt sql.NullTime
+	err := row.Scan(&registration.ID, &registration.Name, &registration.Email, &registration.Username, &registration.Address, &registration.DateOfBirth,
+		&registration.PlaceOfBirth, &registration.LastEducation, &registration.Status, &verifiedAt, &registration.Track,
+		&registration.Reason, &registration.UserID, &registration.ReviewedBy, &reviewedAt, &registration.CreatedAt)
+	if verifiedAt.Valid {
+		registration.VerifiedAt = &verifiedAt.Time
+	}
+	if reviewedAt.Valid {
+		registration.ReviewedAt = &reviewedAt.Time
+	}
+	return registration, err
+}
+
+// IdentityTaken implements RegistrationRepository. Expired unverified registrations are cleared first.
+func (r *registrationRepository) IdentityTaken(email, username string) (bool, error) {
+	if _, err := r.db.Exec(config.DeleteExpiredRegistrations, email, username); err != nil {
+		log.Println("registrationRepository.IdentityTaken:", err.Error())
+		return false, err
+	}
+
+	var taken bool
+	if err := r.db.QueryRow(config.RegistrationIdentityTaken, email, username).Scan(&taken); err != nil {
+		log.Println("registrationRepository.IdentityTaken:", err.Error())
+		return false, err
+	}
+	return taken, nil
+}
+
+// Create implements RegistrationRepository.
+func (r *registrationRepository) Create(payload entity.Registration, password, tokenHash string, expiresAt time.Time) (entity.Registration, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		log.Println("registrationRepository.GenerateFromPassword:", err.Error())
+		return entity.Registration{}, err
+	}
+
+	err = r.db.QueryRow(config.InsertRegistration, payload.Name, payload.Email, payload.Username, payload.Address, string(hashedPassword),
+		payload.DateOfBirth, payload.PlaceOfBirth, payload.LastEducation, tokenHash, expiresAt).Scan(&payload.ID, &payload.Status, &payload.CreatedAt)
+	if err != nil {
+		log.Println("registrationRepository.Create:", err.Error())
+		return entity.Registration{}, err
+	}
+	return payload, nil
+}
+
+// registrationList is what the registration list can be filtered and sorted on, oldest first so
+// the admins work through the queue in order.
+var registrationList = query.Table{
+	Fields: map[string]query.Field{
+		"status":    {Column: "status::text", Type: query.Text},
+		"track":     {Column: "track::text", Type: query.Text},
+		"name":      {Column: "name", Type: query.Text, Sortable: true},
+		"email":     {Column: "email", Type: query.Text},
+		"createdAt": {Column: "created_at", Type: query.Timestamp, Sortable: true},
+	},
+	Key:         "id",
+	DefaultSort: []query.Sort{{Field: "createdAt"}},
+}
+
+// List implements RegistrationRepository.
+func (r *registrationRepository) List(spec query.Spec) ([]entity.Registration, model.Paging, error) {
+	stmt, err := registrationList.Select(config.ListRegistrations, spec)
+	if err != nil {
+		return nil, model.Paging{}, err
+	}
+	rows, err := r.db.Query(stmt.Query, stmt.Args...)
+	if err != nil {
+		log.Println("registrationRepository.List:", err.Error())
+		return nil, model.Paging{}, err
+	}
+	defer rows.Close()
+
+	var registrations []entity.Registration
+	for stmt.Next(rows) {
+		registration, err := scanRegistration(stmt.Row(rows))
+		if err != nil {
+			return nil, model.Paging{}, err
+		}
+		registrations = append(registrations, registration)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, model.Paging{}, err
+	}
+
+	paging, err := stmt.Paging(r.db)
+	if err != nil {
+		return nil, model.Paging{}, err
+	}
+	return registrations, paging, nil
+}
+
+// Get implements RegistrationRepository.
+func (r *registrationRepository) Get(id string) (entity.Registration, error) {
+	registration, err := scanRegistration(r.db.QueryRow(config.GetRegistrationByID, id))
+	if err != nil {
+		log.Println("registrationRepository.Get:", err.Error())
+		return entity.Registration{}, err
+	}
+	return registration, nil
+}
+
+// GetUnverified implements RegistrationRepository.
+func (r *registrationRepository) GetUnverified(email string) (entity.Registration, error) {
+	return scanRegistration(r.db.QueryRow(config.GetOpenRegistration, email))
+}
+
+// RefreshToken implements RegistrationRepository.
+func (r *registrationRepository) RefreshToken(id, tokenHash string, expiresAt time.Time) error {
+	_, err := r.db.Exec(config.RefreshRegistrationToken, id, tokenHash, expiresAt)
+	if err != nil {
+		log.Println("registrationRepository.RefreshToken:", err.Error())
+	}
+	return err
+}
+
+// Verify implements RegistrationRepository. It returns sql.ErrNoRows for unknown or expired tokens.
+func (r *registrationRepository) Verify(tokenHash string) (string, error) {
+	var id string
+	if err := r.db.QueryRow(config.VerifyRegistration, tokenHash).Scan(&id); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// Reject implements RegistrationRepository.
+func (r *registrationRepository) Reject(id, reason, reviewedBy string, reviewedAt time.Time) error {
+	result, err := r.db.Exec(config.DecideRegistration, id, "Rejected", "", reason, reviewedBy, reviewedAt)
+	if err != nil {
+		log.Println("registrationRepository.Reject:", err.Error())
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// Approve implements RegistrationRepository. The user, its participant row and the first track
+// history entry are created together with the decision.
+func (r *registrationRepository) Approve(id, track, reason, reviewedBy string, reviewedAt time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(config.DecideRegistration, id, "Approved", track, reason, reviewedBy, reviewedAt)
+	if err != nil {
+		tx.Rollback()
+		log.Println("registrationRepository.Approve:", err.Error())
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		tx.Rollback()
+		return sql.ErrNoRows
+	}
+
+	var userId, participantId string
+	if err := tx.QueryRow(config.InsertRegisteredUser, id).Scan(&userId); err != nil {
+		tx.Rollback()
+		log.Println("registrationRepository.Approve:", err.Error())
+		return err
+	}
+	if err := tx.QueryRow(config.InsertRegisteredParticipant, id, userId).Scan(&participantId); err != nil {
+		tx.Rollback()
+		log.Println("registrationRepository.Approve:", err.Error())
+		return err
+	}
+
+	statements := [][]interface{}{
+		{config.InsertTrackHistory, participantId, nil, track, "registration", nil},
+		{config.LinkRegistrationUser, id, userId},
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(statement[0].(string), statement[1:]...); err != nil {
+			tx.Rollback()
+			log.Println("registrationRepository.Approve:", err.Error())
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func NewRegistrationRepository(db *sql.DB) RegistrationRepository {
+	return &registrationRepository{db: db}
+}
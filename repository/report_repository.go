@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"database/sql"
+	"instructor-led-app/config"
+	"instructor-led-app/entity/dto"
+	"log"
+	"time"
+)
+
+type ReportRepository interface {
+	TrainerWorkload(startDate, endDate time.Time, trainerId string) ([]dto.TrainerWorkloadDTO, error)
+	ListTrainerRates() ([]dto.TrainerRateDTO, error)
+	SaveTrainerRate(trainerId string, rate float64, updatedAt time.Time) error
+	DeleteTrainerRate(trainerId string) error
+}
+
+type reportRepository struct {
+	db *sql.DB
+}
+
+// TrainerWorkload implements ReportRepository.
+// CustomRate tells whether the trainer has a rate of their own; without one RatePerSession is 0.
+func (r *reportRepository) TrainerWorkload(startDate, endDate time.Time, trainerId string) ([]dto.TrainerWorkloadDTO, error) {
+	rows, err := r.db.Query(config.TrainerWorkloadReport, startDate, endDate, trainerId)
+	if err != nil {
+		log.Println("reportRepository.TrainerWorkload:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var workloads []dto.TrainerWorkloadDTO
+	for rows.Next() {
+		var workload dto.TrainerWorkloadDTO
+		var rate sql.NullFloat64
+		if err := rows.Scan(
+			&workload.TrainerID,
+			&workload.TrainerName,
+			&rate,
+			&workload.SessionsScheduled,
+			&workload.SessionsDelivered,
+			&workload.AttendanceSessions,
+			&workload.AttendanceRecords,
+			&workload.QuestionsAnswered); err != nil {
+			log.Println("reportRepository.TrainerWorkload.Scan:", err.Error())
+			return nil, err
+		}
+		workload.RatePerSession, workload.CustomRate = rate.Float64, rate.Valid
+		workloads = append(workloads, workload)
+	}
+	return workloads, nil
+}
+
+// ListTrainerRates implements ReportRepository.
+func (r *reportRepository) ListTrainerRates() ([]dto.TrainerRateDTO, error) {
+	rows, err := r.db.Query(config.ListTrainerRates)
+	if err != nil {
+		log.Println("reportRepository.ListTrainerRates:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rates []dto.TrainerRateDTO
+	for rows.Next() {
+		var rate dto.TrainerRateDTO
+		if err := rows.Scan(&rate.TrainerID, &rate.TrainerName, &rate.RatePerSession, &rate.UpdatedAt); err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
+
+// SaveTrainerRate implements ReportRepository.
+func (r *reportRepository) SaveTrainerRate(trainerId string, rate float64, updatedAt time.Time) error {
+	if _, err := r.db.Exec(config.UpsertTrainerRate, trainerId, rate, updatedAt); err != nil {
+		log.Println("reportRepository.SaveTrainerRate:", err.Error())
+		return err
+	}
+	return nil
+}
+
+// DeleteTrainerRate implements ReportRepository.
+func (r *reportRepository) DeleteTrainerRate(trainerId string) error {
+	if _, err := r.db.Exec(config.DeleteTrainerRate, trainerId); err != nil {
+		log.Println("reportRepository.DeleteTrainerRate:", err.Error())
+		return err
+	}
+	return nil
+}
+
+func NewReportRepository(db *sql.DB) ReportRepository {
+	return &reportRepository{db: db}
+}
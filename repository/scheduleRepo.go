@@ -7,18 +7,20 @@ import (
 	"instructor-led-app/entity"
 	"instructor-led-app/entity/dto"
 	"instructor-led-app/shared/model"
+	"instructor-led-app/shared/query"
 	"log"
 	"math"
 	"time"
 )
 
 type ScheduleRepository interface {
-	List(page, size int) ([]entity.Schedule, model.Paging, error)
+	List(spec query.Spec) ([]entity.Schedule, model.Paging, error)
+	Get(id string) (entity.Schedule, error)
+	IsParticipantInSession(scheduleId, participantId string) (bool, error)
 	GetScheduleByTrainerId(id string, page, size int) ([]entity.Schedule, model.Paging, error)
 	GetScheduleByTrainerIdWithoutPagination(id string) ([]string, error)
 	GetScheduleByParticipantID(id string, page, size int) ([]entity.Schedule, model.Paging, error)
 	Create(payload dto.ScheduleDto) (dto.ScheduleDto, error)
-	ListByDate(startDate, endDate time.Time, page, size int) ([]entity.Schedule, model.Paging, error)
 	GetScheduleIdByDay(code int) ([]string, error)
 	GetDateByDay(code int) ([]time.Time, error)
 	GetDateByScheduleId(id string) (time.Time, error)
@@ -26,12 +28,73 @@ type ScheduleRepository interface {
 	GetScheduleWithParticipantId(id string) ([]dto.ScheduleDto, error)
 	UpdateScheduleByAdmin(trainerId string, dates []time.Time) ([]entity.Schedule, error)
 	DeleteByDate(date string) error
+	ListSessions(startDate, endDate time.Time) ([]dto.SessionDTO, error)
+	ReassignSessions(assignments []dto.AutoAssignmentDTO, updatedAt time.Time) error
 }
 
 type scheduleRepository struct {
 	db *sql.DB
 }
 
+// Get implements ScheduleRepository.
+func (s *scheduleRepository) Get(id string) (entity.Schedule, error) {
+	var schedule entity.Schedule
+	err := s.db.QueryRow(config.GetScheduleByID, id).Scan(&schedule.ID, &schedule.Activity, &schedule.Date, &schedule.TrainerID, &schedule.ParticipantID, &schedule.CohortID, &schedule.LessonID, &schedule.CreatedAt, &schedule.UpdatedAt)
+	if err != nil {
+		log.Println("scheduleRepository.Get:", err.Error())
+		return entity.Schedule{}, err
+	}
+	return schedule, nil
+}
+
+// IsParticipantInSession implements ScheduleRepository.
+// A session is every schedule row sharing the date and trainer of scheduleId.
+func (s *scheduleRepository) IsParticipantInSession(scheduleId, participantId string) (bool, error) {
+	total := 0
+	if err := s.db.QueryRow(config.CountParticipantInSession, scheduleId, participantId).Scan(&total); err != nil {
+		log.Println("scheduleRepository.IsParticipantInSession:", err.Error())
+		return false, err
+	}
+	return total > 0, nil
+}
+
+// ListSessions implements ScheduleRepository.
+func (s *scheduleRepository) ListSessions(startDate, endDate time.Time) ([]dto.SessionDTO, error) {
+	rows, err := s.db.Query(config.ListSessionsByDateRange, startDate, endDate)
+	if err != nil {
+		log.Println("scheduleRepository.ListSessions:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []dto.SessionDTO
+	for rows.Next() {
+		var session dto.SessionDTO
+		if err := rows.Scan(&session.ScheduleID, &session.Date, &session.TrainerID, &session.Activity, &session.CohortID); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+// ReassignSessions implements ScheduleRepository.
+// Every session moves to its new trainer or none of them do.
+func (s *scheduleRepository) ReassignSessions(assignments []dto.AutoAssignmentDTO, updatedAt time.Time) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, assignment := range assignments {
+		if _, err := tx.Exec(config.ReassignSession, assignment.ScheduleID, assignment.CurrentTrainerID, assignment.TrainerID, updatedAt); err != nil {
+			log.Println("scheduleRepository.ReassignSessions:", err.Error())
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
 // DeleteByDate implements ScheduleRepository.
 func (s *scheduleRepository) DeleteByDate(date string) error {
 	// var question entity.Question
@@ -57,7 +120,7 @@ func (s *scheduleRepository) UpdateScheduleByAdmin(trainerId string, dates []tim
 
 	for _, date := range dates {
 		var updatedSchedule entity.Schedule
-		err := s.db.QueryRow(config.UpdateScheduleByAdmin, date, trainerId).Scan(&updatedSchedule.ID, &updatedSchedule.Activity, &updatedSchedule.Date, &updatedSchedule.TrainerID, &updatedSchedule.ParticipantID, &updatedSchedule.UpdatedAt)
+		err := s.db.QueryRow(config.UpdateScheduleByAdmin, date, trainerId).Scan(&updatedSchedule.ID, &updatedSchedule.Activity, &updatedSchedule.Date, &updatedSchedule.TrainerID, &updatedSchedule.ParticipantID, &updatedSchedule.CohortID, &updatedSchedule.LessonID, &updatedSchedule.UpdatedAt)
 		if err != nil {
 			log.Println("QueryRow.err UpdatedByID :", err)
 			return nil, err
@@ -172,37 +235,6 @@ func (s *scheduleRepository) GetScheduleIdByDay(code int) ([]string, error) {
 	return schedules, nil
 }
 
-// ListByDate implements ScheduleRepository.
-func (s *scheduleRepository) ListByDate(startDate time.Time, endDate time.Time, page int, size int) ([]entity.Schedule, model.Paging, error) {
-	var schedules []entity.Schedule
-	offset := (page - 1) * size
-	rows, err := s.db.Query(config.ListScheduleByDate, startDate, endDate, size, offset)
-	if err != nil {
-		return nil, model.Paging{}, err
-	}
-	for rows.Next() {
-		var schedule entity.Schedule
-		err := rows.Scan(&schedule.ID, &schedule.Activity, &schedule.Date, &schedule.TrainerID, &schedule.ParticipantID, &schedule.CreatedAt, &schedule.UpdatedAt)
-		if err != nil {
-			return nil, model.Paging{}, err
-		}
-		schedules = append(schedules, schedule)
-	}
-	totalRows := 0
-	if err := s.db.QueryRow("SELECT COUNT(*) FROM schedules").Scan(&totalRows); err != nil {
-		return nil, model.Paging{}, err
-	}
-
-	paging := model.Paging{
-		Page:        page,
-		RowsPerPage: size,
-		TotalRows:   totalRows,
-		TotalPages:  int(math.Ceil(float64(totalRows) / float64(size))),
-	}
-
-	return schedules, paging, nil
-}
-
 // Create implements ScheduleRepository.
 func (s *scheduleRepository) Create(payload dto.ScheduleDto) (dto.ScheduleDto, error) {
 	var schedule dto.ScheduleDto
@@ -210,7 +242,9 @@ func (s *scheduleRepository) Create(payload dto.ScheduleDto) (dto.ScheduleDto, e
 		payload.Activity,
 		payload.Date,
 		payload.TrainerID,
-		payload.ParticipantID).Scan(
+		payload.ParticipantID,
+		payload.CohortID,
+		payload.LessonID).Scan(
 		&schedule.ID,
 		&schedule.CreatedAt,
 		&schedule.UpdatedAt,
@@ -225,6 +259,8 @@ func (s *scheduleRepository) Create(payload dto.ScheduleDto) (dto.ScheduleDto, e
 	schedule.Activity = payload.Activity
 	schedule.TrainerID = payload.TrainerID
 	schedule.ParticipantID = payload.ParticipantID
+	schedule.CohortID = payload.CohortID
+	schedule.LessonID = payload.LessonID
 	return schedule, nil
 }
 
@@ -238,7 +274,7 @@ func (s *scheduleRepository) GetScheduleByParticipantID(id string, page, size in
 
 	for rows.Next() {
 		var schedule entity.Schedule
-		err := rows.Scan(&schedule.ID, &schedule.Activity, &schedule.Date, &schedule.TrainerID, &schedule.ParticipantID, &schedule.CreatedAt, &schedule.UpdatedAt)
+		err := rows.Scan(&schedule.ID, &schedule.Activity, &schedule.Date, &schedule.TrainerID, &schedule.ParticipantID, &schedule.CohortID, &schedule.LessonID, &schedule.CreatedAt, &schedule.UpdatedAt)
 		if err != nil {
 			return nil, model.Paging{}, err
 		}
@@ -271,7 +307,7 @@ func (s *scheduleRepository) GetScheduleByTrainerId(id string, page, size int) (
 
 	for rows.Next() {
 		var schedule entity.Schedule
-		err := rows.Scan(&schedule.ID, &schedule.Activity, &schedule.Date, &schedule.TrainerID, &schedule.ParticipantID, &schedule.CreatedAt, &schedule.UpdatedAt)
+		err := rows.Scan(&schedule.ID, &schedule.Activity, &schedule.Date, &schedule.TrainerID, &schedule.ParticipantID, &schedule.CohortID, &schedule.LessonID, &schedule.CreatedAt, &schedule.UpdatedAt)
 		if err != nil {
 			return nil, model.Paging{}, err
 		}
@@ -292,35 +328,51 @@ func (s *scheduleRepository) GetScheduleByTrainerId(id string, page, size int) (
 	return schedules, paging, nil
 }
 
+// scheduleList is what the schedule list can be filtered and sorted on.
+var scheduleList = query.Table{
+	Fields: map[string]query.Field{
+		"activity":      {Column: "activity", Type: query.Text},
+		"date":          {Column: "date", Type: query.Date, Sortable: true},
+		"trainerId":     {Column: "trainer_id", Type: query.UUID},
+		"participantId": {Column: "participant_id", Type: query.UUID},
+		"cohortId":      {Column: "cohort_id", Type: query.UUID},
+		"lessonId":      {Column: "lesson_id", Type: query.UUID},
+		"createdAt":     {Column: "created_at", Type: query.Timestamp, Sortable: true},
+		"updatedAt":     {Column: "updated_at", Type: query.Timestamp, Sortable: true},
+	},
+	Key:         "id",
+	DefaultSort: []query.Sort{{Field: "createdAt", Desc: true}},
+}
+
 // List implements ScheduleRepository.
-func (s *scheduleRepository) List(page int, size int) ([]entity.Schedule, model.Paging, error) {
-	var schedules []entity.Schedule
-	offset := (page - 1) * size
-	rows, err := s.db.Query(config.ListSchedule, size, offset)
+func (s *scheduleRepository) List(spec query.Spec) ([]entity.Schedule, model.Paging, error) {
+	stmt, err := scheduleList.Select(config.ListSchedule, spec)
 	if err != nil {
 		return nil, model.Paging{}, err
 	}
-	for rows.Next() {
+	rows, err := s.db.Query(stmt.Query, stmt.Args...)
+	if err != nil {
+		return nil, model.Paging{}, err
+	}
+	defer rows.Close()
+
+	var schedules []entity.Schedule
+	for stmt.Next(rows) {
 		var schedule entity.Schedule
-		err := rows.Scan(&schedule.ID, &schedule.Activity, &schedule.Date, &schedule.TrainerID, &schedule.ParticipantID, &schedule.CreatedAt, &schedule.UpdatedAt)
+		err := stmt.Scan(rows, &schedule.ID, &schedule.Activity, &schedule.Date, &schedule.TrainerID, &schedule.ParticipantID, &schedule.CohortID, &schedule.LessonID, &schedule.CreatedAt, &schedule.UpdatedAt)
 		if err != nil {
 			return nil, model.Paging{}, err
 		}
 		schedules = append(schedules, schedule)
 	}
-
-	totalRows := 0
-	if err := s.db.QueryRow("SELECT COUNT(*) FROM schedules").Scan(&totalRows); err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, model.Paging{}, err
 	}
 
-	paging := model.Paging{
-		Page:        page,
-		RowsPerPage: size,
-		TotalRows:   totalRows,
-		TotalPages:  int(math.Ceil(float64(totalRows) / float64(size))),
+	paging, err := stmt.Paging(s.db)
+	if err != nil {
+		return nil, model.Paging{}, err
 	}
-
 	return schedules, paging, nil
 }
 
@@ -3,11 +3,22 @@ package repository
 import (
 	"database/sql"
 	"instructor-led-app/config"
+	"instructor-led-app/entity"
 	"instructor-led-app/entity/dto"
+	"instructor-led-app/shared/model"
+	"instructor-led-app/shared/query"
+	"log"
+	"time"
 )
 
 type ScheduleImageRepository interface {
 	Insert(scheduleImage dto.ScheduleImagesDTO) (dto.ScheduleImagesDTO, error)
+	ExistsBySourceChecksum(checksum string) (bool, error)
+	List(spec query.Spec) ([]dto.ScheduleImagesDTO, model.Paging, error)
+	Get(id string) (dto.ScheduleImagesDTO, error)
+	Review(id, reviewerId string, payload dto.ActivityProofReviewDTO, reviewedAt time.Time) error
+	Delete(id string) error
+	ListSessionsWithoutProof(spec query.Spec) ([]entity.Schedule, model.Paging, error)
 }
 
 type scheduleImageRepository struct {
@@ -17,13 +28,209 @@ type scheduleImageRepository struct {
 func (r *scheduleImageRepository) Insert(scheduleImage dto.ScheduleImagesDTO) (dto.ScheduleImagesDTO, error) {
 	var scheduleImageDTO dto.ScheduleImagesDTO
 
-	if err := r.db.QueryRow(config.InsertScheduleImage, scheduleImage.ScheduleID, scheduleImage.FileName).Scan(&scheduleImageDTO.ID, &scheduleImageDTO.ScheduleID, &scheduleImageDTO.FileName); err != nil {
+	if err := r.db.QueryRow(config.InsertScheduleImage,
+		scheduleImage.ScheduleID,
+		scheduleImage.FileName,
+		scheduleImage.StorageKey,
+		scheduleImage.Size,
+		scheduleImage.MimeType,
+		scheduleImage.Checksum,
+		scheduleImage.SourceChecksum,
+		scheduleImage.ThumbnailKey,
+		scheduleImage.Caption,
+		scheduleImage.CapturedAt,
+		scheduleImage.Latitude,
+		scheduleImage.Longitude,
+		scheduleImage.Verification,
+		scheduleImage.VerificationNote).Scan(
+		&scheduleImageDTO.ID,
+		&scheduleImageDTO.ScheduleID,
+		&scheduleImageDTO.FileName,
+		&scheduleImageDTO.StorageKey,
+		&scheduleImageDTO.Size,
+		&scheduleImageDTO.MimeType,
+		&scheduleImageDTO.Checksum,
+		&scheduleImageDTO.ThumbnailKey,
+		&scheduleImageDTO.Caption,
+		&scheduleImageDTO.Verification,
+		&scheduleImageDTO.VerificationNote,
+		&scheduleImageDTO.CreatedAt); err != nil {
 		return dto.ScheduleImagesDTO{}, err
 	}
+	scheduleImageDTO.CapturedAt = scheduleImage.CapturedAt
+	scheduleImageDTO.Latitude, scheduleImageDTO.Longitude = scheduleImage.Latitude, scheduleImage.Longitude
+	scheduleImageDTO.ReviewStatus = "Pending"
 
 	return scheduleImageDTO, nil
 }
 
+func (r *scheduleImageRepository) ExistsBySourceChecksum(checksum string) (bool, error) {
+	total := 0
+	if err := r.db.QueryRow(config.CountScheduleImageBySourceChecksum, checksum).Scan(&total); err != nil {
+		return false, err
+	}
+	return total > 0, nil
+}
+
+// activityProofList is what the activity proofs can be filtered and sorted on.
+var activityProofList = query.Table{
+	Fields: map[string]query.Field{
+		"reviewStatus": {Column: "i.review_status::text", Type: query.Text},
+		"verification": {Column: "i.verification::text", Type: query.Text},
+		"scheduleId":   {Column: "i.schedule_id", Type: query.UUID},
+		"trainerId":    {Column: "s.trainer_id", Type: query.UUID},
+		"scheduleDate": {Column: "s.date", Type: query.Date, Sortable: true},
+		"createdAt":    {Column: "i.created_at", Type: query.Timestamp, Sortable: true},
+	},
+	Key:         "i.id",
+	DefaultSort: []query.Sort{{Field: "createdAt", Desc: true}},
+}
+
+func (r *scheduleImageRepository) List(spec query.Spec) ([]dto.ScheduleImagesDTO, model.Paging, error) {
+	stmt, err := activityProofList.Select(config.ListActivityProofs, spec)
+	if err != nil {
+		return nil, model.Paging{}, err
+	}
+	rows, err := r.db.Query(stmt.Query, stmt.Args...)
+	if err != nil {
+		log.Println("scheduleImageRepository.List:", err.Error())
+		return nil, model.Paging{}, err
+	}
+	defer rows.Close()
+
+	var images []dto.ScheduleImagesDTO
+	for stmt.Next(rows) {
+		image, err := scanActivityProof(stmt.Row(rows))
+		if err != nil {
+			log.Println("scheduleImageRepository.List.Scan:", err.Error())
+			return nil, model.Paging{}, err
+		}
+		images = append(images, image)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, model.Paging{}, err
+	}
+
+	paging, err := stmt.Paging(r.db)
+	if err != nil {
+		return nil, model.Paging{}, err
+	}
+	return images, paging, nil
+}
+
+func (r *scheduleImageRepository) Get(id string) (dto.ScheduleImagesDTO, error) {
+	image, err := scanActivityProof(r.db.QueryRow(config.GetActivityProofByID, id))
+	if err != nil {
+		log.Println("scheduleImageRepository.Get:", err.Error())
+		return dto.ScheduleImagesDTO{}, err
+	}
+	return image, nil
+}
+
+func (r *scheduleImageRepository) Review(id, reviewerId string, payload dto.ActivityProofReviewDTO, reviewedAt time.Time) error {
+	if _, err := r.db.Exec(config.ReviewActivityProof, id, payload.Status, payload.Reason, reviewerId, reviewedAt); err != nil {
+		log.Println("scheduleImageRepository.Review:", err.Error())
+		return err
+	}
+	return nil
+}
+
+func (r *scheduleImageRepository) Delete(id string) error {
+	if _, err := r.db.Exec(config.DeleteActivityProof, id); err != nil {
+		log.Println("scheduleImageRepository.Delete:", err.Error())
+		return err
+	}
+	return nil
+}
+
+// missingProofList is what the sessions without an activity proof can be filtered and sorted on.
+var missingProofList = query.Table{
+	Fields: map[string]query.Field{
+		"date":      {Column: "date", Type: query.Date, Sortable: true},
+		"trainerId": {Column: "trainer_id", Type: query.UUID},
+		"activity":  {Column: "activity", Type: query.Text},
+	},
+	Key:         "id",
+	DefaultSort: []query.Sort{{Field: "date", Desc: true}},
+}
+
+func (r *scheduleImageRepository) ListSessionsWithoutProof(spec query.Spec) ([]entity.Schedule, model.Paging, error) {
+	stmt, err := missingProofList.Select(config.ListSessionsWithoutProof, spec)
+	if err != nil {
+		return nil, model.Paging{}, err
+	}
+	rows, err := r.db.Query(stmt.Query, stmt.Args...)
+	if err != nil {
+		log.Println("scheduleImageRepository.ListSessionsWithoutProof:", err.Error())
+		return nil, model.Paging{}, err
+	}
+	defer rows.Close()
+
+	var schedules []entity.Schedule
+	for stmt.Next(rows) {
+		var schedule entity.Schedule
+		if err := stmt.Scan(rows, &schedule.ID, &schedule.Activity, &schedule.Date, &schedule.TrainerID, &schedule.ParticipantID, &schedule.CohortID, &schedule.CreatedAt, &schedule.UpdatedAt); err != nil {
+			return nil, model.Paging{}, err
+		}
+		schedules = append(schedules, schedule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, model.Paging{}, err
+	}
+
+	paging, err := stmt.Paging(r.db)
+	if err != nil {
+		return nil, model.Paging{}, err
+	}
+	return schedules, paging, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanActivityProof(row rowScanner) (dto.ScheduleImagesDTO, error) {
+	var image dto.ScheduleImagesDTO
+	var scheduleDate time.Time
+	var capturedAt, reviewedAt sql.NullTime
+	var latitude, longitude sql.NullFloat64
+	if err := row.Scan(
+		&image.ID,
+		&image.ScheduleID,
+		&image.TrainerID,
+		&scheduleDate,
+		&image.FileName,
+		&image.StorageKey,
+		&image.Size,
+		&image.MimeType,
+		&image.Checksum,
+		&image.ThumbnailKey,
+		&image.Caption,
+		&capturedAt,
+		&latitude,
+		&longitude,
+		&image.Verification,
+		&image.VerificationNote,
+		&image.ReviewStatus,
+		&image.ReviewReason,
+		&image.ReviewedBy,
+		&reviewedAt,
+		&image.CreatedAt); err != nil {
+		return dto.ScheduleImagesDTO{}, err
+	}
+	image.ScheduleDate = &scheduleDate
+	if capturedAt.Valid {
+		image.CapturedAt = &capturedAt.Time
+	}
+	if latitude.Valid && longitude.Valid {
+		image.Latitude, image.Longitude = &latitude.Float64, &longitude.Float64
+	}
+	if reviewedAt.Valid {
+		image.ReviewedAt = &reviewedAt.Time
+	}
+	return image, nil
+}
+
 func NewScheduleImagesRepository(db *sql.DB) ScheduleImageRepository {
 	return &scheduleImageRepository{db}
 }
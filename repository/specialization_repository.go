@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"database/sql"
+	"instructor-led-app/config"
+	"instructor-led-app/entity"
+	"instructor-led-app/entity/dto"
+	"log"
+
+	"github.com/lib/pq"
+)
+
+type SpecializationRepository interface {
+	Create(payload dto.SpecializationDTO) (entity.Specialization, error)
+	List(trainerId string) ([]entity.Specialization, error)
+	Get(id string) (entity.Specialization, error)
+	Update(id, name string) (entity.Specialization, error)
+	Delete(id string) error
+	ExistsForTrainer(trainerId, name, exceptId string) (bool, error)
+	SuggestTrainers(activity string) ([]dto.TrainerSuggestionDTO, error)
+}
+
+type specializationRepository struct {
+	db *sql.DB
+}
+
+// Create implements SpecializationRepository.
+func (s *specializationRepository) Create(payload dto.SpecializationDTO) (entity.Specialization, error) {
+	var specialization entity.Specialization
+	if err := s.db.QueryRow(config.InsertSpecialization, payload.TrainerID, payload.Name).Scan(&specialization.ID, &specialization.TrainerID, &specialization.Name); err != nil {
+		log.Println("specializationRepository.Create:", err.Error())
+		return entity.Specialization{}, err
+	}
+	return specialization, nil
+}
+
+// List implements SpecializationRepository. An empty trainerId lists every specialization.
+func (s *specializationRepository) List(trainerId string) ([]entity.Specialization, error) {
+	rows, err := s.db.Query(config.ListSpecializationsByTrainer, trainerId)
+	if err != nil {
+		log.Println("specializationRepository.List:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var specializations []entity.Specialization
+	for rows.Next() {
+		var specialization entity.Specialization
+		if err := rows.Scan(&specialization.ID, &specialization.TrainerID, &specialization.Name); err != nil {
+			return nil, err
+		}
+		specializations = append(specializations, specialization)
+	}
+	return specializations, nil
+}
+
+// Get implements SpecializationRepository.
+func (s *specializationRepository) Get(id string) (entity.Specialization, error) {
+	var specialization entity.Specialization
+	if err := s.db.QueryRow(config.GetSpecializationByID, id).Scan(&specialization.ID, &specialization.TrainerID, &specialization.Name); err != nil {
+		log.Println("specializationRepository.Get:", err.Error())
+		return entity.Specialization{}, err
+	}
+	return specialization, nil
+}
+
+// Update implements SpecializationRepository.
+func (s *specializationRepository) Update(id, name string) (entity.Specialization, error) {
+	var specialization entity.Specialization
+	if err := s.db.QueryRow(config.UpdateSpecializationByID, id, name).Scan(&specialization.ID, &specialization.TrainerID, &specialization.Name); err != nil {
+		log.Println("specializationRepository.Update:", err.Error())
+		return entity.Specialization{}, err
+	}
+	return specialization, nil
+}
+
+// Delete implements SpecializationRepository.
+func (s *specializationRepository) Delete(id string) error {
+	if _, err := s.db.Exec(config.DeleteSpecializationByID, id); err != nil {
+		log.Println("specializationRepository.Delete:", err.Error())
+		return err
+	}
+	return nil
+}
+
+// ExistsForTrainer implements SpecializationRepository.
+func (s *specializationRepository) ExistsForTrainer(trainerId, name, exceptId string) (bool, error) {
+	total := 0
+	if err := s.db.QueryRow(config.CountSpecializationByName, trainerId, name, exceptId).Scan(&total); err != nil {
+		return false, err
+	}
+	return total > 0, nil
+}
+
+// SuggestTrainers implements SpecializationRepository.
+// Trainers are ranked by how many of their specializations match the activity.
+func (s *specializationRepository) SuggestTrainers(activity string) ([]dto.TrainerSuggestionDTO, error) {
+	rows, err := s.db.Query(config.SuggestTrainersByActivity, activity)
+	if err != nil {
+		log.Println("specializationRepository.SuggestTrainers:", err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var suggestions []dto.TrainerSuggestionDTO
+	for rows.Next() {
+		var suggestion dto.TrainerSuggestionDTO
+		if err := rows.Scan(&suggestion.TrainerID, &suggestion.Name, &suggestion.PhoneNumber, pq.Array(&suggestion.Specializations), &suggestion.MatchCount); err != nil {
+			return nil, err
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	return suggestions, nil
+}
+
+func NewSpecializationRepository(db *sql.DB) SpecializationRepository {
+	return &specializationRepository{db: db}
+}
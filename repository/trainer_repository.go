@@ -7,13 +7,13 @@ import (
 	"instructor-led-app/entity"
 	"instructor-led-app/entity/dto"
 	"instructor-led-app/shared/model"
+	"instructor-led-app/shared/query"
 	"log"
-	"math"
 	"time"
 )
 
 type TrainerRepository interface {
-	List(page, size int) ([]entity.Trainer, model.Paging, error)
+	List(spec query.Spec) ([]entity.Trainer, model.Paging, error)
 	TrainerById(trainerId string) ([]entity.Trainer, error)
 	FindByUserID(userID string) (dto.TrainerDTO, error)
 	UpdateTrainer(trainerDTO dto.TrainerDTO, updateAt time.Time) (dto.TrainerDTO, error)
@@ -98,20 +98,40 @@ func (t *trainerRepository) FindByUserID(userID string) (dto.TrainerDTO, error)
 	return trainer, nil
 }
 
-// List implements TrainerRepository.
-func (t *trainerRepository) List(page, size int) ([]entity.Trainer, model.Paging, error) {
-	var trainers []entity.Trainer
-	offset := (page - 1) * size
-	rows, err := t.db.Query(config.ListTrainers, size, offset)
+// trainerList is what the trainer list can be filtered and sorted on.
+var trainerList = query.Table{
+	Fields: map[string]query.Field{
+		"phoneNumber": {Column: "phone_number", Type: query.Text},
+		"userId":      {Column: "user_id", Type: query.UUID},
+		"specialization": {
+			Column: "sp.name",
+			Type:   query.Text,
+			Exists: "SELECT 1 FROM specializations sp WHERE sp.trainer_id = trainers.id",
+		},
+		"createdAt": {Column: "created_at", Type: query.Timestamp, Sortable: true},
+		"updatedAt": {Column: "updated_at", Type: query.Timestamp, Sortable: true},
+	},
+	Key:         "id",
+	DefaultSort: []query.Sort{{Field: "createdAt", Desc: true}},
+}
 
+// List implements TrainerRepository.
+func (t *trainerRepository) List(spec query.Spec) ([]entity.Trainer, model.Paging, error) {
+	stmt, err := trainerList.Select(config.ListTrainers, spec)
+	if err != nil {
+		return nil, model.Paging{}, err
+	}
+	rows, err := t.db.Query(stmt.Query, stmt.Args...)
 	if err != nil {
 		log.Println("TrainerRepository.Query:", err.Error())
 		return nil, model.Paging{}, err
 	}
+	defer rows.Close()
 
-	for rows.Next() {
+	var trainers []entity.Trainer
+	for stmt.Next(rows) {
 		var trainer entity.Trainer
-		err := rows.Scan(
+		err := stmt.Scan(rows,
 			&trainer.ID,
 			&trainer.PhoneNumber,
 			&trainer.UserID,
@@ -119,25 +139,21 @@ func (t *trainerRepository) List(page, size int) ([]entity.Trainer, model.Paging
 			&trainer.UpdatedAt,
 		)
 		if err != nil {
-			log.Println("UserRepository.Rows.Next():", err.Error())
+			log.Println("TrainerRepository.Rows.Next():", err.Error())
 			return nil, model.Paging{}, err
 		}
 
 		trainers = append(trainers, trainer)
 	}
-	totalRows := 0
-	if err := t.db.QueryRow("SELECT COUNT(*) FROM users").Scan(&totalRows); err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, model.Paging{}, err
 	}
 
-	paging := model.Paging{
-		Page:        page,
-		RowsPerPage: size,
-		TotalRows:   totalRows,
-		TotalPages:  int(math.Ceil(float64(totalRows) / float64(size))),
+	paging, err := stmt.Paging(t.db)
+	if err != nil {
+		return nil, model.Paging{}, err
 	}
 	return trainers, paging, nil
-
 }
 
 // CreateTrainer implements TrainerRepository.
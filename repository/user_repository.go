@@ -7,9 +7,10 @@ import (
 	"instructor-led-app/config"
 	"instructor-led-app/entity"
 	"instructor-led-app/entity/dto"
+	"instructor-led-app/shared/apperror"
 	"instructor-led-app/shared/model"
+	"instructor-led-app/shared/query"
 	"log"
-	"math"
 	"os"
 	"time"
 
@@ -18,7 +19,7 @@ import (
 
 type UserRepository interface {
 	Get(id string) (entity.User, error)
-	List(page, size int) ([]entity.User, model.Paging, error)
+	List(spec query.Spec) ([]entity.User, model.Paging, error)
 	Created(data entity.User) (entity.User, error)
 	CreateByCsv(filePath string) ([]entity.User, error)
 	Updated(id string, data entity.User) (entity.User, error)
@@ -67,7 +68,7 @@ func (t *userRepository) GetUser(email string) (entity.User, error) {
 	if err != nil {
 		if err == sql.ErrNoRows {
 			// Handle case when no rows are returned
-			return entity.User{}, fmt.Errorf("user not found with email: %s", email)
+			return entity.User{}, apperror.NotFound("user not found with email: %s", email)
 		}
 		log.Println("userRepository.GetUser:", err.Error())
 		return entity.User{}, err
@@ -262,20 +263,37 @@ func (t *userRepository) Created(data entity.User) (entity.User, error) {
 
 }
 
-// List implements UserRepository.
-func (t *userRepository) List(page, size int) ([]entity.User, model.Paging, error) {
-	var users []entity.User
-	offset := (page - 1) * size
-	row, err := t.db.Query(config.ListUsers, size, offset)
+// userList is what the user list can be filtered and sorted on.
+var userList = query.Table{
+	Fields: map[string]query.Field{
+		"name":      {Column: "name", Type: query.Text, Sortable: true},
+		"email":     {Column: "email", Type: query.Text},
+		"username":  {Column: "username", Type: query.Text},
+		"role":      {Column: "role::text", Type: query.Text},
+		"createdAt": {Column: "created_at", Type: query.Timestamp, Sortable: true},
+		"updatedAt": {Column: "updated_at", Type: query.Timestamp, Sortable: true},
+	},
+	Key:         "id",
+	DefaultSort: []query.Sort{{Field: "createdAt", Desc: true}},
+}
 
+// List implements UserRepository.
+func (t *userRepository) List(spec query.Spec) ([]entity.User, model.Paging, error) {
+	stmt, err := userList.Select(config.ListUsers, spec)
+	if err != nil {
+		return nil, model.Paging{}, err
+	}
+	row, err := t.db.Query(stmt.Query, stmt.Args...)
 	if err != nil {
 		log.Println("UserRepository.Query:", err.Error())
 		return nil, model.Paging{}, err
 	}
+	defer row.Close()
 
-	for row.Next() {
+	var users []entity.User
+	for stmt.Next(row) {
 		var user entity.User
-		err := row.Scan(
+		err := stmt.Scan(row,
 			&user.Id,
 			&user.Name,
 			&user.Email,
@@ -292,19 +310,15 @@ func (t *userRepository) List(page, size int) ([]entity.User, model.Paging, erro
 
 		users = append(users, user)
 	}
-	totalRows := 0
-	if err := t.db.QueryRow("SELECT COUNT(*) FROM users").Scan(&totalRows); err != nil {
+	if err := row.Err(); err != nil {
 		return nil, model.Paging{}, err
 	}
 
-	paging := model.Paging{
-		Page:        page,
-		RowsPerPage: size,
-		TotalRows:   totalRows,
-		TotalPages:  int(math.Ceil(float64(totalRows) / float64(size))),
+	paging, err := stmt.Paging(t.db)
+	if err != nil {
+		return nil, model.Paging{}, err
 	}
 	return users, paging, nil
-
 }
 
 // Delete implements UserRepository.
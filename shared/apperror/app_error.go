@@ -0,0 +1,173 @@
+package apperror
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"instructor-led-app/shared/model"
+	"io/fs"
+	"net/http"
+
+	"github.com/lib/pq"
+)
+
+// Codes are the machine-readable part of an error response, clients should branch on them rather than on messages.
+const (
+	CodeBadRequest      = "bad_request"
+	CodeValidation      = "validation_failed"
+	CodeUnauthorized    = "unauthorized"
+	CodeForbidden       = "forbidden"
+	CodeNotFound        = "not_found"
+	CodeConflict        = "conflict"
+	CodePayloadTooLarge = "payload_too_large"
+	CodeInternal        = "internal_error"
+)
+
+var statuses = map[string]int{
+	CodeBadRequest:      http.StatusBadRequest,
+	CodeValidation:      http.StatusBadRequest,
+	CodeUnauthorized:    http.StatusUnauthorized,
+	CodeForbidden:       http.StatusForbidden,
+	CodeNotFound:        http.StatusNotFound,
+	CodeConflict:        http.StatusConflict,
+	CodePayloadTooLarge: http.StatusRequestEntityTooLarge,
+	CodeInternal:        http.StatusInternalServerError,
+}
+
+// Error is a domain error. Message is safe to show to clients, Err keeps the cause for the logs.
+type Error struct {
+	Code    string
+	Message string
+	Fields  []model.FieldError
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Status is the HTTP status the error is answered with.
+func (e *Error) Status() int {
+	if status, ok := statuses[e.Code]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+func newError(code, format string, args []interface{}) error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+func NotFound(format string, args ...interface{}) error {
+	return newError(CodeNotFound, format, args)
+}
+
+func Conflict(format string, args ...interface{}) error {
+	return newError(CodeConflict, format, args)
+}
+
+func Forbidden(format string, args ...interface{}) error {
+	return newError(CodeForbidden, format, args)
+}
+
+func Unauthorized(format string, args ...interface{}) error {
+	return newError(CodeUnauthorized, format, args)
+}
+
+func BadRequest(format string, args ...interface{}) error {
+	return newError(CodeBadRequest, format, args)
+}
+
+// Invalid is a validation error about a single field.
+func Invalid(field, format string, args ...interface{}) error {
+	message := fmt.Sprintf(format, args...)
+	return &Error{Code: CodeValidation, Message: message, Fields: []model.FieldError{{Field: field, Message: message}}}
+}
+
+// Validation groups the problems found in a payload.
+func Validation(message string, fields ...model.FieldError) error {
+	return &Error{Code: CodeValidation, Message: message, Fields: fields}
+}
+
+// Wrap describes what failed while keeping the kind of err: a missing row stays a not found,
+// a duplicate key stays a conflict, anything unknown becomes an internal error.
+func Wrap(err error, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	cause := From(err)
+	message := fmt.Sprintf(format, args...)
+	if cause.Code != CodeInternal {
+		message += ": " + cause.Message
+	}
+	return &Error{Code: cause.Code, Message: message, Fields: cause.Fields, Err: err}
+}
+
+// Lookup answers a lookup that came back empty: a nil err or a missing row is the not found
+// described by format, any other failure keeps its own kind so an outage isn't answered with 404.
+func Lookup(err error, format string, args ...interface{}) error {
+	if err == nil {
+		return newError(CodeNotFound, format, args)
+	}
+	cause := From(err)
+	if cause.Code != CodeNotFound {
+		return cause
+	}
+	return &Error{Code: CodeNotFound, Message: fmt.Sprintf(format, args...), Err: err}
+}
+
+// From classifies any error. Database errors are recognised by their SQLSTATE so repositories can
+// hand them up as they are.
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	if errors.Is(err, sql.ErrNoRows) || errors.Is(err, fs.ErrNotExist) {
+		return &Error{Code: CodeNotFound, Message: "resource not found", Err: err}
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		message := pqErr.Message
+		if pqErr.Detail != "" {
+			message = pqErr.Detail
+		}
+		switch pqErr.Code.Class() {
+		case "23":
+			if pqErr.Code == "23505" || pqErr.Code == "23503" || pqErr.Code == "23P01" {
+				return &Error{Code: CodeConflict, Message: message, Err: err}
+			}
+			return &Error{Code: CodeValidation, Message: message, Err: err}
+		case "22":
+			return &Error{Code: CodeValidation, Message: message, Err: err}
+		}
+	}
+	return &Error{Code: CodeInternal, Message: "internal server error", Err: err}
+}
+
+// CodeOf returns the code answered for an HTTP status set by hand in a controller or middleware.
+func CodeOf(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeBadRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusRequestEntityTooLarge:
+		return CodePayloadTooLarge
+	}
+	return CodeInternal
+}
@@ -0,0 +1,38 @@
+package apperror
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		code    string
+		message string
+	}{
+		{"nothing found", nil, CodeNotFound, "cohort with ID 1 not found"},
+		{"missing row", fmt.Errorf("cohortRepository.Get: %w", sql.ErrNoRows), CodeNotFound, "cohort with ID 1 not found"},
+		{"missing file", &fs.PathError{Op: "open", Path: "assets/x", Err: fs.ErrNotExist}, CodeNotFound, "cohort with ID 1 not found"},
+		{"database down", errors.New("dial tcp: connection refused"), CodeInternal, "internal server error"},
+		{"bad uuid", &pq.Error{Code: "22P02", Message: "invalid input syntax for type uuid"}, CodeValidation, "invalid input syntax for type uuid"},
+		{"denied upstream", Forbidden("you don't have access"), CodeForbidden, "you don't have access"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := From(Lookup(tt.err, "cohort with ID %s not found", "1"))
+			if got.Code != tt.code || got.Message != tt.message {
+				t.Errorf("Lookup = %s %q, want %s %q", got.Code, got.Message, tt.code, tt.message)
+			}
+			if tt.err != nil && !errors.Is(got, tt.err) {
+				t.Errorf("Lookup lost the cause %v", tt.err)
+			}
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package common
 
 import (
+	"log"
 	"net/http"
 
+	"instructor-led-app/shared/apperror"
 	"instructor-led-app/shared/model"
+	"instructor-led-app/shared/validation"
 
 	"github.com/gin-gonic/gin"
 )
@@ -40,9 +43,28 @@ func SendPagedResponse(ctx *gin.Context, data []interface{}, paging model.Paging
 }
 
 func SendErrorResponse(ctx *gin.Context, code int, message string) {
-	ctx.AbortWithStatusJSON(code, &model.Status{
-		Code:    code,
-		Message: message,
+	ctx.AbortWithStatusJSON(code, &model.ErrorResponse{
+		Status: model.Status{
+			Code:    code,
+			Message: message,
+		},
+		Error: model.ErrorDetail{Code: apperror.CodeOf(code)},
+	})
+}
+
+// SendError answers with the status and code matching the kind of err, internal causes are only logged.
+func SendError(ctx *gin.Context, err error) {
+	appErr := apperror.From(err)
+	status := appErr.Status()
+	if status == http.StatusInternalServerError {
+		log.Println(ctx.Request.Method, ctx.FullPath()+":", err.Error())
+	}
+	ctx.AbortWithStatusJSON(status, &model.ErrorResponse{
+		Status: model.Status{
+			Code:    status,
+			Message: appErr.Message,
+		},
+		Error: model.ErrorDetail{Code: appErr.Code, Fields: appErr.Fields},
 	})
 }
 
@@ -54,3 +76,8 @@ func SendDeleteResponse(ctx *gin.Context, message string) {
 		},
 	})
 }
+
+// SendBindError answers a request that failed binding, with messages in the client's Accept-Language.
+func SendBindError(ctx *gin.Context, err error) {
+	SendError(ctx, validation.Translate(err, ctx.GetHeader("Accept-Language")))
+}
@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+type Event struct {
+	Type       string      `json:"type"`
+	ScheduleID string      `json:"scheduleId"`
+	Data       interface{} `json:"data"`
+	CreatedAt  time.Time   `json:"createdAt"`
+}
@@ -15,3 +15,18 @@ type PagedResponse struct {
 	Data   []interface{} `json:"data"`
 	Paging Paging        `json:"paging"`
 }
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type ErrorDetail struct {
+	Code   string       `json:"code"`
+	Fields []FieldError `json:"fields,omitempty"`
+}
+
+type ErrorResponse struct {
+	Status Status      `json:"status"`
+	Error  ErrorDetail `json:"error"`
+}
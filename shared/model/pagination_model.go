@@ -1,8 +1,11 @@
 package model
 
+// Paging describes a page of a list. Lists read with a cursor leave the totals empty and only
+// carry NextCursor, which is set whenever more rows follow.
 type Paging struct {
-	Page        int `json:"page"`
-	RowsPerPage int `json:"rowsPerPage"`
-	TotalRows   int `json:"totalRows"`
-	TotalPages  int `json:"totalPages"`
+	Page        int    `json:"page"`
+	RowsPerPage int    `json:"rowsPerPage"`
+	TotalRows   int    `json:"totalRows"`
+	TotalPages  int    `json:"totalPages"`
+	NextCursor  string `json:"nextCursor,omitempty"`
 }
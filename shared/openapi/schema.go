@@ -0,0 +1,250 @@
+package openapi
+
+import (
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType  = reflect.TypeOf(time.Time{})
+	bytesType = reflect.TypeOf([]byte(nil))
+)
+
+// Generator builds schemas from Go types. Named structs become components referenced by
+// "package.Name" so every dto and entity is described once.
+type Generator struct {
+	schemas map[string]*Schema
+}
+
+// Schema describes the JSON form of t, registering the structs it meets as components.
+func (g *Generator) Schema(t reflect.Type) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case bytesType:
+		return &Schema{Type: "string", Format: "byte"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := g.Schema(t.Elem())
+		if schema.Ref != "" {
+			return schema
+		}
+		schema.Nullable = true
+		return schema
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Uint, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer"}
+	case reflect.Int32, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.Schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.Schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		name := ComponentName(t)
+		if _, ok := g.schemas[name]; !ok {
+			// Reserve the name first so self referencing types terminate.
+			g.schemas[name] = &Schema{}
+			*g.schemas[name] = *g.object(t)
+		}
+		return Ref(name)
+	}
+	return &Schema{}
+}
+
+// Schemas returns every component met so far.
+func (g *Generator) Schemas() map[string]*Schema {
+	return g.schemas
+}
+
+// QueryParameters describes the fields of a struct bound with ShouldBindQuery.
+func (g *Generator) QueryParameters(t reflect.Type) []Parameter {
+	var params []Parameter
+	for _, field := range fields(t, "form") {
+		schema := g.Schema(field.Type)
+		required := applyBinding(schema, field.Binding)
+		params = append(params, Parameter{Name: field.Name, In: "query", Required: required, Schema: schema})
+	}
+	return params
+}
+
+// FormSchema describes a struct bound from a multipart form.
+func (g *Generator) FormSchema(t reflect.Type) *Schema {
+	return g.properties(fields(t, "form"))
+}
+
+func (g *Generator) object(t reflect.Type) *Schema {
+	return g.properties(fields(t, "json"))
+}
+
+func (g *Generator) properties(fields []field) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for _, field := range fields {
+		property := g.Schema(field.Type)
+		if property.Ref != "" && field.Binding != "" {
+			// Siblings of $ref are ignored, so constraints on a referenced struct are dropped.
+			property = &Schema{Ref: property.Ref}
+		}
+		if applyBinding(property, field.Binding) {
+			schema.Required = append(schema.Required, field.Name)
+		}
+		schema.Properties[field.Name] = property
+	}
+	return schema
+}
+
+// Ref points to a component schema.
+func Ref(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// ComponentName names a struct after its package and type, e.g. dto.CohortDTO.
+func ComponentName(t reflect.Type) string {
+	return path.Base(t.PkgPath()) + "." + t.Name()
+}
+
+type field struct {
+	Name    string
+	Type    reflect.Type
+	Binding string
+}
+
+// fields lists the serialized fields of a struct the way encoding/json and gin's form binding
+// see them, flattening embedded structs.
+func fields(t reflect.Type, tagKey string) []field {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	var result []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name, _, _ := strings.Cut(sf.Tag.Get(tagKey), ",")
+		if name == "-" {
+			continue
+		}
+		if sf.Anonymous && name == "" {
+			embedded := sf.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				result = append(result, fields(embedded, tagKey)...)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		result = append(result, field{Name: name, Type: sf.Type, Binding: sf.Tag.Get("binding")})
+	}
+	return result
+}
+
+// applyBinding copies the validator rules of a binding tag onto schema and reports whether the
+// field is required. Rules after dive apply to the items of a slice.
+func applyBinding(schema *Schema, binding string) bool {
+	if binding == "" {
+		return false
+	}
+	rules := strings.Split(binding, ",")
+	required := false
+	target := schema
+	for _, rule := range rules {
+		tag, param, _ := strings.Cut(rule, "=")
+		switch tag {
+		case "required":
+			if target == schema {
+				required = true
+			}
+		case "dive":
+			if target.Items == nil {
+				return required
+			}
+			target = target.Items
+		case "oneof":
+			target.Enum = strings.Fields(param)
+		case "email":
+			target.Format = "email"
+		case "uuid":
+			target.Format = "uuid"
+		case "url", "uri":
+			target.Format = "uri"
+		case "date":
+			target.Format = "date"
+		case "clock":
+			target.Pattern = `^([01][0-9]|2[0-3]):[0-5][0-9]$`
+		case "phone":
+			target.Pattern = `^\+?[0-9]{8,12}$`
+		case "min", "gte":
+			limit(target, param, true, false)
+		case "max", "lte":
+			limit(target, param, false, false)
+		case "gt":
+			limit(target, param, true, true)
+		case "lt":
+			limit(target, param, false, true)
+		}
+	}
+	return required
+}
+
+// limit sets a length, item count or value bound depending on what the schema describes.
+func limit(schema *Schema, param string, lower, exclusive bool) {
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	count := int(value)
+	if exclusive && lower {
+		count++
+	} else if exclusive {
+		count--
+	}
+	switch schema.Type {
+	case "string":
+		if lower {
+			schema.MinLength = &count
+		} else {
+			schema.MaxLength = &count
+		}
+	case "array":
+		if lower {
+			schema.MinItems = &count
+		} else {
+			schema.MaxItems = &count
+		}
+	case "integer", "number":
+		if lower {
+			schema.Minimum = &value
+			schema.ExclusiveMinimum = exclusive
+		} else {
+			schema.Maximum = &value
+			schema.ExclusiveMaximum = exclusive
+		}
+	}
+}
+
+// NewGenerator creates an empty generator.
+func NewGenerator() *Generator {
+	return &Generator{schemas: map[string]*Schema{}}
+}
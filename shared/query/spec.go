@@ -0,0 +1,150 @@
+package query
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"instructor-led-app/shared/apperror"
+)
+
+// Operators a filter can use.
+const (
+	Eq       = "eq"
+	In       = "in"
+	Range    = "range"
+	Contains = "contains"
+)
+
+const (
+	DefaultSize = 10
+	MaxSize     = 100
+)
+
+// Filter narrows a list on one field. In takes any number of values, Range a lower and an upper
+// bound where either may be empty, the other operators a single value.
+type Filter struct {
+	Field  string
+	Op     string
+	Values []string
+}
+
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// Spec is what a list request asks for. A Cursor continues after the row it points to and
+// replaces Page; offset paging stays available for older clients.
+type Spec struct {
+	Filters []Filter
+	Sort    []Sort
+	Page    int
+	Size    int
+	Cursor  string
+}
+
+// Where adds a filter, for controllers mapping older query parameters onto the spec.
+func (s *Spec) Where(field, op string, values ...string) {
+	s.Filters = append(s.Filters, Filter{Field: field, Op: op, Values: values})
+}
+
+// Parse reads a spec from the query string:
+//
+//	page=2&size=20                    offset paging
+//	cursor=<nextCursor>&size=20       cursor paging
+//	sort=-createdAt,name              descending with a leading -
+//	filter[role]=admin                eq
+//	filter[role][in]=admin,trainer
+//	filter[createdAt][range]=2024-01-01,2024-06-30
+//	filter[name][contains]=budi
+//
+// Whether the fields exist is checked by the repository that runs the spec.
+func Parse(values url.Values) (Spec, error) {
+	spec := Spec{Cursor: values.Get("cursor")}
+
+	var err error
+	if spec.Page, err = number(values, "page"); err != nil {
+		return Spec{}, err
+	}
+	if spec.Size, err = number(values, "size"); err != nil {
+		return Spec{}, err
+	}
+
+	for _, field := range strings.Split(values.Get("sort"), ",") {
+		field = strings.TrimSpace(field)
+		if name, desc := strings.CutPrefix(field, "-"); name != "" {
+			spec.Sort = append(spec.Sort, Sort{Field: name, Desc: desc})
+		}
+	}
+
+	for key, raw := range values {
+		rest, ok := strings.CutPrefix(key, "filter[")
+		if !ok {
+			continue
+		}
+		field, op, ok := filterKey(rest)
+		if !ok {
+			return Spec{}, apperror.Invalid(key, "%s must look like filter[field] or filter[field][operator]", key)
+		}
+		for _, value := range raw {
+			switch op {
+			case Eq, Contains:
+				spec.Where(field, op, value)
+			case In:
+				spec.Where(field, op, strings.Split(value, ",")...)
+			case Range:
+				from, to, ok := strings.Cut(value, ",")
+				if !ok || (from == "" && to == "") {
+					return Spec{}, apperror.Invalid(key, "%s must be a lower and an upper bound separated by a comma", key)
+				}
+				spec.Where(field, op, from, to)
+			default:
+				return Spec{}, apperror.Invalid(key, "%s isn't an operator, use eq, in, range or contains", op)
+			}
+		}
+	}
+	return spec.normalize(), nil
+}
+
+// filterKey splits "role]" or "role][in]" into the field and its operator.
+func filterKey(rest string) (string, string, bool) {
+	field, rest, ok := strings.Cut(rest, "]")
+	if !ok || field == "" {
+		return "", "", false
+	}
+	if rest == "" {
+		return field, Eq, true
+	}
+	op, ok := strings.CutPrefix(rest, "[")
+	if !ok || !strings.HasSuffix(op, "]") {
+		return "", "", false
+	}
+	return field, strings.TrimSuffix(op, "]"), true
+}
+
+func number(values url.Values, key string) (int, error) {
+	raw := values.Get(key)
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, apperror.Invalid(key, "%s must be a number", key)
+	}
+	return n, nil
+}
+
+// normalize falls back to the first page of DefaultSize rows and caps the size at MaxSize.
+func (s Spec) normalize() Spec {
+	if s.Page < 1 {
+		s.Page = 1
+	}
+	if s.Size < 1 {
+		s.Size = DefaultSize
+	}
+	if s.Size > MaxSize {
+		s.Size = MaxSize
+	}
+	return s
+}
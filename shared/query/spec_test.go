@@ -0,0 +1,47 @@
+package query
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    Spec
+		wantErr bool
+	}{
+		{"defaults", "", Spec{Page: 1, Size: DefaultSize}, false},
+		{"offset page", "page=3&size=20", Spec{Page: 3, Size: 20}, false},
+		{"size capped", "size=1000", Spec{Page: 1, Size: MaxSize}, false},
+		{"cursor", "cursor=abc&size=5", Spec{Page: 1, Size: 5, Cursor: "abc"}, false},
+		{"sort", "sort=-createdAt,%20name,", Spec{Page: 1, Size: DefaultSize, Sort: []Sort{{Field: "createdAt", Desc: true}, {Field: "name"}}}, false},
+		{"eq without operator", "filter[role]=admin", Spec{Page: 1, Size: DefaultSize, Filters: []Filter{{Field: "role", Op: Eq, Values: []string{"admin"}}}}, false},
+		{"in", "filter[role][in]=admin,trainer", Spec{Page: 1, Size: DefaultSize, Filters: []Filter{{Field: "role", Op: In, Values: []string{"admin", "trainer"}}}}, false},
+		{"open range", "filter[createdAt][range]=2024-01-01,", Spec{Page: 1, Size: DefaultSize, Filters: []Filter{{Field: "createdAt", Op: Range, Values: []string{"2024-01-01", ""}}}}, false},
+		{"contains", "filter[name][contains]=budi", Spec{Page: 1, Size: DefaultSize, Filters: []Filter{{Field: "name", Op: Contains, Values: []string{"budi"}}}}, false},
+		{"page not a number", "page=two", Spec{}, true},
+		{"unknown operator", "filter[name][like]=budi", Spec{}, true},
+		{"range without bounds", "filter[createdAt][range]=,", Spec{}, true},
+		{"range without comma", "filter[createdAt][range]=2024-01-01", Spec{}, true},
+		{"empty field", "filter[]=x", Spec{}, true},
+		{"unclosed operator", "filter[role][in=admin", Spec{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Parse(values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,363 @@
+package query
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"instructor-led-app/shared/apperror"
+	"instructor-led-app/shared/model"
+
+	"github.com/lib/pq"
+)
+
+// Types of a field, used as the cast of the values compared with its column.
+const (
+	Text      = "text"
+	Number    = "numeric"
+	Date      = "date"
+	Timestamp = "timestamptz"
+	UUID      = "uuid"
+	Bool      = "boolean"
+)
+
+var operators = map[string][]string{
+	Text:      {Eq, In, Contains},
+	Number:    {Eq, In, Range},
+	Date:      {Eq, In, Range},
+	Timestamp: {Eq, In, Range},
+	UUID:      {Eq, In},
+	Bool:      {Eq, In},
+}
+
+// Field maps a field of the API onto a column. Enum columns are cast to text, e.g.
+// "role::text". Only NOT NULL columns may be Sortable, a cursor can't point past a null.
+//
+// A field held by a child table sets Exists to a correlated "SELECT 1 FROM ... WHERE ..."
+// and Column to the child column; a row matches when one of its children does. Such a
+// field can be filtered on but not sorted on.
+type Field struct {
+	Column   string
+	Type     string
+	Sortable bool
+	Exists   string
+}
+
+// Table is the whitelist of a list: the fields it can be filtered and sorted on, its uuid
+// Key column that breaks ties between equal sort values, and the order used without a sort.
+type Table struct {
+	Fields      map[string]Field
+	Key         string
+	DefaultSort []Sort
+}
+
+type sortColumn struct {
+	Name   string
+	Column string
+	Type   string
+	Desc   bool
+}
+
+// Statement is a list query built from a spec. Iterate it with Next and Scan, then read the
+// paging of what was scanned.
+type Statement struct {
+	Query string
+	Args  []interface{}
+
+	spec      Spec
+	sorts     []sortColumn
+	countFrom string
+	countArgs []interface{}
+	scanned   int
+	more      bool
+	last      []string
+}
+
+// Select builds the statement listing base, a bare "SELECT ... FROM table" without WHERE,
+// ORDER BY or LIMIT, and without DISTINCT: a list needing one selects from a subquery. The sort values are selected ahead of the columns of base so the last
+// row can be turned into a cursor.
+func (t Table) Select(base string, spec Spec) (*Statement, error) {
+	spec = spec.normalize()
+	stmt := &Statement{spec: spec}
+
+	sorts, err := t.sorts(spec.Sort)
+	if err != nil {
+		return nil, err
+	}
+	stmt.sorts = sorts
+
+	var where []string
+	for _, filter := range spec.Filters {
+		condition, err := t.condition(filter, &stmt.Args)
+		if err != nil {
+			return nil, err
+		}
+		if exists := t.Fields[filter.Field].Exists; exists != "" {
+			condition = "EXISTS (" + exists + " AND " + condition + ")"
+		}
+		where = append(where, condition)
+	}
+	stmt.countArgs = append([]interface{}{}, stmt.Args...)
+	stmt.countFrom = base + clause(" WHERE ", where)
+
+	if spec.Cursor != "" {
+		keys, err := decodeCursor(spec.Cursor, signature(sorts))
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, keyset(sorts, keys, &stmt.Args))
+	}
+
+	columns := make([]string, len(sorts))
+	order := make([]string, len(sorts))
+	for i, s := range sorts {
+		columns[i] = "(" + s.Column + ")::text"
+		order[i] = s.Column + direction(s.Desc)
+	}
+	stmt.Query = "SELECT " + strings.Join(columns, ", ") + ", " + strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(base), "SELECT")) +
+		clause(" WHERE ", where) + " ORDER BY " + strings.Join(order, ", ")
+
+	stmt.Args = append(stmt.Args, spec.Size+1)
+	stmt.Query += fmt.Sprintf(" LIMIT $%d", len(stmt.Args))
+	if spec.Cursor == "" {
+		stmt.Args = append(stmt.Args, (spec.Page-1)*spec.Size)
+		stmt.Query += fmt.Sprintf(" OFFSET $%d", len(stmt.Args))
+	}
+	return stmt, nil
+}
+
+// sorts resolves the requested order, or the default one, and ends it with the key column in
+// the direction of the first sort.
+func (t Table) sorts(requested []Sort) ([]sortColumn, error) {
+	if len(requested) == 0 {
+		requested = t.DefaultSort
+	}
+	var sorts []sortColumn
+	seen := map[string]bool{}
+	for _, s := range requested {
+		field, ok := t.Fields[s.Field]
+		if !ok || !field.Sortable {
+			return nil, apperror.Invalid("sort", "%s can't be sorted on, use one of %s", s.Field, strings.Join(t.sortable(), ", "))
+		}
+		if seen[s.Field] {
+			continue
+		}
+		seen[s.Field] = true
+		sorts = append(sorts, sortColumn{Name: s.Field, Column: field.Column, Type: field.Type, Desc: s.Desc})
+	}
+	desc := len(sorts) > 0 && sorts[0].Desc
+	return append(sorts, sortColumn{Name: t.Key, Column: t.Key, Type: UUID, Desc: desc}), nil
+}
+
+func (t Table) sortable() []string {
+	var names []string
+	for name, field := range t.Fields {
+		if field.Sortable {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (t Table) filterable() []string {
+	var names []string
+	for name := range t.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// condition turns a filter into SQL, adding its values to args.
+func (t Table) condition(filter Filter, args *[]interface{}) (string, error) {
+	key := "filter[" + filter.Field + "]"
+	field, ok := t.Fields[filter.Field]
+	if !ok {
+		return "", apperror.Invalid(key, "%s can't be filtered on, use one of %s", filter.Field, strings.Join(t.filterable(), ", "))
+	}
+	if !allowed(field.Type, filter.Op) {
+		return "", apperror.Invalid(key, "%s can't be used on %s, use one of %s", filter.Op, filter.Field, strings.Join(operators[field.Type], ", "))
+	}
+	if len(filter.Values) == 0 || (filter.Op != In && filter.Op != Range && len(filter.Values) != 1) {
+		return "", apperror.Invalid(key, "%s has the wrong number of values", key)
+	}
+
+	arg := func(value interface{}) string {
+		*args = append(*args, value)
+		return fmt.Sprintf("$%d", len(*args))
+	}
+	switch filter.Op {
+	case In:
+		return fmt.Sprintf("%s = ANY(%s::%s[])", field.Column, arg(pq.Array(filter.Values)), field.Type), nil
+	case Contains:
+		return fmt.Sprintf("%s ILIKE '%%' || %s || '%%'", field.Column, arg(likeEscaper.Replace(filter.Values[0]))), nil
+	case Range:
+		if len(filter.Values) != 2 {
+			return "", apperror.Invalid(key, "%s needs a lower and an upper bound", key)
+		}
+		var bounds []string
+		if from := filter.Values[0]; from != "" {
+			bounds = append(bounds, fmt.Sprintf("%s >= %s::%s", field.Column, arg(from), field.Type))
+		}
+		if to := filter.Values[1]; to != "" {
+			if _, err := time.Parse("2006-01-02", to); err == nil && field.Type == Timestamp {
+				// A plain date as the upper bound of a timestamp includes the whole day.
+				bounds = append(bounds, fmt.Sprintf("%s < %s::date + 1", field.Column, arg(to)))
+			} else {
+				bounds = append(bounds, fmt.Sprintf("%s <= %s::%s", field.Column, arg(to), field.Type))
+			}
+		}
+		return strings.Join(bounds, " AND "), nil
+	}
+	return fmt.Sprintf("%s = %s::%s", field.Column, arg(filter.Values[0]), field.Type), nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func allowed(fieldType, op string) bool {
+	for _, candidate := range operators[fieldType] {
+		if candidate == op {
+			return true
+		}
+	}
+	return false
+}
+
+// keyset selects the rows after the cursor: (a > $1) OR (a = $1 AND b > $2) OR ...
+func keyset(sorts []sortColumn, keys []string, args *[]interface{}) string {
+	placeholders := make([]string, len(sorts))
+	for i, s := range sorts {
+		*args = append(*args, keys[i])
+		placeholders[i] = fmt.Sprintf("$%d::%s", len(*args), s.Type)
+	}
+	alternatives := make([]string, len(sorts))
+	for i := range sorts {
+		var parts []string
+		for j := 0; j < i; j++ {
+			parts = append(parts, sorts[j].Column+" = "+placeholders[j])
+		}
+		op := " > "
+		if sorts[i].Desc {
+			op = " < "
+		}
+		parts = append(parts, sorts[i].Column+op+placeholders[i])
+		alternatives[i] = "(" + strings.Join(parts, " AND ") + ")"
+	}
+	return "(" + strings.Join(alternatives, " OR ") + ")"
+}
+
+func clause(keyword string, conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return keyword + strings.Join(conditions, " AND ")
+}
+
+func direction(desc bool) string {
+	if desc {
+		return " DESC"
+	}
+	return " ASC"
+}
+
+// Next advances to the next row of the page, holding back the extra row that tells whether
+// another page follows.
+func (s *Statement) Next(rows *sql.Rows) bool {
+	if !rows.Next() {
+		return false
+	}
+	if s.scanned == s.spec.Size {
+		s.more = true
+		return false
+	}
+	s.scanned++
+	return true
+}
+
+// Scan reads the current row into dest, the columns of the base query.
+func (s *Statement) Scan(rows *sql.Rows, dest ...interface{}) error {
+	keys := make([]string, len(s.sorts))
+	targets := make([]interface{}, 0, len(keys)+len(dest))
+	for i := range keys {
+		targets = append(targets, &keys[i])
+	}
+	if err := rows.Scan(append(targets, dest...)...); err != nil {
+		return err
+	}
+	s.last = keys
+	return nil
+}
+
+// Row is the current row of a statement, for the scan functions shared with single-row
+// queries.
+func (s *Statement) Row(rows *sql.Rows) Row {
+	return Row{stmt: s, rows: rows}
+}
+
+type Row struct {
+	stmt *Statement
+	rows *sql.Rows
+}
+
+func (r Row) Scan(dest ...interface{}) error {
+	return r.stmt.Scan(r.rows, dest...)
+}
+
+// Paging describes what was scanned. Offset pages count the filtered rows, cursor pages don't
+// and only tell where to continue.
+func (s *Statement) Paging(db *sql.DB) (model.Paging, error) {
+	paging := model.Paging{RowsPerPage: s.spec.Size}
+	if s.more {
+		paging.NextCursor = encodeCursor(signature(s.sorts), s.last)
+	}
+	if s.spec.Cursor != "" {
+		return paging, nil
+	}
+
+	if err := db.QueryRow("SELECT COUNT(*) FROM ("+s.countFrom+") AS list", s.countArgs...).Scan(&paging.TotalRows); err != nil {
+		return model.Paging{}, err
+	}
+	paging.Page = s.spec.Page
+	paging.TotalPages = int(math.Ceil(float64(paging.TotalRows) / float64(s.spec.Size)))
+	return paging, nil
+}
+
+type cursor struct {
+	Sort string   `json:"s"`
+	Keys []string `json:"k"`
+}
+
+// signature names the order a cursor was made for, e.g. "-createdAt,-id".
+func signature(sorts []sortColumn) string {
+	names := make([]string, len(sorts))
+	for i, s := range sorts {
+		names[i] = s.Name
+		if s.Desc {
+			names[i] = "-" + s.Name
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+func encodeCursor(sort string, keys []string) string {
+	raw, _ := json.Marshal(cursor{Sort: sort, Keys: keys})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(value, sort string) ([]string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	var c cursor
+	if err != nil || json.Unmarshal(raw, &c) != nil {
+		return nil, apperror.Invalid("cursor", "cursor is malformed")
+	}
+	if c.Sort != sort || len(c.Keys) != strings.Count(sort, ",")+1 {
+		return nil, apperror.Invalid("cursor", "cursor belongs to another sort, request the first page again")
+	}
+	return c.Keys, nil
+}
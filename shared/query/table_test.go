@@ -0,0 +1,163 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+var trainers = Table{
+	Fields: map[string]Field{
+		"name":      {Column: "name", Type: Text, Sortable: true},
+		"role":      {Column: "role::text", Type: Text},
+		"createdAt": {Column: "created_at", Type: Timestamp, Sortable: true},
+		"specialization": {
+			Column: "sp.name",
+			Type:   Text,
+			Exists: "SELECT 1 FROM specializations sp WHERE sp.trainer_id = trainers.id",
+		},
+	},
+	Key:         "id",
+	DefaultSort: []Sort{{Field: "createdAt", Desc: true}},
+}
+
+func TestSelect(t *testing.T) {
+	const base = "SELECT id, name FROM trainers"
+	const columns = "SELECT (created_at)::text, (id)::text, id, name FROM trainers"
+	const order = " ORDER BY created_at DESC, id DESC"
+	cursor := encodeCursor("-createdAt,-id", []string{"2024-01-31 12:00:00+00", "7b0e6c1e-8f3a-4d56-9d2b-0c2f6f0f2a11"})
+
+	tests := []struct {
+		name    string
+		base    string
+		spec    Spec
+		query   string
+		args    []interface{}
+		wantErr bool
+	}{
+		{
+			name:  "default order",
+			base:  base,
+			query: columns + order + " LIMIT $1 OFFSET $2",
+			args:  []interface{}{DefaultSize + 1, 0},
+		},
+		{
+			name:  "multi-line base",
+			base:  "\n\tSELECT\n\t\tid, name\n\tFROM\n\t\ttrainers",
+			query: "SELECT (created_at)::text, (id)::text, id, name\n\tFROM\n\t\ttrainers" + order + " LIMIT $1 OFFSET $2",
+			args:  []interface{}{DefaultSize + 1, 0},
+		},
+		{
+			name: "placeholders follow the filters",
+			base: base,
+			spec: Spec{Page: 3, Size: 5, Filters: []Filter{
+				{Field: "role", Op: Eq, Values: []string{"trainer"}},
+				{Field: "name", Op: In, Values: []string{"Budi", "Sari"}},
+			}},
+			query: columns + " WHERE role::text = $1::text AND name = ANY($2::text[])" + order + " LIMIT $3 OFFSET $4",
+			args:  []interface{}{"trainer", pq.Array([]string{"Budi", "Sari"}), 6, 10},
+		},
+		{
+			name:  "child field goes through exists",
+			base:  base,
+			spec:  Spec{Filters: []Filter{{Field: "specialization", Op: Contains, Values: []string{"50%_go"}}}},
+			query: columns + " WHERE EXISTS (SELECT 1 FROM specializations sp WHERE sp.trainer_id = trainers.id AND sp.name ILIKE '%' || $1 || '%')" + order + " LIMIT $2 OFFSET $3",
+			args:  []interface{}{`50\%\_go`, DefaultSize + 1, 0},
+		},
+		{
+			name:  "date upper bound of a timestamp takes the whole day",
+			base:  base,
+			spec:  Spec{Filters: []Filter{{Field: "createdAt", Op: Range, Values: []string{"2024-01-01", "2024-01-31"}}}},
+			query: columns + " WHERE created_at >= $1::timestamptz AND created_at < $2::date + 1" + order + " LIMIT $3 OFFSET $4",
+			args:  []interface{}{"2024-01-01", "2024-01-31", DefaultSize + 1, 0},
+		},
+		{
+			name:  "timestamp upper bound is inclusive",
+			base:  base,
+			spec:  Spec{Filters: []Filter{{Field: "createdAt", Op: Range, Values: []string{"", "2024-01-31T12:00:00Z"}}}},
+			query: columns + " WHERE created_at <= $1::timestamptz" + order + " LIMIT $2 OFFSET $3",
+			args:  []interface{}{"2024-01-31T12:00:00Z", DefaultSize + 1, 0},
+		},
+		{
+			name:  "cursor replaces the offset",
+			base:  base,
+			spec:  Spec{Cursor: cursor, Filters: []Filter{{Field: "role", Op: Eq, Values: []string{"trainer"}}}},
+			query: columns + " WHERE role::text = $1::text AND ((created_at < $2::timestamptz) OR (created_at = $2::timestamptz AND id < $3::uuid))" + order + " LIMIT $4",
+			args:  []interface{}{"trainer", "2024-01-31 12:00:00+00", "7b0e6c1e-8f3a-4d56-9d2b-0c2f6f0f2a11", DefaultSize + 1},
+		},
+		{name: "unknown field", base: base, spec: Spec{Filters: []Filter{{Field: "password", Op: Eq, Values: []string{"x"}}}}, wantErr: true},
+		{name: "operator of another type", base: base, spec: Spec{Filters: []Filter{{Field: "createdAt", Op: Contains, Values: []string{"2024"}}}}, wantErr: true},
+		{name: "eq with two values", base: base, spec: Spec{Filters: []Filter{{Field: "name", Op: Eq, Values: []string{"a", "b"}}}}, wantErr: true},
+		{name: "sort on a filter-only field", base: base, spec: Spec{Sort: []Sort{{Field: "role"}}}, wantErr: true},
+		{name: "sort on a child field", base: base, spec: Spec{Sort: []Sort{{Field: "specialization"}}}, wantErr: true},
+		{name: "cursor of another sort", base: base, spec: Spec{Cursor: cursor, Sort: []Sort{{Field: "name"}}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt, err := trainers.Select(tt.base, tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Select error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if stmt.Query != tt.query {
+				t.Errorf("Select query\n got %q\nwant %q", stmt.Query, tt.query)
+			}
+			if !reflect.DeepEqual(stmt.Args, tt.args) {
+				t.Errorf("Select args = %#v, want %#v", stmt.Args, tt.args)
+			}
+		})
+	}
+}
+
+func TestKeyset(t *testing.T) {
+	sorts, err := trainers.sorts([]Sort{{Field: "name"}, {Field: "createdAt", Desc: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := []interface{}{"trainer"}
+	got := keyset(sorts, []string{"Budi", "2024-01-31 12:00:00+00", "7b0e6c1e-8f3a-4d56-9d2b-0c2f6f0f2a11"}, &args)
+
+	// the key follows the direction of the first sort, ascending here
+	want := "((name > $2::text)" +
+		" OR (name = $2::text AND created_at < $3::timestamptz)" +
+		" OR (name = $2::text AND created_at = $3::timestamptz AND id > $4::uuid))"
+	if got != want {
+		t.Errorf("keyset\n got %q\nwant %q", got, want)
+	}
+	wantArgs := []interface{}{"trainer", "Budi", "2024-01-31 12:00:00+00", "7b0e6c1e-8f3a-4d56-9d2b-0c2f6f0f2a11"}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("keyset args = %v, want %v", args, wantArgs)
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	keys := []string{"Budi", "7b0e6c1e-8f3a-4d56-9d2b-0c2f6f0f2a11"}
+
+	tests := []struct {
+		name    string
+		cursor  string
+		sort    string
+		wantErr bool
+	}{
+		{"same sort", encodeCursor("name,id", keys), "name,id", false},
+		{"another field", encodeCursor("name,id", keys), "createdAt,id", true},
+		{"another direction", encodeCursor("name,id", keys), "-name,-id", true},
+		{"keys missing", encodeCursor("name,id", keys[:1]), "name,id", true},
+		{"not base64", "not a cursor!", "name,id", true},
+		{"not json", "bm90IGpzb24", "name,id", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeCursor(tt.cursor, tt.sort)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodeCursor error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, keys) {
+				t.Errorf("decodeCursor = %v, want %v", got, keys)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	pdfPageWidth  = 842.0 // A4 landscape in points
+	pdfPageHeight = 595.0
+)
+
+// CertificateDocument is everything printed on a completion certificate.
+type CertificateDocument struct {
+	ParticipantName string
+	Track           string
+	CohortName      string
+	Trainer         string
+	StartDate       string
+	EndDate         string
+	IssuedAt        string
+	Code            string
+	VerifyURL       string
+}
+
+type CertificateRenderer interface {
+	Render(doc CertificateDocument) ([]byte, error)
+}
+
+type certificateRenderer struct{}
+
+type pdfLine struct {
+	text string
+	size float64
+	bold bool
+	y    float64
+}
+
+// Render writes a single page PDF using the standard Helvetica fonts, so no font has to be embedded.
+func (r *certificateRenderer) Render(doc CertificateDocument) ([]byte, error) {
+	if doc.ParticipantName == "" || doc.Code == "" {
+		return nil, fmt.Errorf("certificate needs a participant name and a code")
+	}
+
+	lines := []pdfLine{
+		{"CERTIFICATE OF COMPLETION", 30, true, 470},
+		{"This certifies that", 14, false, 420},
+		{doc.ParticipantName, 28, true, 375},
+		{"has completed the " + doc.Track + " track of", 14, false, 335},
+		{doc.CohortName, 18, true, 305},
+		{"held from " + doc.StartDate + " to " + doc.EndDate + " with " + doc.Trainer, 12, false, 270},
+		{"Issued on " + doc.IssuedAt, 11, false, 150},
+		{"Verification code: " + doc.Code, 11, true, 130},
+	}
+	if doc.VerifyURL != "" {
+		lines = append(lines, pdfLine{"Verify at " + doc.VerifyURL, 9, false, 112})
+	}
+
+	var content bytes.Buffer
+	// a simple double frame around the page
+	content.WriteString("0.2 0.3 0.5 RG 3 w 30 30 782 535 re S 1 w 40 40 762 515 re S\n")
+	for _, line := range lines {
+		font := "F1"
+		if line.bold {
+			font = "F2"
+		}
+		text := pdfText(line.text)
+		x := (pdfPageWidth - textWidth(text, line.size, line.bold)) / 2
+		fmt.Fprintf(&content, "BT /%s %.0f Tf %.2f %.2f Td (%s) Tj ET\n", font, line.size, x, line.y, escapePDF(text))
+	}
+
+	stream := strings.TrimSuffix(content.String(), "\n")
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 4 0 R /F2 5 0 R >> >> /Contents 6 0 R >>", pdfPageWidth, pdfPageHeight),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(stream), stream),
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return out.Bytes(), nil
+}
+
+// pdfText maps the string onto single byte WinAnsi; characters outside Latin-1 become '?'.
+func pdfText(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		if r < 32 || r > 255 {
+			r = '?'
+		}
+		b.WriteByte(byte(r))
+	}
+	return b.String()
+}
+
+func escapePDF(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`).Replace(value)
+}
+
+// textWidth estimates the rendered width from average Helvetica glyph widths, close enough to center a line.
+func textWidth(text string, size float64, bold bool) float64 {
+	var units float64
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case c == ' ' || c == '.' || c == ',' || c == ':' || c == 'i' || c == 'l' || c == 'j' || c == 't' || c == 'f':
+			units += 300
+		case c >= 'A' && c <= 'Z', c == 'm' || c == 'w':
+			units += 700
+		default:
+			units += 556
+		}
+	}
+	if bold {
+		units *= 1.05
+	}
+	return units / 1000 * size
+}
+
+func NewCertificateRenderer() CertificateRenderer {
+	return &certificateRenderer{}
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"instructor-led-app/shared/model"
+	"sync"
+	"time"
+)
+
+const (
+	EventQuestionCreated  = "question.created"
+	EventQuestionAnswered = "question.answered"
+	EventQuestionVoted    = "question.voted"
+	EventAbsenceCreated   = "absence.created"
+	EventAbsenceUpdated   = "absence.updated"
+)
+
+type EventBroker interface {
+	Subscribe(scheduleID string) (<-chan model.Event, func())
+	Publish(scheduleID, eventType string, data interface{})
+}
+
+type eventBroker struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[chan model.Event]struct{}
+}
+
+// Subscribe registers a listener for the schedule, call the returned func to release it.
+func (b *eventBroker) Subscribe(scheduleID string) (<-chan model.Event, func()) {
+	ch := make(chan model.Event, 16)
+
+	b.mu.Lock()
+	if b.subscribers[scheduleID] == nil {
+		b.subscribers[scheduleID] = make(map[chan model.Event]struct{})
+	}
+	b.subscribers[scheduleID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers[scheduleID], ch)
+			if len(b.subscribers[scheduleID]) == 0 {
+				delete(b.subscribers, scheduleID)
+			}
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+	return ch, unsubscribe
+}
+
+// Publish never blocks, slow listeners just miss the event.
+func (b *eventBroker) Publish(scheduleID, eventType string, data interface{}) {
+	if scheduleID == "" {
+		return
+	}
+	event := model.Event{
+		Type:       eventType,
+		ScheduleID: scheduleID,
+		Data:       data,
+		CreatedAt:  time.Now(),
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for ch := range b.subscribers[scheduleID] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+func NewEventBroker() EventBroker {
+	return &eventBroker{subscribers: make(map[string]map[chan model.Event]struct{})}
+}
@@ -0,0 +1,265 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	exifTagDateTime         = 0x0132
+	exifTagExifIFD          = 0x8769
+	exifTagGPSIFD           = 0x8825
+	exifTagDateTimeOriginal = 0x9003
+	exifTagOffsetOriginal   = 0x9011
+	exifTagGPSLatitudeRef   = 0x0001
+	exifTagGPSLatitude      = 0x0002
+	exifTagGPSLongitudeRef  = 0x0003
+	exifTagGPSLongitude     = 0x0004
+
+	exifTypeASCII    = 2
+	exifTypeLong     = 4
+	exifTypeRational = 5
+	exifTypeShort    = 3
+)
+
+// ImageMetadata is what the upload pipeline keeps from EXIF before it gets stripped.
+type ImageMetadata struct {
+	CapturedAt *time.Time
+	Latitude   *float64
+	Longitude  *float64
+}
+
+func (m ImageMetadata) HasLocation() bool {
+	return m.Latitude != nil && m.Longitude != nil
+}
+
+// ExtractMetadata reads the capture time and GPS position from the EXIF block
+// of a JPEG, PNG or WebP file. Missing or unreadable EXIF yields empty metadata.
+func ExtractMetadata(content []byte) ImageMetadata {
+	payload := exifPayload(content)
+	if payload == nil {
+		return ImageMetadata{}
+	}
+	metadata, err := parseExif(payload)
+	if err != nil {
+		return ImageMetadata{}
+	}
+	return metadata
+}
+
+// exifPayload locates the raw TIFF structure inside the container.
+func exifPayload(content []byte) []byte {
+	switch {
+	case len(content) > 4 && content[0] == 0xFF && content[1] == 0xD8:
+		pos := 2
+		for pos+4 <= len(content) && content[pos] == 0xFF {
+			marker := content[pos+1]
+			if marker == 0xDA || marker == 0xD9 {
+				return nil
+			}
+			length := int(binary.BigEndian.Uint16(content[pos+2:]))
+			if length < 2 || pos+2+length > len(content) {
+				return nil
+			}
+			segment := content[pos+4 : pos+2+length]
+			if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+				return segment[6:]
+			}
+			pos += 2 + length
+		}
+	case bytes.HasPrefix(content, []byte("\x89PNG\r\n\x1a\n")):
+		pos := 8
+		for pos+8 <= len(content) {
+			length := int(binary.BigEndian.Uint32(content[pos:]))
+			if length < 0 || pos+12+length > len(content) {
+				return nil
+			}
+			if string(content[pos+4:pos+8]) == "eXIf" {
+				return content[pos+8 : pos+8+length]
+			}
+			pos += 12 + length
+		}
+	case len(content) > 12 && string(content[0:4]) == "RIFF" && string(content[8:12]) == "WEBP":
+		pos := 12
+		for pos+8 <= len(content) {
+			length := int(binary.LittleEndian.Uint32(content[pos+4:]))
+			if length < 0 || pos+8+length > len(content) {
+				return nil
+			}
+			if string(content[pos:pos+4]) == "EXIF" {
+				return bytes.TrimPrefix(content[pos+8:pos+8+length], []byte("Exif\x00\x00"))
+			}
+			pos += 8 + length + length%2
+		}
+	}
+	return nil
+}
+
+type exifEntry struct {
+	kind  uint16
+	count uint32
+	value []byte
+}
+
+type exifReader struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+func parseExif(data []byte) (ImageMetadata, error) {
+	if len(data) < 8 {
+		return ImageMetadata{}, fmt.Errorf("exif header is too short")
+	}
+	r := exifReader{data: data}
+	switch string(data[0:2]) {
+	case "II":
+		r.order = binary.LittleEndian
+	case "MM":
+		r.order = binary.BigEndian
+	default:
+		return ImageMetadata{}, fmt.Errorf("unknown exif byte order")
+	}
+
+	ifd0, err := r.readIFD(r.order.Uint32(data[4:]))
+	if err != nil {
+		return ImageMetadata{}, err
+	}
+
+	var metadata ImageMetadata
+	dateTime := r.ascii(ifd0[exifTagDateTime])
+	offset := ""
+	if entry, ok := ifd0[exifTagExifIFD]; ok {
+		if exifIFD, err := r.readIFD(r.long(entry)); err == nil {
+			if original := r.ascii(exifIFD[exifTagDateTimeOriginal]); original != "" {
+				dateTime = original
+			}
+			offset = r.ascii(exifIFD[exifTagOffsetOriginal])
+		}
+	}
+	if capturedAt, ok := parseExifTime(dateTime, offset); ok {
+		metadata.CapturedAt = &capturedAt
+	}
+
+	if entry, ok := ifd0[exifTagGPSIFD]; ok {
+		if gpsIFD, err := r.readIFD(r.long(entry)); err == nil {
+			latitude, latOK := r.coordinate(gpsIFD[exifTagGPSLatitude], r.ascii(gpsIFD[exifTagGPSLatitudeRef]), "S")
+			longitude, lonOK := r.coordinate(gpsIFD[exifTagGPSLongitude], r.ascii(gpsIFD[exifTagGPSLongitudeRef]), "W")
+			if latOK && lonOK {
+				metadata.Latitude, metadata.Longitude = &latitude, &longitude
+			}
+		}
+	}
+
+	return metadata, nil
+}
+
+func (r exifReader) readIFD(offset uint32) (map[uint16]exifEntry, error) {
+	start := int(offset)
+	if start < 8 || start+2 > len(r.data) {
+		return nil, fmt.Errorf("exif directory is out of range")
+	}
+	count := int(r.order.Uint16(r.data[start:]))
+	entries := make(map[uint16]exifEntry, count)
+	for i := 0; i < count; i++ {
+		pos := start + 2 + i*12
+		if pos+12 > len(r.data) {
+			return nil, fmt.Errorf("exif directory is truncated")
+		}
+		entry := exifEntry{
+			kind:  r.order.Uint16(r.data[pos+2:]),
+			count: r.order.Uint32(r.data[pos+4:]),
+		}
+		size := exifTypeSize(entry.kind) * int(entry.count)
+		if size <= 0 {
+			continue
+		}
+		if size <= 4 {
+			entry.value = r.data[pos+8 : pos+8+size]
+		} else {
+			valueOffset := int(r.order.Uint32(r.data[pos+8:]))
+			if valueOffset < 0 || valueOffset+size > len(r.data) {
+				continue
+			}
+			entry.value = r.data[valueOffset : valueOffset+size]
+		}
+		entries[r.order.Uint16(r.data[pos:])] = entry
+	}
+	return entries, nil
+}
+
+func (r exifReader) ascii(entry exifEntry) string {
+	if entry.kind != exifTypeASCII {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimRight(string(entry.value), "\x00"))
+}
+
+func (r exifReader) long(entry exifEntry) uint32 {
+	switch entry.kind {
+	case exifTypeLong:
+		return r.order.Uint32(entry.value)
+	case exifTypeShort:
+		return uint32(r.order.Uint16(entry.value))
+	}
+	return 0
+}
+
+// coordinate converts a degrees/minutes/seconds triple into signed decimal degrees.
+func (r exifReader) coordinate(entry exifEntry, ref, negativeRef string) (float64, bool) {
+	if entry.kind != exifTypeRational || entry.count < 3 || ref == "" {
+		return 0, false
+	}
+	var parts [3]float64
+	for i := range parts {
+		numerator := r.order.Uint32(entry.value[i*8:])
+		denominator := r.order.Uint32(entry.value[i*8+4:])
+		if denominator == 0 {
+			return 0, false
+		}
+		parts[i] = float64(numerator) / float64(denominator)
+	}
+	value := parts[0] + parts[1]/60 + parts[2]/3600
+	if strings.EqualFold(ref, negativeRef) {
+		value = -value
+	}
+	if math.IsNaN(value) || math.Abs(value) > 180 {
+		return 0, false
+	}
+	return value, true
+}
+
+// parseExifTime reads "2006:01:02 15:04:05"; without an offset tag the camera's
+// local time is assumed to be the server's, the same zone sessions are planned in.
+func parseExifTime(value, offset string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	if offset != "" {
+		if t, err := time.Parse("2006:01:02 15:04:05-07:00", value+offset); err == nil {
+			return t, true
+		}
+	}
+	t, err := time.ParseInLocation("2006:01:02 15:04:05", value, time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func exifTypeSize(kind uint16) int {
+	switch kind {
+	case 1, exifTypeASCII, 6, 7:
+		return 1
+	case exifTypeShort, 8:
+		return 2
+	case exifTypeLong, 9, 11:
+		return 4
+	case exifTypeRational, 10, 12:
+		return 8
+	}
+	return 0
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"math"
+	"testing"
+	"time"
+)
+
+// The fixtures are built in memory: a TIFF structure holding IFD0 with optional Exif and GPS
+// directories, wrapped in the smallest JPEG, PNG or WebP container ExtractMetadata reads.
+
+type ifdEntry struct {
+	tag   uint16
+	kind  uint16
+	count uint32
+	value []byte
+}
+
+func asciiEntry(tag uint16, value string) ifdEntry {
+	return ifdEntry{tag: tag, kind: exifTypeASCII, count: uint32(len(value) + 1), value: []byte(value + "\x00")}
+}
+
+func longEntry(order binary.ByteOrder, tag uint16, value uint32) ifdEntry {
+	raw := make([]byte, 4)
+	order.PutUint32(raw, value)
+	return ifdEntry{tag: tag, kind: exifTypeLong, count: 1, value: raw}
+}
+
+// rationalEntry takes numerator and denominator pairs.
+func rationalEntry(order binary.ByteOrder, tag uint16, values ...uint32) ifdEntry {
+	raw := make([]byte, 4*len(values))
+	for i, v := range values {
+		order.PutUint32(raw[i*4:], v)
+	}
+	return ifdEntry{tag: tag, kind: exifTypeRational, count: uint32(len(values) / 2), value: raw}
+}
+
+// appendIFD writes a directory at the end of data, its out of line values right after it.
+func appendIFD(data []byte, order binary.ByteOrder, entries []ifdEntry) ([]byte, uint32) {
+	start := len(data)
+	ifd := make([]byte, 2+12*len(entries)+4)
+	valuesAt := start + len(ifd)
+	order.PutUint16(ifd, uint16(len(entries)))
+	var values []byte
+	for i, e := range entries {
+		pos := 2 + 12*i
+		order.PutUint16(ifd[pos:], e.tag)
+		order.PutUint16(ifd[pos+2:], e.kind)
+		order.PutUint32(ifd[pos+4:], e.count)
+		if len(e.value) <= 4 {
+			copy(ifd[pos+8:], e.value)
+			continue
+		}
+		order.PutUint32(ifd[pos+8:], uint32(valuesAt+len(values)))
+		values = append(values, e.value...)
+	}
+	return append(append(data, ifd...), values...), uint32(start)
+}
+
+func buildTIFF(order binary.ByteOrder, ifd0, exifIFD, gpsIFD []ifdEntry) []byte {
+	data := []byte("II*\x00\x00\x00\x00\x00")
+	if order == binary.BigEndian {
+		data = []byte("MM\x00*\x00\x00\x00\x00")
+	}
+	var offset uint32
+	if exifIFD != nil {
+		data, offset = appendIFD(data, order, exifIFD)
+		ifd0 = append(ifd0, longEntry(order, exifTagExifIFD, offset))
+	}
+	if gpsIFD != nil {
+		data, offset = appendIFD(data, order, gpsIFD)
+		ifd0 = append(ifd0, longEntry(order, exifTagGPSIFD, offset))
+	}
+	data, offset = appendIFD(data, order, ifd0)
+	order.PutUint32(data[4:], offset)
+	return data
+}
+
+func jpegWith(tiff []byte) []byte {
+	content := []byte{0xFF, 0xD8}
+	// a JFIF segment ahead of the EXIF one, which has to be skipped
+	content = append(content, 0xFF, 0xE0, 0x00, 0x07, 'J', 'F', 'I', 'F', 0x00)
+	if tiff != nil {
+		segment := append([]byte("Exif\x00\x00"), tiff...)
+		content = append(content, 0xFF, 0xE1, byte((len(segment)+2)>>8), byte(len(segment)+2))
+		content = append(content, segment...)
+	}
+	return append(content, 0xFF, 0xDA, 0x00, 0x02, 0xFF, 0xD9)
+}
+
+func pngChunk(kind string, data []byte) []byte {
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+	chunk = append(append(chunk, kind...), data...)
+	return binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+}
+
+func pngWith(tiff []byte) []byte {
+	content := []byte("\x89PNG\r\n\x1a\n")
+	content = append(content, pngChunk("IHDR", []byte{0, 0, 0, 1, 0, 0, 0, 1, 8, 2, 0, 0, 0})...)
+	if tiff != nil {
+		content = append(content, pngChunk("eXIf", tiff)...)
+	}
+	return append(content, pngChunk("IEND", nil)...)
+}
+
+func webpWith(tiff []byte) []byte {
+	body := []byte("WEBP")
+	body = append(body, "VP8X"...)
+	body = binary.LittleEndian.AppendUint32(body, 10)
+	body = append(body, 0x08, 0, 0, 0, 0, 0, 0, 0, 0, 0)
+	if tiff != nil {
+		body = append(body, "EXIF"...)
+		body = binary.LittleEndian.AppendUint32(body, uint32(len(tiff)))
+		body = append(body, tiff...)
+		if len(tiff)%2 == 1 {
+			body = append(body, 0)
+		}
+	}
+	content := append([]byte("RIFF"), binary.LittleEndian.AppendUint32(nil, uint32(len(body)))...)
+	return append(content, body...)
+}
+
+// jakartaGPS is 6°12'30"S 106°50'45"E.
+func jakartaGPS(order binary.ByteOrder) []ifdEntry {
+	return []ifdEntry{
+		asciiEntry(exifTagGPSLatitudeRef, "S"),
+		rationalEntry(order, exifTagGPSLatitude, 6, 1, 12, 1, 3000, 100),
+		asciiEntry(exifTagGPSLongitudeRef, "E"),
+		rationalEntry(order, exifTagGPSLongitude, 106, 1, 50, 1, 45, 1),
+	}
+}
+
+func fullTIFF(order binary.ByteOrder) []byte {
+	return buildTIFF(order,
+		[]ifdEntry{asciiEntry(exifTagDateTime, "2024:03:05 12:00:00")},
+		[]ifdEntry{asciiEntry(exifTagDateTimeOriginal, "2024:03:05 09:30:00"), asciiEntry(exifTagOffsetOriginal, "+07:00")},
+		jakartaGPS(order))
+}
+
+func TestExtractMetadata(t *testing.T) {
+	le, be := binary.LittleEndian, binary.BigEndian
+	taken := time.Date(2024, time.March, 5, 9, 30, 0, 0, time.FixedZone("", 7*3600))
+	localTaken := time.Date(2024, time.March, 5, 12, 0, 0, 0, time.Local)
+	latitude, longitude := -(6 + 12.0/60 + 30.0/3600), 106+50.0/60+45.0/3600
+
+	// IFD0 announces three entries but ends after the first
+	truncated := fullTIFF(le)
+	truncated = truncated[:le.Uint32(truncated[4:])+2+12]
+
+	zeroDenominator := buildTIFF(le, nil, nil, []ifdEntry{
+		asciiEntry(exifTagGPSLatitudeRef, "N"),
+		rationalEntry(le, exifTagGPSLatitude, 6, 0, 12, 1, 30, 1),
+		asciiEntry(exifTagGPSLongitudeRef, "E"),
+		rationalEntry(le, exifTagGPSLongitude, 106, 1, 50, 1, 45, 1),
+	})
+	outOfRange := buildTIFF(le, nil, nil, []ifdEntry{
+		asciiEntry(exifTagGPSLatitudeRef, "N"),
+		rationalEntry(le, exifTagGPSLatitude, 200, 1, 0, 1, 0, 1),
+		asciiEntry(exifTagGPSLongitudeRef, "E"),
+		rationalEntry(le, exifTagGPSLongitude, 106, 1, 50, 1, 45, 1),
+	})
+	missingRef := buildTIFF(be, nil, nil, jakartaGPS(be)[1:])
+	valueOutside := buildTIFF(le, []ifdEntry{asciiEntry(exifTagDateTime, "2024:03:05 12:00:00")}, nil, nil)
+	// point the DateTime value of the first IFD0 entry past the end of the data
+	le.PutUint32(valueOutside[le.Uint32(valueOutside[4:])+2+8:], 0xFFFFFF00)
+	badIFD0 := fullTIFF(be)
+	be.PutUint32(badIFD0[4:], uint32(len(badIFD0)+100))
+	badGPSPointer := buildTIFF(le,
+		[]ifdEntry{asciiEntry(exifTagDateTime, "2024:03:05 12:00:00"), longEntry(le, exifTagGPSIFD, 0xFFFF)}, nil, nil)
+	unknownOrder := fullTIFF(le)
+	copy(unknownOrder, "XX")
+
+	tests := []struct {
+		name       string
+		content    []byte
+		capturedAt *time.Time
+		location   bool
+	}{
+		{"jpeg little endian", jpegWith(fullTIFF(le)), &taken, true},
+		{"jpeg big endian", jpegWith(fullTIFF(be)), &taken, true},
+		{"png little endian", pngWith(fullTIFF(le)), &taken, true},
+		{"webp big endian", webpWith(fullTIFF(be)), &taken, true},
+		{"ifd0 time without offset is local", pngWith(buildTIFF(be, []ifdEntry{asciiEntry(exifTagDateTime, "2024:03:05 12:00:00")}, nil, nil)), &localTaken, false},
+		{"gps only", webpWith(buildTIFF(le, nil, nil, jakartaGPS(le))), nil, true},
+		{"jpeg without exif", jpegWith(nil), nil, false},
+		{"png without exif", pngWith(nil), nil, false},
+		{"webp without exif", webpWith(nil), nil, false},
+		{"not an image", []byte("GIF89a and then some"), nil, false},
+		{"empty", nil, nil, false},
+		{"truncated directory", jpegWith(truncated), nil, false},
+		{"ifd0 out of range", jpegWith(badIFD0), nil, false},
+		{"unknown byte order", jpegWith(unknownOrder), nil, false},
+		{"gps directory out of range keeps the time", jpegWith(badGPSPointer), &localTaken, false},
+		{"value offset out of range", jpegWith(valueOutside), nil, false},
+		{"zero denominator", jpegWith(zeroDenominator), nil, false},
+		{"latitude out of range", jpegWith(outOfRange), nil, false},
+		{"latitude without reference", jpegWith(missingRef), nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractMetadata(tt.content)
+
+			switch {
+			case tt.capturedAt == nil && got.CapturedAt != nil:
+				t.Errorf("CapturedAt = %v, want none", got.CapturedAt)
+			case tt.capturedAt != nil && got.CapturedAt == nil:
+				t.Errorf("CapturedAt = none, want %v", tt.capturedAt)
+			case tt.capturedAt != nil && !got.CapturedAt.Equal(*tt.capturedAt):
+				t.Errorf("CapturedAt = %v, want %v", got.CapturedAt, tt.capturedAt)
+			}
+
+			if got.HasLocation() != tt.location {
+				t.Fatalf("HasLocation = %v, want %v", got.HasLocation(), tt.location)
+			}
+			if tt.location && (math.Abs(*got.Latitude-latitude) > 1e-9 || math.Abs(*got.Longitude-longitude) > 1e-9) {
+				t.Errorf("location = %f,%f, want %f,%f", *got.Latitude, *got.Longitude, latitude, longitude)
+			}
+		})
+	}
+}
+
+// TestExtractMetadataNeverPanics cuts the fixtures at every length and overwrites every byte,
+// the upload pipeline runs ExtractMetadata on whatever a client sends.
+func TestExtractMetadataNeverPanics(t *testing.T) {
+	for _, content := range hostileSeeds() {
+		for i := 0; i <= len(content); i++ {
+			ExtractMetadata(content[:i])
+		}
+		for i := range content {
+			for _, b := range []byte{0x00, 0xFF, 0x7F} {
+				corrupted := bytes.Clone(content)
+				corrupted[i] = b
+				ExtractMetadata(corrupted)
+			}
+		}
+	}
+}
+
+func FuzzExtractMetadata(f *testing.F) {
+	for _, seed := range hostileSeeds() {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, content []byte) {
+		ExtractMetadata(content)
+	})
+}
+
+func hostileSeeds() [][]byte {
+	le, be := binary.LittleEndian, binary.BigEndian
+	return [][]byte{
+		jpegWith(fullTIFF(le)),
+		jpegWith(fullTIFF(be)),
+		pngWith(fullTIFF(le)),
+		webpWith(fullTIFF(be)),
+	}
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"instructor-led-app/config"
+	"io"
+	"strings"
+)
+
+// FileStorage keeps uploaded files under keys. Get fails with an error wrapping
+// fs.ErrNotExist when nothing is stored under the key.
+type FileStorage interface {
+	Put(key string, body io.Reader, size int64, contentType string) error
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
+
+// NewFileStorage picks the backend from STORAGE_DRIVER, local disk when it's empty.
+func NewFileStorage(cfg config.StorageConfig) (FileStorage, error) {
+	switch cfg.StorageDriver {
+	case "", "local":
+		return NewLocalStorage(cfg.LocalDir), nil
+	case "s3":
+		return NewS3Storage(cfg)
+	default:
+		return nil, fmt.Errorf("unknown storage driver %s", cfg.StorageDriver)
+	}
+}
+
+// ContentKey builds a content-addressed key like "images/ab/ab12...ef.jpg" and returns it with the hex checksum.
+func ContentKey(prefix string, content []byte, ext string) (string, string) {
+	checksum := Checksum(content)
+	return fmt.Sprintf("%s/%s/%s%s", prefix, checksum[:2], checksum, strings.ToLower(ext)), checksum
+}
+
+// Checksum is the hex SHA-256 of content.
+func Checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	maxImagePixels   = 40_000_000
+	thumbnailMaxSide = 320
+)
+
+var allowedImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+}
+
+type ProcessedImage struct {
+	Content   []byte
+	MimeType  string
+	Extension string
+	Width     int
+	Height    int
+	Thumbnail []byte
+	Metadata  ImageMetadata
+}
+
+type ImageProcessor interface {
+	Process(content []byte) (ProcessedImage, error)
+}
+
+type imageProcessor struct{}
+
+// Process sniffs and fully decodes the upload, keeps the capture time and GPS
+// position, then re-encodes it so every metadata block (EXIF, GPS, comments)
+// is dropped, and renders a JPEG thumbnail.
+func (p *imageProcessor) Process(content []byte) (ProcessedImage, error) {
+	sniffed := http.DetectContentType(content)
+	if !allowedImageTypes[sniffed] {
+		return ProcessedImage{}, fmt.Errorf("file content is %s, not an allowed image", sniffed)
+	}
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return ProcessedImage{}, fmt.Errorf("file is not a valid image: %v", err)
+	}
+	if "image/"+format != sniffed {
+		return ProcessedImage{}, fmt.Errorf("image content doesn't match its type")
+	}
+	if cfg.Width*cfg.Height > maxImagePixels {
+		return ProcessedImage{}, fmt.Errorf("image dimension %dx%d is too large", cfg.Width, cfg.Height)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return ProcessedImage{}, fmt.Errorf("file is not a valid image: %v", err)
+	}
+
+	// there is no webp encoder, those are stored as png
+	var buf bytes.Buffer
+	processed := ProcessedImage{Width: cfg.Width, Height: cfg.Height, Metadata: ExtractMetadata(content)}
+	if format == "jpeg" {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90})
+		processed.MimeType, processed.Extension = "image/jpeg", ".jpg"
+	} else {
+		err = png.Encode(&buf, img)
+		processed.MimeType, processed.Extension = "image/png", ".png"
+	}
+	if err != nil {
+		return ProcessedImage{}, fmt.Errorf("failed to encode image: %v", err)
+	}
+	processed.Content = buf.Bytes()
+
+	processed.Thumbnail, err = thumbnail(img)
+	if err != nil {
+		return ProcessedImage{}, fmt.Errorf("failed to create thumbnail: %v", err)
+	}
+
+	return processed, nil
+}
+
+func thumbnail(img image.Image) ([]byte, error) {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > thumbnailMaxSide || height > thumbnailMaxSide {
+		if width >= height {
+			height = height * thumbnailMaxSide / width
+			width = thumbnailMaxSide
+		} else {
+			width = width * thumbnailMaxSide / height
+			height = thumbnailMaxSide
+		}
+	}
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func NewImageProcessor() ImageProcessor {
+	return &imageProcessor{}
+}
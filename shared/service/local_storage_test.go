@@ -0,0 +1,72 @@
+package service
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type failingReader struct{ sent bool }
+
+func (f *failingReader) Read(p []byte) (int, error) {
+	if f.sent {
+		return 0, errors.New("connection reset")
+	}
+	f.sent = true
+	return copy(p, "partial"), nil
+}
+
+func TestLocalStoragePut(t *testing.T) {
+	root := t.TempDir()
+	storage := NewLocalStorage(root)
+	key := "attachments/ab/abcdef.txt"
+
+	if err := storage.Put(key, strings.NewReader("first"), 5, "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := storage.Put(key, &failingReader{}, 20, "text/plain"); err == nil {
+		t.Fatal("Put of a failing body succeeded")
+	}
+	if got := read(t, storage, key); got != "first" {
+		t.Fatalf("after a failed Put the key holds %q, want %q", got, "first")
+	}
+
+	if err := storage.Put(key, strings.NewReader("second"), 6, "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if got := read(t, storage, key); got != "second" {
+		t.Fatalf("Get = %q, want %q", got, "second")
+	}
+
+	entries, err := os.ReadDir(filepath.Join(root, "attachments", "ab"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("the directory holds %d files, temp files were left behind", len(entries))
+	}
+}
+
+func TestLocalStorageRejectsEscapingKeys(t *testing.T) {
+	storage := NewLocalStorage(t.TempDir())
+	if err := storage.Put("../outside.txt", strings.NewReader("x"), 1, "text/plain"); err == nil {
+		t.Fatal("Put outside the root succeeded")
+	}
+}
+
+func read(t *testing.T, storage FileStorage, key string) string {
+	t.Helper()
+	body, err := storage.Get(key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	defer body.Close()
+	content, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"fmt"
+	"instructor-led-app/config"
+	"log"
+)
+
+type Mailer interface {
+	Send(to, subject, body string) error
+}
+
+// logMailer stands in for a real mail server by writing every message to the log.
+type logMailer struct {
+	from string
+}
+
+// Send implements Mailer.
+func (l *logMailer) Send(to, subject, body string) error {
+	log.Printf("mail from %s to %s: %s\n%s\n", l.from, to, subject, body)
+	return nil
+}
+
+func NewLogMailer(from string) Mailer {
+	return &logMailer{from: from}
+}
+
+func NewMailer(cfg config.MailConfig) (Mailer, error) {
+	switch cfg.MailDriver {
+	case "", "log":
+		return NewLogMailer(cfg.MailFrom), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver %s", cfg.MailDriver)
+	}
+}
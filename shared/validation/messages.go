@@ -0,0 +1,93 @@
+package validation
+
+import (
+	"strings"
+	"unicode"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+// enMessages and idMessages cover the custom rules and the built-in ones the default
+// translations miss or word with Go field names. {0} is the field, the rule's params follow.
+var enMessages = map[string]string{
+	"phone":            "{0} must be a phone number of 8 to 12 digits, optionally starting with +",
+	"date":             "{0} must use the YYYY-MM-DD format",
+	"clock":            "{0} must use the HH:MM format",
+	"required_if":      "{0} is required when {1} is {2}",
+	"required_without": "{0} is required when {1} is empty",
+	"excluded_with":    "{0} can't be combined with {1}",
+	"gtfield":          "{0} must be after {1}",
+}
+
+var idMessages = map[string]string{
+	"phone":            "{0} harus berupa nomor telepon 8 sampai 12 digit, boleh diawali +",
+	"date":             "{0} harus menggunakan format YYYY-MM-DD",
+	"clock":            "{0} harus menggunakan format HH:MM",
+	"required_if":      "{0} wajib diisi jika {1} bernilai {2}",
+	"required_without": "{0} wajib diisi jika {1} kosong",
+	"excluded_with":    "{0} tidak boleh diisi bersama {1}",
+	"gtfield":          "{0} harus setelah {1}",
+}
+
+var texts = map[string]map[string]string{
+	English: {
+		"validation_failed": "validation failed",
+		"type_mismatch":     "must be a {0}",
+		"invalid_body":      "invalid request body",
+	},
+	Indonesian: {
+		"validation_failed": "validasi gagal",
+		"type_mismatch":     "harus berupa {0}",
+		"invalid_body":      "isi permintaan tidak valid",
+	},
+}
+
+func text(lang, key string) string {
+	return texts[lang][key]
+}
+
+func registerMessages(v *validator.Validate, trans ut.Translator, messages map[string]string) error {
+	for tag, message := range messages {
+		tag, message := tag, message
+		err := v.RegisterTranslation(tag, trans, func(t ut.Translator) error {
+			return t.Add(tag, message, true)
+		}, func(t ut.Translator, fe validator.FieldError) string {
+			msg, err := t.T(tag, append([]string{fe.Field()}, messageParams(fe)...)...)
+			if err != nil {
+				return fe.Error()
+			}
+			return msg
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// messageParams names the fields a cross-field rule refers to the way clients know them.
+func messageParams(fe validator.FieldError) []string {
+	params := strings.Fields(fe.Param())
+	switch fe.Tag() {
+	case "required_if":
+		for i := 0; i < len(params); i += 2 {
+			params[i] = paramField(params[i])
+		}
+	case "required_without", "excluded_with", "gtfield":
+		for i := range params {
+			params[i] = paramField(params[i])
+		}
+	}
+	return params
+}
+
+// paramField turns a Go field name such as CohortID into its json name cohortId.
+func paramField(name string) string {
+	name = strings.Replace(name, "ID", "Id", 1)
+	runes := []rune(name)
+	if len(runes) > 0 {
+		runes[0] = unicode.ToLower(runes[0])
+	}
+	return string(runes)
+}
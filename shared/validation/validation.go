@@ -0,0 +1,151 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"instructor-led-app/shared/apperror"
+	"instructor-led-app/shared/model"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/id"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	idTranslations "github.com/go-playground/validator/v10/translations/id"
+)
+
+const (
+	English    = "en"
+	Indonesian = "id"
+)
+
+var (
+	phonePattern = regexp.MustCompile(`^\+?[0-9]{8,12}$`)
+	translators  *ut.UniversalTranslator
+)
+
+// Register adds the custom rules and the English and Indonesian messages to gin's validator.
+// It must run before the first request is bound.
+func Register() error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return errors.New("validation: unexpected binding engine")
+	}
+
+	v.RegisterTagNameFunc(fieldName)
+	rules := map[string]validator.Func{
+		"phone": func(fl validator.FieldLevel) bool { return phonePattern.MatchString(fl.Field().String()) },
+		"date":  layoutRule("2006-01-02"),
+		"clock": layoutRule("15:04"),
+	}
+	for tag, rule := range rules {
+		if err := v.RegisterValidation(tag, rule); err != nil {
+			return err
+		}
+	}
+
+	enLocale := en.New()
+	translators = ut.New(enLocale, enLocale, id.New())
+	enTrans, _ := translators.GetTranslator(English)
+	idTrans, _ := translators.GetTranslator(Indonesian)
+	if err := enTranslations.RegisterDefaultTranslations(v, enTrans); err != nil {
+		return err
+	}
+	if err := idTranslations.RegisterDefaultTranslations(v, idTrans); err != nil {
+		return err
+	}
+	if err := registerMessages(v, enTrans, enMessages); err != nil {
+		return err
+	}
+	return registerMessages(v, idTrans, idMessages)
+}
+
+// Translate turns a binding error into a validation error whose messages are in the language
+// preferred by acceptLanguage. Malformed bodies become bad requests.
+func Translate(err error, acceptLanguage string) error {
+	lang := Language(acceptLanguage)
+
+	var fieldErrs validator.ValidationErrors
+	if errors.As(err, &fieldErrs) && translators != nil {
+		trans, _ := translators.GetTranslator(lang)
+		fields := make([]model.FieldError, 0, len(fieldErrs))
+		for _, fe := range fieldErrs {
+			fields = append(fields, model.FieldError{Field: fieldPath(fe.Namespace()), Message: fe.Translate(trans)})
+		}
+		return apperror.Validation(text(lang, "validation_failed"), fields...)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return apperror.Validation(text(lang, "validation_failed"), model.FieldError{
+			Field:   typeErr.Field,
+			Message: strings.Replace(text(lang, "type_mismatch"), "{0}", typeErr.Type.String(), 1),
+		})
+	}
+
+	return apperror.BadRequest("%s: %s", text(lang, "invalid_body"), err.Error())
+}
+
+// Language picks English or Indonesian from an Accept-Language header, English being the default.
+func Language(acceptLanguage string) string {
+	type candidate struct {
+		lang    string
+		quality float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = parsed
+			}
+		}
+		primary, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		if primary == English || primary == Indonesian {
+			candidates = append(candidates, candidate{primary, quality})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].quality > candidates[j].quality })
+	if len(candidates) == 0 || candidates[0].quality == 0 {
+		return English
+	}
+	return candidates[0].lang
+}
+
+func layoutRule(layout string) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		_, err := time.Parse(layout, fl.Field().String())
+		return err == nil
+	}
+}
+
+// fieldName reports fields by the name clients send them with.
+func fieldName(field reflect.StructField) string {
+	for _, key := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// fieldPath drops the struct name validator puts in front of every namespace.
+func fieldPath(namespace string) string {
+	if _, path, ok := strings.Cut(namespace, "."); ok {
+		return path
+	}
+	return namespace
+}
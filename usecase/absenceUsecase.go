@@ -5,13 +5,16 @@ import (
 	"instructor-led-app/entity"
 	"instructor-led-app/entity/dto"
 	"instructor-led-app/repository"
+	"instructor-led-app/shared/apperror"
 	"instructor-led-app/shared/model"
+	"instructor-led-app/shared/query"
+	"instructor-led-app/shared/service"
 	"time"
 )
 
 type AbsenceUseCase interface {
 	InsertNewAbsence(name string) ([]dto.ParticipantScheduleDTO, error)
-	FindAllAbsence(startDate, endDate time.Time, page, size int) ([]entity.Absence, model.Paging, error)
+	FindAllAbsence(spec query.Spec) ([]entity.Absence, model.Paging, error)
 	GetAbsencesByParticipantID(id string) (entity.Absence, error)
 	GetAbsencesByScheduleID(id string) (dto.AbsenceDTO, error)
 	UpdateAbsencesByScheduleId(trainerId, participantId string, payload dto.AbsenceCheckDTO) (dto.AbsenceCheckDTO, error)
@@ -24,6 +27,8 @@ type absenceUseCase struct {
 	scheduleRepo    repository.ScheduleRepository
 	userRepo        repository.UserRepository
 	trainerRepo     repository.TrainerRepository
+	eventBroker     service.EventBroker
+	promotionUC     PromotionUseCase
 }
 
 // UpdateAbsencesByScheduleId implements AbsenceUseCase.
@@ -34,14 +39,14 @@ func (a *absenceUseCase) UpdateAbsencesByScheduleId(trainerId, participantId str
 	fmt.Print(trainerId)
 	fmt.Print(scheduleIDs)
 	if err != nil {
-		return dto.AbsenceCheckDTO{}, fmt.Errorf("Field can't be empty")
+		return dto.AbsenceCheckDTO{}, apperror.Wrap(err, "failed to get trainer schedules")
 	}
 	fmt.Println(scheduleIDs)
 	for _, scheduleId := range scheduleIDs {
 		date, err := a.scheduleRepo.GetDateByScheduleId(scheduleId)
 		if err != nil {
 			fmt.Println("error tidak dapat menemukan tanggal")
-			return dto.AbsenceCheckDTO{}, fmt.Errorf("Field can't be empty")
+			return dto.AbsenceCheckDTO{}, apperror.Wrap(err, "failed to get schedule date")
 		}
 		dates = append(dates, date)
 	}
@@ -61,6 +66,12 @@ func (a *absenceUseCase) UpdateAbsencesByScheduleId(trainerId, participantId str
 	payload.Absence_time = absenceTimeReal
 	schedule, _ := a.scheduleRepo.GetScheduleIdByDate(day)
 	data, err := a.repo.UpdateByScheduleIDandParticipantID(schedule, participantId, payload)
+	if err != nil {
+		return dto.AbsenceCheckDTO{}, apperror.Wrap(err, "failed to update absence")
+	}
+	a.eventBroker.Publish(schedule, service.EventAbsenceUpdated, data)
+	// taking attendance may be what makes the participant eligible for promotion
+	a.promotionUC.EvaluateParticipant(participantId)
 	return data, nil
 }
 
@@ -113,12 +124,8 @@ func (a *absenceUseCase) GetAbsencesByParticipantID(id string) (entity.Absence,
 }
 
 // FindAllTask implements AbsenceUseCase.
-func (a *absenceUseCase) FindAllAbsence(startDate time.Time, endDate time.Time, page int, size int) ([]entity.Absence, model.Paging, error) {
-	if !startDate.IsZero() && !endDate.IsZero() {
-		return a.repo.ListByDate(startDate, endDate, page, size)
-	} else {
-		return a.repo.List(page, size)
-	}
+func (a *absenceUseCase) FindAllAbsence(spec query.Spec) ([]entity.Absence, model.Paging, error) {
+	return a.repo.List(spec)
 }
 
 // InsertNewAbsence implements AbsenceUseCase.
@@ -142,7 +149,7 @@ func (a *absenceUseCase) InsertNewAbsence(name string) ([]dto.ParticipantSchedul
 	if err != nil {
 		// Handle error
 		fmt.Println("Error fetching participants:", err)
-		return nil, fmt.Errorf("oppps, failed to save data absence :%v", err.Error())
+		return nil, apperror.Wrap(err, "failed to save absence")
 	}
 
 	roleToDay := map[string]int{
@@ -173,11 +180,34 @@ func (a *absenceUseCase) InsertNewAbsence(name string) ([]dto.ParticipantSchedul
 
 	absence, err := a.repo.Create(userScheduleDto)
 	if err != nil {
-		return nil, fmt.Errorf("oppps, failed to save data absence :%v", err.Error())
+		return nil, apperror.Wrap(err, "failed to save absence")
 	}
+	a.publishCheckIns(absence)
 	return absence, nil
 }
 
-func NewAbsenceUseCase(repo repository.AbsenceRepository, participantRepo repository.ParticipantRepository, scheduleRepo repository.ScheduleRepository, userRepo repository.UserRepository, trainerRepo repository.TrainerRepository) AbsenceUseCase {
-	return &absenceUseCase{repo: repo, participantRepo: participantRepo, scheduleRepo: scheduleRepo, userRepo: userRepo, trainerRepo: trainerRepo}
+// publishCheckIns tells the listeners of every schedule which participants were checked in to it.
+func (a *absenceUseCase) publishCheckIns(absences []dto.ParticipantScheduleDTO) {
+	var scheduleIDs []string
+	bySchedule := map[string][]dto.ParticipantScheduleDTO{}
+	for _, absence := range absences {
+		for i, scheduleID := range absence.ScheduleID {
+			if _, ok := bySchedule[scheduleID]; !ok {
+				scheduleIDs = append(scheduleIDs, scheduleID)
+			}
+			bySchedule[scheduleID] = append(bySchedule[scheduleID], dto.ParticipantScheduleDTO{
+				ID:         absence.ID,
+				TrainerID:  absence.TrainerID,
+				Date:       []time.Time{absence.Date[i]},
+				ScheduleID: []string{scheduleID},
+			})
+		}
+	}
+	for _, scheduleID := range scheduleIDs {
+		a.eventBroker.Publish(scheduleID, service.EventAbsenceCreated, bySchedule[scheduleID])
+	}
+}
+
+func NewAbsenceUseCase(repo repository.AbsenceRepository, participantRepo repository.ParticipantRepository, scheduleRepo repository.ScheduleRepository, userRepo repository.UserRepository, trainerRepo repository.TrainerRepository, eventBroker service.EventBroker, promotionUC PromotionUseCase) AbsenceUseCase {
+	return &absenceUseCase{repo: repo, participantRepo: participantRepo, scheduleRepo: scheduleRepo, userRepo: userRepo, trainerRepo: trainerRepo, eventBroker: eventBroker, promotionUC: promotionUC}
 }
@@ -0,0 +1,341 @@
+package usecase
+
+import (
+	"instructor-led-app/entity"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/repository"
+	"instructor-led-app/shared/apperror"
+	"strings"
+	"time"
+)
+
+const (
+	questionMultipleChoice = "multiple_choice"
+	questionShortAnswer    = "short_answer"
+)
+
+type AssessmentUseCase interface {
+	CreateAssessment(payload dto.AssessmentDTO, userID string) (entity.Assessment, error)
+	FindAssessments(scheduleID, userID, role string) ([]entity.Assessment, error)
+	FindAssessment(id, userID, role string) (entity.Assessment, error)
+	DeleteAssessment(id, userID, role string) error
+	SubmitAttempt(id, userID string, payload dto.AssessmentSubmissionDTO) (entity.AssessmentAttempt, error)
+	FindAttempts(id, userID, role string) ([]entity.AssessmentAttempt, error)
+	FindAttempt(attemptID, userID, role string) (entity.AssessmentAttempt, error)
+	FindOwnResults(userID string) ([]entity.AssessmentAttempt, error)
+	GradeAnswer(attemptID, answerID, userID, role string, payload dto.GradeAnswerDTO) (entity.AssessmentAttempt, error)
+}
+
+type assessmentUseCase struct {
+	repo               repository.AssessmentRepository
+	scheduleUseCase    ScheduleUseCase
+	trainerUseCase     TrainerUsecase
+	participantUseCase ParticipantUseCase
+	promotionUC        PromotionUseCase
+}
+
+func buildQuestions(payload []dto.AssessmentQuestionDTO) ([]entity.AssessmentQuestion, error) {
+	if len(payload) == 0 {
+		return nil, apperror.Invalid("questions", "at least one question is required")
+	}
+	questions := make([]entity.AssessmentQuestion, 0, len(payload))
+	for i, item := range payload {
+		number := i + 1
+		item.Prompt = strings.TrimSpace(item.Prompt)
+		if item.Prompt == "" {
+			return nil, apperror.Invalid("questions", "question %d: prompt is required", number)
+		}
+		if item.Points < 0 {
+			return nil, apperror.Invalid("questions", "question %d: points can't be negative", number)
+		}
+		if item.Points == 0 {
+			item.Points = 1
+		}
+
+		switch item.Kind {
+		case questionMultipleChoice:
+			if len(item.Options) < 2 {
+				return nil, apperror.Invalid("questions", "question %d: a multiple choice question needs at least two options", number)
+			}
+			if item.CorrectOption == nil || *item.CorrectOption < 0 || *item.CorrectOption >= len(item.Options) {
+				return nil, apperror.Invalid("questions", "question %d: correctOption must point at one of the options", number)
+			}
+		case questionShortAnswer:
+			item.Options, item.CorrectOption = nil, nil
+		default:
+			return nil, apperror.Invalid("questions", "question %d: kind must be %s or %s", number, questionMultipleChoice, questionShortAnswer)
+		}
+
+		questions = append(questions, entity.AssessmentQuestion{
+			Position:      number,
+			Kind:          item.Kind,
+			Prompt:        item.Prompt,
+			Options:       item.Options,
+			CorrectOption: item.CorrectOption,
+			Points:        item.Points,
+		})
+	}
+	return questions, nil
+}
+
+// CreateAssessment implements AssessmentUseCase. Only the trainer of the schedule can add a quiz to it.
+func (a *assessmentUseCase) CreateAssessment(payload dto.AssessmentDTO, userID string) (entity.Assessment, error) {
+	payload.Title = strings.TrimSpace(payload.Title)
+	if payload.ScheduleID == "" || payload.Title == "" {
+		return entity.Assessment{}, apperror.BadRequest("scheduleId and title are required")
+	}
+	if payload.OpensAt.IsZero() || !payload.ClosesAt.After(payload.OpensAt) {
+		return entity.Assessment{}, apperror.Invalid("closesAt", "closesAt must be after opensAt")
+	}
+
+	schedule, err := a.scheduleUseCase.FindScheduleForUser(payload.ScheduleID, userID, "trainer")
+	if err != nil {
+		return entity.Assessment{}, err
+	}
+	questions, err := buildQuestions(payload.Questions)
+	if err != nil {
+		return entity.Assessment{}, err
+	}
+
+	assessment, err := a.repo.Create(entity.Assessment{
+		ScheduleID:  schedule.ID,
+		TrainerID:   schedule.TrainerID,
+		Title:       payload.Title,
+		Description: payload.Description,
+		OpensAt:     payload.OpensAt,
+		ClosesAt:    payload.ClosesAt,
+		Questions:   questions,
+	})
+	if err != nil {
+		return entity.Assessment{}, apperror.Wrap(err, "failed to save assessment")
+	}
+	return assessment, nil
+}
+
+// FindAssessments implements AssessmentUseCase.
+func (a *assessmentUseCase) FindAssessments(scheduleID, userID, role string) ([]entity.Assessment, error) {
+	if scheduleID == "" {
+		return nil, apperror.Invalid("scheduleId", "scheduleId is required")
+	}
+	if _, err := a.scheduleUseCase.FindScheduleForUser(scheduleID, userID, role); err != nil {
+		return nil, err
+	}
+	return a.repo.List(scheduleID)
+}
+
+// FindAssessment implements AssessmentUseCase. Participants never see the answer key.
+func (a *assessmentUseCase) FindAssessment(id, userID, role string) (entity.Assessment, error) {
+	assessment, err := a.repo.Get(id)
+	if err != nil {
+		return entity.Assessment{}, apperror.Lookup(err, "assessment not found")
+	}
+	if _, err := a.scheduleUseCase.FindScheduleForUser(assessment.ScheduleID, userID, role); err != nil {
+		return entity.Assessment{}, err
+	}
+	if role == "participant" {
+		for i := range assessment.Questions {
+			assessment.Questions[i].CorrectOption = nil
+		}
+	}
+	return assessment, nil
+}
+
+// manageable returns the assessment when the user is an admin or the trainer who owns it.
+func (a *assessmentUseCase) manageable(id, userID, role string) (entity.Assessment, error) {
+	assessment, err := a.repo.Get(id)
+	if err != nil {
+		return entity.Assessment{}, apperror.Lookup(err, "assessment not found")
+	}
+	if role == "admin" {
+		return assessment, nil
+	}
+	trainer, err := a.trainerUseCase.FindTrainerByUserId(userID)
+	if err != nil || trainer.ID != assessment.TrainerID {
+		return entity.Assessment{}, apperror.Forbidden("you don't have access to assessment %s", id)
+	}
+	return assessment, nil
+}
+
+// DeleteAssessment implements AssessmentUseCase. Assessments that were already taken are kept.
+func (a *assessmentUseCase) DeleteAssessment(id, userID, role string) error {
+	if _, err := a.manageable(id, userID, role); err != nil {
+		return err
+	}
+	total, err := a.repo.CountAttempts(id)
+	if err != nil {
+		return apperror.Wrap(err, "failed to check attempts")
+	}
+	if total > 0 {
+		return apperror.Conflict("assessment already has %d attempt(s)", total)
+	}
+	return a.repo.Delete(id)
+}
+
+// SubmitAttempt implements AssessmentUseCase. Multiple choice answers are graded right away;
+// short answers wait for the trainer unless they were left empty.
+func (a *assessmentUseCase) SubmitAttempt(id, userID string, payload dto.AssessmentSubmissionDTO) (entity.AssessmentAttempt, error) {
+	assessment, err := a.FindAssessment(id, userID, "participant")
+	if err != nil {
+		return entity.AssessmentAttempt{}, err
+	}
+	now := time.Now()
+	if now.Before(assessment.OpensAt) || now.After(assessment.ClosesAt) {
+		return entity.AssessmentAttempt{}, apperror.BadRequest("assessment is open from %s until %s", assessment.OpensAt.Format(time.RFC3339), assessment.ClosesAt.Format(time.RFC3339))
+	}
+
+	participant, err := a.participantUseCase.GetParticipantByUserId(userID)
+	if err != nil {
+		return entity.AssessmentAttempt{}, apperror.Lookup(err, "participant not found")
+	}
+	if taken, err := a.repo.HasAttempt(id, participant.ID); err != nil {
+		return entity.AssessmentAttempt{}, apperror.Wrap(err, "failed to check attempts")
+	} else if taken {
+		return entity.AssessmentAttempt{}, apperror.Conflict("you already submitted this assessment")
+	}
+
+	// the answer key is stripped for participants, so grade against the stored questions
+	stored, err := a.repo.Get(id)
+	if err != nil {
+		return entity.AssessmentAttempt{}, apperror.Lookup(err, "assessment not found")
+	}
+	given := make(map[string]dto.AssessmentAnswerDTO, len(payload.Answers))
+	for _, answer := range payload.Answers {
+		given[answer.QuestionID] = answer
+	}
+
+	attempt := entity.AssessmentAttempt{
+		AssessmentID:  id,
+		ParticipantID: participant.ID,
+		Status:        "Graded",
+		SubmittedAt:   now,
+	}
+	var score float64
+	for _, question := range stored.Questions {
+		attempt.MaxScore += question.Points
+		answer := entity.AssessmentAnswer{QuestionID: question.ID}
+		submitted, ok := given[question.ID]
+		delete(given, question.ID)
+
+		points := 0.0
+		switch question.Kind {
+		case questionMultipleChoice:
+			if ok && submitted.SelectedOption != nil {
+				if *submitted.SelectedOption < 0 || *submitted.SelectedOption >= len(question.Options) {
+					return entity.AssessmentAttempt{}, apperror.Invalid("answers", "question %d: selectedOption is out of range", question.Position)
+				}
+				answer.SelectedOption = submitted.SelectedOption
+				if question.CorrectOption != nil && *submitted.SelectedOption == *question.CorrectOption {
+					points = question.Points
+				}
+			}
+		case questionShortAnswer:
+			answer.AnswerText = strings.TrimSpace(submitted.AnswerText)
+		}
+
+		if question.Kind == questionShortAnswer && answer.AnswerText != "" {
+			attempt.Status = "Submitted"
+		} else {
+			answer.Points, answer.GradedAt = &points, &now
+			score += points
+		}
+		attempt.Answers = append(attempt.Answers, answer)
+	}
+	if len(given) > 0 {
+		return entity.AssessmentAttempt{}, apperror.Invalid("answers", "answers contain a question that is not part of this assessment")
+	}
+	attempt.Score = &score
+
+	attempt, err = a.repo.CreateAttempt(attempt)
+	if err != nil {
+		return entity.AssessmentAttempt{}, apperror.Wrap(err, "failed to save attempt")
+	}
+	if attempt.Status == "Graded" {
+		a.promotionUC.EvaluateParticipant(participant.ID)
+	}
+	return attempt, nil
+}
+
+// FindAttempts implements AssessmentUseCase.
+func (a *assessmentUseCase) FindAttempts(id, userID, role string) ([]entity.AssessmentAttempt, error) {
+	if _, err := a.manageable(id, userID, role); err != nil {
+		return nil, err
+	}
+	return a.repo.ListAttempts(id)
+}
+
+// FindAttempt implements AssessmentUseCase. Participants can only open their own attempt.
+func (a *assessmentUseCase) FindAttempt(attemptID, userID, role string) (entity.AssessmentAttempt, error) {
+	attempt, err := a.repo.GetAttempt(attemptID)
+	if err != nil {
+		return entity.AssessmentAttempt{}, apperror.Lookup(err, "attempt not found")
+	}
+	if role == "participant" {
+		participant, err := a.participantUseCase.GetParticipantByUserId(userID)
+		if err != nil || participant.ID != attempt.ParticipantID {
+			return entity.AssessmentAttempt{}, apperror.Forbidden("you don't have access to attempt %s", attemptID)
+		}
+		return attempt, nil
+	}
+	if _, err := a.manageable(attempt.AssessmentID, userID, role); err != nil {
+		return entity.AssessmentAttempt{}, err
+	}
+	return attempt, nil
+}
+
+// FindOwnResults implements AssessmentUseCase.
+func (a *assessmentUseCase) FindOwnResults(userID string) ([]entity.AssessmentAttempt, error) {
+	participant, err := a.participantUseCase.GetParticipantByUserId(userID)
+	if err != nil {
+		return nil, apperror.Lookup(err, "participant not found")
+	}
+	return a.repo.ListAttemptsByParticipant(participant.ID)
+}
+
+// GradeAnswer implements AssessmentUseCase. Any answer can be (re)graded, up to the question's points.
+func (a *assessmentUseCase) GradeAnswer(attemptID, answerID, userID, role string, payload dto.GradeAnswerDTO) (entity.AssessmentAttempt, error) {
+	attempt, err := a.FindAttempt(attemptID, userID, role)
+	if err != nil {
+		return entity.AssessmentAttempt{}, err
+	}
+	assessment, err := a.repo.Get(attempt.AssessmentID)
+	if err != nil {
+		return entity.AssessmentAttempt{}, apperror.Lookup(err, "assessment not found")
+	}
+
+	var questionID string
+	for _, answer := range attempt.Answers {
+		if answer.ID == answerID {
+			questionID = answer.QuestionID
+		}
+	}
+	var maxPoints float64 = -1
+	for _, question := range assessment.Questions {
+		if question.ID == questionID {
+			maxPoints = question.Points
+		}
+	}
+	if maxPoints < 0 {
+		return entity.AssessmentAttempt{}, apperror.NotFound("answer not found")
+	}
+	if payload.Points < 0 || payload.Points > maxPoints {
+		return entity.AssessmentAttempt{}, apperror.Invalid("points", "points must be between 0 and %g", maxPoints)
+	}
+
+	points := payload.Points
+	if err := a.repo.GradeAnswer(attemptID, answerID, entity.AssessmentAnswer{Points: &points, Feedback: strings.TrimSpace(payload.Feedback)}, userID); err != nil {
+		return entity.AssessmentAttempt{}, apperror.Wrap(err, "failed to grade answer")
+	}
+
+	attempt, err = a.repo.GetAttempt(attemptID)
+	if err != nil {
+		return entity.AssessmentAttempt{}, apperror.Lookup(err, "attempt not found")
+	}
+	if attempt.Status == "Graded" {
+		a.promotionUC.EvaluateParticipant(attempt.ParticipantID)
+	}
+	return attempt, nil
+}
+
+func NewAssessmentUseCase(repo repository.AssessmentRepository, scheduleUseCase ScheduleUseCase, trainerUseCase TrainerUsecase, participantUseCase ParticipantUseCase, promotionUC PromotionUseCase) AssessmentUseCase {
+	return &assessmentUseCase{repo: repo, scheduleUseCase: scheduleUseCase, trainerUseCase: trainerUseCase, participantUseCase: participantUseCase, promotionUC: promotionUC}
+}
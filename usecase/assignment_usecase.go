@@ -0,0 +1,417 @@
+package usecase
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"instructor-led-app/entity"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/repository"
+	"instructor-led-app/shared/apperror"
+	"instructor-led-app/shared/service"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type AssignmentUseCase interface {
+	CreateAssignment(payload dto.AssignmentDTO, userID, role string) (entity.Assignment, error)
+	FindAssignments(scheduleID, cohortID, userID, role string) ([]entity.Assignment, error)
+	FindOwnAssignments(userID string) ([]entity.Assignment, error)
+	FindAssignment(id, userID, role string) (entity.Assignment, error)
+	DeleteAssignment(id, userID, role string) error
+	Submit(id, userID, text string, file *dto.UploadFileDTO) (entity.AssignmentSubmission, error)
+	FindSubmissions(id, userID, role string) ([]entity.AssignmentSubmission, error)
+	FindSubmission(id, userID, role string) (entity.AssignmentSubmission, error)
+	OpenSubmissionFile(id, userID, role string) (io.ReadCloser, entity.AssignmentSubmission, error)
+	GradeSubmission(id, userID, role string, payload dto.GradeSubmissionDTO) (entity.AssignmentSubmission, error)
+	Gradebook(cohortID, userID, role string) (dto.GradebookDTO, error)
+}
+
+type assignmentUseCase struct {
+	repo               repository.AssignmentRepository
+	cohortRepo         repository.CohortRepository
+	scheduleUseCase    ScheduleUseCase
+	trainerUseCase     TrainerUsecase
+	participantUseCase ParticipantUseCase
+	storage            service.FileStorage
+}
+
+// CreateAssignment implements AssignmentUseCase. Session work belongs to the session's trainer;
+// cohort work to the trainer creating it, or the trainer an admin names.
+func (a *assignmentUseCase) CreateAssignment(payload dto.AssignmentDTO, userID, role string) (entity.Assignment, error) {
+	payload.Title = strings.TrimSpace(payload.Title)
+	if payload.Title == "" {
+		return entity.Assignment{}, apperror.Invalid("title", "title is required")
+	}
+	if (payload.ScheduleID == "") == (payload.CohortID == "") {
+		return entity.Assignment{}, apperror.BadRequest("either scheduleId or cohortId is required")
+	}
+	if !payload.DueAt.After(time.Now()) {
+		return entity.Assignment{}, apperror.Invalid("dueAt", "dueAt must be in the future")
+	}
+	if len(payload.Rubric) == 0 {
+		return entity.Assignment{}, apperror.Invalid("rubric", "at least one rubric criterion is required")
+	}
+
+	assignment := entity.Assignment{
+		ScheduleID:   payload.ScheduleID,
+		CohortID:     payload.CohortID,
+		Title:        payload.Title,
+		Instructions: payload.Instructions,
+		DueAt:        payload.DueAt,
+	}
+	for i, item := range payload.Rubric {
+		item.Name = strings.TrimSpace(item.Name)
+		if item.Name == "" || item.MaxPoints <= 0 {
+			return entity.Assignment{}, apperror.Invalid("rubric", "rubric criterion %d needs a name and positive maxPoints", i+1)
+		}
+		assignment.Rubric = append(assignment.Rubric, entity.RubricCriterion{Position: i + 1, Name: item.Name, MaxPoints: item.MaxPoints})
+		assignment.MaxScore += item.MaxPoints
+	}
+
+	if payload.ScheduleID != "" {
+		schedule, err := a.scheduleUseCase.FindScheduleForUser(payload.ScheduleID, userID, role)
+		if err != nil {
+			return entity.Assignment{}, err
+		}
+		assignment.TrainerID = schedule.TrainerID
+	} else {
+		if _, err := a.cohortRepo.Get(payload.CohortID); err != nil {
+			return entity.Assignment{}, apperror.Lookup(err, "cohort with ID %s not found", payload.CohortID)
+		}
+		if role == "trainer" {
+			trainer, err := a.trainerUseCase.FindTrainerByUserId(userID)
+			if err != nil {
+				return entity.Assignment{}, apperror.Lookup(err, "trainer not found")
+			}
+			if teaching, _ := a.cohortRepo.IsTeaching(payload.CohortID, trainer.ID); !teaching {
+				return entity.Assignment{}, apperror.Forbidden("you don't teach cohort %s", payload.CohortID)
+			}
+			assignment.TrainerID = trainer.ID
+		} else {
+			if payload.TrainerID == "" {
+				return entity.Assignment{}, apperror.Invalid("trainerId", "trainerId is required")
+			}
+			if trainers, err := a.trainerUseCase.FindTrainerById(payload.TrainerID); err != nil || len(trainers) == 0 {
+				return entity.Assignment{}, apperror.Lookup(err, "trainer with ID %s not found", payload.TrainerID)
+			}
+			assignment.TrainerID = payload.TrainerID
+		}
+	}
+
+	created, err := a.repo.Create(assignment)
+	if err != nil {
+		return entity.Assignment{}, apperror.Wrap(err, "failed to save assignment")
+	}
+	created.MaxScore = assignment.MaxScore
+	return created, nil
+}
+
+// canView reports whether the user takes part in the session or cohort the assignment belongs to.
+func (a *assignmentUseCase) canView(assignment entity.Assignment, userID, role string) bool {
+	switch role {
+	case "admin":
+		return true
+	case "trainer":
+		trainer, err := a.trainerUseCase.FindTrainerByUserId(userID)
+		if err != nil {
+			return false
+		}
+		if trainer.ID == assignment.TrainerID {
+			return true
+		}
+		if assignment.CohortID != "" {
+			teaching, _ := a.cohortRepo.IsTeaching(assignment.CohortID, trainer.ID)
+			return teaching
+		}
+	case "participant":
+		if assignment.CohortID != "" {
+			participant, err := a.participantUseCase.GetParticipantByUserId(userID)
+			if err != nil {
+				return false
+			}
+			enrolled, _ := a.cohortRepo.IsEnrolled(assignment.CohortID, participant.ID)
+			return enrolled
+		}
+	default:
+		return false
+	}
+	_, err := a.scheduleUseCase.FindScheduleForUser(assignment.ScheduleID, userID, role)
+	return assignment.ScheduleID != "" && err == nil
+}
+
+// manageable returns the assignment when the user is an admin or the trainer who owns it.
+func (a *assignmentUseCase) manageable(id, userID, role string) (entity.Assignment, error) {
+	assignment, err := a.repo.Get(id)
+	if err != nil {
+		return entity.Assignment{}, apperror.Lookup(err, "assignment not found")
+	}
+	if role == "admin" {
+		return assignment, nil
+	}
+	trainer, err := a.trainerUseCase.FindTrainerByUserId(userID)
+	if err != nil || trainer.ID != assignment.TrainerID {
+		return entity.Assignment{}, apperror.Forbidden("you don't have access to assignment %s", id)
+	}
+	return assignment, nil
+}
+
+// FindAssignments implements AssignmentUseCase.
+func (a *assignmentUseCase) FindAssignments(scheduleID, cohortID, userID, role string) ([]entity.Assignment, error) {
+	if role != "admin" {
+		if scheduleID == "" && cohortID == "" {
+			return nil, apperror.BadRequest("scheduleId or cohortId is required")
+		}
+		if !a.canView(entity.Assignment{ScheduleID: scheduleID, CohortID: cohortID}, userID, role) {
+			return nil, apperror.Forbidden("you don't have access to these assignments")
+		}
+	}
+	return a.repo.List(scheduleID, cohortID)
+}
+
+// FindOwnAssignments implements AssignmentUseCase.
+func (a *assignmentUseCase) FindOwnAssignments(userID string) ([]entity.Assignment, error) {
+	participant, err := a.participantUseCase.GetParticipantByUserId(userID)
+	if err != nil {
+		return nil, apperror.Lookup(err, "participant not found")
+	}
+	return a.repo.ListByParticipant(participant.ID)
+}
+
+// FindAssignment implements AssignmentUseCase.
+func (a *assignmentUseCase) FindAssignment(id, userID, role string) (entity.Assignment, error) {
+	assignment, err := a.repo.Get(id)
+	if err != nil {
+		return entity.Assignment{}, apperror.Lookup(err, "assignment not found")
+	}
+	if !a.canView(assignment, userID, role) {
+		return entity.Assignment{}, apperror.Forbidden("you don't have access to assignment %s", id)
+	}
+	return assignment, nil
+}
+
+// DeleteAssignment implements AssignmentUseCase. Assignments with submissions are kept.
+func (a *assignmentUseCase) DeleteAssignment(id, userID, role string) error {
+	if _, err := a.manageable(id, userID, role); err != nil {
+		return err
+	}
+	total, err := a.repo.CountSubmissions(id)
+	if err != nil {
+		return apperror.Wrap(err, "failed to check submissions")
+	}
+	if total > 0 {
+		return apperror.Conflict("assignment already has %d submission(s)", total)
+	}
+	return a.repo.Delete(id)
+}
+
+// Submit implements AssignmentUseCase. Work handed in after the due date is accepted and flagged late;
+// it can be replaced until the trainer grades it.
+func (a *assignmentUseCase) Submit(id, userID, text string, file *dto.UploadFileDTO) (entity.AssignmentSubmission, error) {
+	assignment, err := a.FindAssignment(id, userID, "participant")
+	if err != nil {
+		return entity.AssignmentSubmission{}, err
+	}
+	text = strings.TrimSpace(text)
+	if text == "" && file == nil {
+		return entity.AssignmentSubmission{}, apperror.BadRequest("text or file is required")
+	}
+
+	participant, err := a.participantUseCase.GetParticipantByUserId(userID)
+	if err != nil {
+		return entity.AssignmentSubmission{}, apperror.Lookup(err, "participant not found")
+	}
+	if previous, err := a.repo.GetSubmissionOf(id, participant.ID); err == nil && previous.Status == "Graded" {
+		return entity.AssignmentSubmission{}, apperror.Conflict("your submission was already graded")
+	}
+
+	now := time.Now()
+	submission := entity.AssignmentSubmission{
+		AssignmentID:  id,
+		ParticipantID: participant.ID,
+		Text:          text,
+		IsLate:        now.After(assignment.DueAt),
+		SubmittedAt:   now,
+	}
+	if file != nil {
+		key, _ := service.ContentKey("assignments", file.Content, filepath.Ext(file.FileName))
+		if err := a.storage.Put(key, bytes.NewReader(file.Content), int64(len(file.Content)), file.ContentType); err != nil {
+			return entity.AssignmentSubmission{}, apperror.Wrap(err, "failed to store file")
+		}
+		submission.FileName, submission.StorageKey = file.FileName, key
+		submission.Size, submission.MimeType = int64(len(file.Content)), file.ContentType
+	}
+
+	saved, err := a.repo.Submit(submission)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.AssignmentSubmission{}, apperror.Conflict("your submission was already graded")
+	}
+	if err != nil {
+		return entity.AssignmentSubmission{}, apperror.Wrap(err, "failed to save submission")
+	}
+	return saved, nil
+}
+
+// FindSubmissions implements AssignmentUseCase.
+func (a *assignmentUseCase) FindSubmissions(id, userID, role string) ([]entity.AssignmentSubmission, error) {
+	if _, err := a.manageable(id, userID, role); err != nil {
+		return nil, err
+	}
+	return a.repo.ListSubmissions(id)
+}
+
+// FindSubmission implements AssignmentUseCase. Participants can only open their own work.
+func (a *assignmentUseCase) FindSubmission(id, userID, role string) (entity.AssignmentSubmission, error) {
+	submission, err := a.repo.GetSubmission(id)
+	if err != nil {
+		return entity.AssignmentSubmission{}, apperror.Lookup(err, "submission not found")
+	}
+	if role == "participant" {
+		participant, err := a.participantUseCase.GetParticipantByUserId(userID)
+		if err != nil || participant.ID != submission.ParticipantID {
+			return entity.AssignmentSubmission{}, apperror.Forbidden("you don't have access to submission %s", id)
+		}
+		return submission, nil
+	}
+	if _, err := a.manageable(submission.AssignmentID, userID, role); err != nil {
+		return entity.AssignmentSubmission{}, err
+	}
+	return submission, nil
+}
+
+// OpenSubmissionFile implements AssignmentUseCase.
+func (a *assignmentUseCase) OpenSubmissionFile(id, userID, role string) (io.ReadCloser, entity.AssignmentSubmission, error) {
+	submission, err := a.FindSubmission(id, userID, role)
+	if err != nil {
+		return nil, entity.AssignmentSubmission{}, err
+	}
+	if submission.StorageKey == "" {
+		return nil, entity.AssignmentSubmission{}, apperror.NotFound("submission has no file")
+	}
+	body, err := a.storage.Get(submission.StorageKey)
+	if err != nil {
+		return nil, entity.AssignmentSubmission{}, apperror.Lookup(err, "file not found")
+	}
+	return body, submission, nil
+}
+
+// GradeSubmission implements AssignmentUseCase. Every rubric criterion must be scored once.
+func (a *assignmentUseCase) GradeSubmission(id, userID, role string, payload dto.GradeSubmissionDTO) (entity.AssignmentSubmission, error) {
+	submission, err := a.FindSubmission(id, userID, role)
+	if err != nil {
+		return entity.AssignmentSubmission{}, err
+	}
+	if role == "participant" {
+		return entity.AssignmentSubmission{}, apperror.Forbidden("participants can't grade submissions")
+	}
+	assignment, err := a.repo.Get(submission.AssignmentID)
+	if err != nil {
+		return entity.AssignmentSubmission{}, apperror.Lookup(err, "assignment not found")
+	}
+
+	criteria := make(map[string]entity.RubricCriterion, len(assignment.Rubric))
+	for _, criterion := range assignment.Rubric {
+		criteria[criterion.ID] = criterion
+	}
+	var score float64
+	for _, item := range payload.Scores {
+		criterion, ok := criteria[item.CriterionID]
+		if !ok {
+			return entity.AssignmentSubmission{}, apperror.Invalid("scores", "criterion %s is not part of this rubric or was scored twice", item.CriterionID)
+		}
+		if item.Points < 0 || item.Points > criterion.MaxPoints {
+			return entity.AssignmentSubmission{}, apperror.Invalid("scores", "%s: points must be between 0 and %g", criterion.Name, criterion.MaxPoints)
+		}
+		delete(criteria, item.CriterionID)
+		score += item.Points
+	}
+	if len(criteria) > 0 {
+		return entity.AssignmentSubmission{}, apperror.Invalid("scores", "every rubric criterion must be scored")
+	}
+
+	payload.Feedback = strings.TrimSpace(payload.Feedback)
+	if err := a.repo.Grade(id, payload, score, userID, time.Now()); err != nil {
+		return entity.AssignmentSubmission{}, apperror.Wrap(err, "failed to grade submission")
+	}
+	return a.repo.GetSubmission(id)
+}
+
+// Gradebook implements AssignmentUseCase. It covers the cohort's own assignments and those of its sessions;
+// work past its due date without a submission counts as missing.
+func (a *assignmentUseCase) Gradebook(cohortID, userID, role string) (dto.GradebookDTO, error) {
+	if _, err := a.cohortRepo.Get(cohortID); err != nil {
+		return dto.GradebookDTO{}, apperror.Lookup(err, "cohort with ID %s not found", cohortID)
+	}
+	if role == "trainer" {
+		trainer, err := a.trainerUseCase.FindTrainerByUserId(userID)
+		if err != nil {
+			return dto.GradebookDTO{}, apperror.Lookup(err, "trainer not found")
+		}
+		if teaching, _ := a.cohortRepo.IsTeaching(cohortID, trainer.ID); !teaching {
+			return dto.GradebookDTO{}, apperror.Forbidden("you don't teach cohort %s", cohortID)
+		}
+	}
+
+	assignments, err := a.repo.ListByCohort(cohortID)
+	if err != nil {
+		return dto.GradebookDTO{}, apperror.Wrap(err, "failed to get assignments")
+	}
+	enrollments, err := a.cohortRepo.ListEnrollments(cohortID)
+	if err != nil {
+		return dto.GradebookDTO{}, apperror.Wrap(err, "failed to get enrollments")
+	}
+	submissions, err := a.repo.ListSubmissionsByCohort(cohortID)
+	if err != nil {
+		return dto.GradebookDTO{}, apperror.Wrap(err, "failed to get submissions")
+	}
+	byKey := make(map[string]entity.AssignmentSubmission, len(submissions))
+	for _, submission := range submissions {
+		byKey[submission.AssignmentID+"|"+submission.ParticipantID] = submission
+	}
+
+	gradebook := dto.GradebookDTO{CohortID: cohortID, Assignments: []dto.GradebookColumnDTO{}, Rows: []dto.GradebookRowDTO{}}
+	for _, assignment := range assignments {
+		gradebook.Assignments = append(gradebook.Assignments, dto.GradebookColumnDTO{
+			AssignmentID: assignment.ID,
+			Title:        assignment.Title,
+			DueAt:        assignment.DueAt,
+			MaxScore:     assignment.MaxScore,
+		})
+	}
+
+	now := time.Now()
+	for _, enrollment := range enrollments {
+		row := dto.GradebookRowDTO{ParticipantID: enrollment.ParticipantID, Name: enrollment.Name}
+		var total float64
+		var graded int
+		for _, assignment := range assignments {
+			entry := dto.GradebookEntryDTO{AssignmentID: assignment.ID, Status: "Pending"}
+			if submission, ok := byKey[assignment.ID+"|"+enrollment.ParticipantID]; ok {
+				entry.Status, entry.Score, entry.IsLate = submission.Status, submission.Score, submission.IsLate
+				if submission.IsLate {
+					row.Late++
+				}
+				if submission.Score != nil && assignment.MaxScore > 0 {
+					total += *submission.Score / assignment.MaxScore * 100
+					graded++
+				}
+			} else if now.After(assignment.DueAt) {
+				entry.Status = "Missing"
+				row.Missing++
+			}
+			row.Grades = append(row.Grades, entry)
+		}
+		if graded > 0 {
+			average := roundTo(total/float64(graded), 2)
+			row.Average = &average
+		}
+		gradebook.Rows = append(gradebook.Rows, row)
+	}
+	return gradebook, nil
+}
+
+func NewAssignmentUseCase(repo repository.AssignmentRepository, cohortRepo repository.CohortRepository, scheduleUseCase ScheduleUseCase, trainerUseCase TrainerUsecase, participantUseCase ParticipantUseCase, storage service.FileStorage) AssignmentUseCase {
+	return &assignmentUseCase{repo: repo, cohortRepo: cohortRepo, scheduleUseCase: scheduleUseCase, trainerUseCase: trainerUseCase, participantUseCase: participantUseCase, storage: storage}
+}
@@ -0,0 +1,379 @@
+package usecase
+
+import (
+	"fmt"
+	"instructor-led-app/config"
+	"instructor-led-app/entity"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/repository"
+	"instructor-led-app/shared/apperror"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	assignmentReassign   = "reassign"
+	assignmentUnresolved = "unresolved"
+)
+
+type AvailabilityUseCase interface {
+	FindSlots(trainerId, userID, role string) ([]entity.TrainerAvailability, error)
+	AddSlot(payload dto.AvailabilitySlotDTO, userID, role string) (entity.TrainerAvailability, error)
+	DeleteSlot(id, userID, role string) error
+	FindTimeOffs(trainerId, userID, role string) ([]entity.TrainerTimeOff, error)
+	AddTimeOff(payload dto.TimeOffDTO, userID, role string) (entity.TrainerTimeOff, error)
+	DeleteTimeOff(id, userID, role string) error
+	CheckTrainerAvailable(trainerId string, dates []time.Time) error
+	AutoAssign(payload dto.AutoAssignRequestDTO, commit bool) (dto.AutoAssignResultDTO, error)
+}
+
+type availabilityUseCase struct {
+	repo           repository.AvailabilityRepository
+	scheduleRepo   repository.ScheduleRepository
+	trainerUseCase TrainerUsecase
+	sessionConfig  config.SessionConfig
+}
+
+// availabilityCalendar answers whether a trainer can run the session on a given date.
+// Trainers who never published a slot are only bound by their time off.
+type availabilityCalendar struct {
+	trainers     []string
+	slots        map[string][]entity.TrainerAvailability
+	timeOffs     map[string][]entity.TrainerTimeOff
+	sessionStart string
+	sessionEnd   string
+}
+
+func (c availabilityCalendar) unavailableReason(trainerId string, date time.Time) string {
+	for _, timeOff := range c.timeOffs[trainerId] {
+		if !date.Before(dateOnly(timeOff.StartDate)) && !date.After(dateOnly(timeOff.EndDate)) {
+			return "trainer is on time off"
+		}
+	}
+	slots, published := c.slots[trainerId]
+	if !published {
+		return ""
+	}
+	for _, slot := range slots {
+		if slot.DayOfWeek == int(date.Weekday()) && slot.StartTime <= c.sessionStart && slot.EndTime >= c.sessionEnd {
+			return ""
+		}
+	}
+	return fmt.Sprintf("trainer has no availability on %s %s-%s", date.Weekday(), c.sessionStart, c.sessionEnd)
+}
+
+// FindSlots implements AvailabilityUseCase.
+func (a *availabilityUseCase) FindSlots(trainerId, userID, role string) ([]entity.TrainerAvailability, error) {
+	trainerId, err := a.resolveTrainer(trainerId, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	return a.repo.ListSlots(trainerId)
+}
+
+// AddSlot implements AvailabilityUseCase.
+func (a *availabilityUseCase) AddSlot(payload dto.AvailabilitySlotDTO, userID, role string) (entity.TrainerAvailability, error) {
+	trainerId, err := a.resolveTrainer(payload.TrainerID, userID, role)
+	if err != nil {
+		return entity.TrainerAvailability{}, err
+	}
+	if trainerId == "" {
+		return entity.TrainerAvailability{}, apperror.Invalid("trainerId", "trainerId is required")
+	}
+	payload.TrainerID = trainerId
+
+	start, err := time.Parse("15:04", payload.StartTime)
+	if err != nil {
+		return entity.TrainerAvailability{}, apperror.Invalid("startTime", "startTime must use HH:MM format")
+	}
+	end, err := time.Parse("15:04", payload.EndTime)
+	if err != nil {
+		return entity.TrainerAvailability{}, apperror.Invalid("endTime", "endTime must use HH:MM format")
+	}
+	if !start.Before(end) {
+		return entity.TrainerAvailability{}, apperror.Invalid("startTime", "startTime must be before endTime")
+	}
+	payload.StartTime, payload.EndTime = start.Format("15:04"), end.Format("15:04")
+
+	slot, err := a.repo.CreateSlot(payload)
+	if err != nil {
+		return entity.TrainerAvailability{}, apperror.Wrap(err, "failed to save availability")
+	}
+	return slot, nil
+}
+
+// DeleteSlot implements AvailabilityUseCase.
+func (a *availabilityUseCase) DeleteSlot(id, userID, role string) error {
+	slot, err := a.repo.GetSlot(id)
+	if err != nil {
+		return apperror.Lookup(err, "availability with ID %s not found", id)
+	}
+	if err := a.checkOwner(slot.TrainerID, userID, role); err != nil {
+		return err
+	}
+	return a.repo.DeleteSlot(id)
+}
+
+// FindTimeOffs implements AvailabilityUseCase. Time-off that already ended is left out.
+func (a *availabilityUseCase) FindTimeOffs(trainerId, userID, role string) ([]entity.TrainerTimeOff, error) {
+	trainerId, err := a.resolveTrainer(trainerId, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	return a.repo.ListTimeOffs(trainerId, dateOnly(time.Now()))
+}
+
+// AddTimeOff implements AvailabilityUseCase.
+func (a *availabilityUseCase) AddTimeOff(payload dto.TimeOffDTO, userID, role string) (entity.TrainerTimeOff, error) {
+	trainerId, err := a.resolveTrainer(payload.TrainerID, userID, role)
+	if err != nil {
+		return entity.TrainerTimeOff{}, err
+	}
+	if trainerId == "" {
+		return entity.TrainerTimeOff{}, apperror.Invalid("trainerId", "trainerId is required")
+	}
+
+	startDate, endDate, err := parseDateRange(payload.StartDate, payload.EndDate)
+	if err != nil {
+		return entity.TrainerTimeOff{}, err
+	}
+	if startDate.IsZero() {
+		return entity.TrainerTimeOff{}, apperror.Invalid("startDate", "startDate is required")
+	}
+	if endDate.IsZero() {
+		endDate = startDate
+	}
+
+	timeOff, err := a.repo.CreateTimeOff(trainerId, startDate, endDate, strings.TrimSpace(payload.Reason))
+	if err != nil {
+		return entity.TrainerTimeOff{}, apperror.Wrap(err, "failed to save time off")
+	}
+	return timeOff, nil
+}
+
+// DeleteTimeOff implements AvailabilityUseCase.
+func (a *availabilityUseCase) DeleteTimeOff(id, userID, role string) error {
+	timeOff, err := a.repo.GetTimeOff(id)
+	if err != nil {
+		return apperror.Lookup(err, "time off with ID %s not found", id)
+	}
+	if err := a.checkOwner(timeOff.TrainerID, userID, role); err != nil {
+		return err
+	}
+	return a.repo.DeleteTimeOff(id)
+}
+
+// CheckTrainerAvailable implements AvailabilityUseCase.
+func (a *availabilityUseCase) CheckTrainerAvailable(trainerId string, dates []time.Time) error {
+	calendar, err := a.calendar(trainerId, time.Time{})
+	if err != nil {
+		return err
+	}
+	for _, date := range dates {
+		if reason := calendar.unavailableReason(trainerId, dateOnly(date)); reason != "" {
+			return apperror.Conflict("trainer is not available on %s: %s", date.Format("2006-01-02"), reason)
+		}
+	}
+	return nil
+}
+
+// AutoAssign implements AvailabilityUseCase.
+// Sessions whose trainer is unavailable, or who already teaches another session that day,
+// go to the available trainer with the fewest sessions in the period who is free that day.
+// Without commit only the preview is returned. Every schedule carries a trainer
+// (schedules.trainer_id is NOT NULL), so there are no unassigned sessions to fill.
+func (a *availabilityUseCase) AutoAssign(payload dto.AutoAssignRequestDTO, commit bool) (dto.AutoAssignResultDTO, error) {
+	startDate, endDate, err := parseDateRange(payload.StartDate, payload.EndDate)
+	if err != nil {
+		return dto.AutoAssignResultDTO{}, err
+	}
+	if startDate.IsZero() {
+		startDate = dateOnly(time.Now())
+	}
+	if endDate.IsZero() {
+		endDate = startDate.AddDate(0, 0, 27)
+	}
+
+	sessions, err := a.scheduleRepo.ListSessions(startDate, endDate)
+	if err != nil {
+		return dto.AutoAssignResultDTO{}, apperror.Wrap(err, "failed to get sessions")
+	}
+	calendar, err := a.calendar("", startDate)
+	if err != nil {
+		return dto.AutoAssignResultDTO{}, err
+	}
+
+	result := calendar.plan(sessions)
+	var reassignments []dto.AutoAssignmentDTO
+	for _, assignment := range result.Assignments {
+		if assignment.Status == assignmentReassign {
+			reassignments = append(reassignments, assignment)
+		}
+	}
+	if commit && len(reassignments) > 0 {
+		if err := a.scheduleRepo.ReassignSessions(reassignments, time.Now()); err != nil {
+			return dto.AutoAssignResultDTO{}, apperror.Wrap(err, "failed to reassign sessions")
+		}
+		result.Committed = true
+	}
+	return result, nil
+}
+
+// plan walks the sessions in date order. The first session of a trainer on a day stays
+// with them when they are available, every further one that day is a double booking.
+func (c availabilityCalendar) plan(sessions []dto.SessionDTO) dto.AutoAssignResultDTO {
+	workload := map[string]int{}
+	busy := map[string]int{}
+	for _, trainerId := range c.trainers {
+		workload[trainerId] = 0
+	}
+	for _, session := range sessions {
+		workload[session.TrainerID]++
+		busy[busyKey(session.TrainerID, session.Date)]++
+	}
+
+	result := dto.AutoAssignResultDTO{Assignments: []dto.AutoAssignmentDTO{}}
+	kept := map[string]bool{}
+	for _, session := range sessions {
+		date := dateOnly(session.Date)
+		reason := c.unavailableReason(session.TrainerID, date)
+		if reason == "" {
+			if key := busyKey(session.TrainerID, date); kept[key] {
+				reason = "trainer already teaches another session that day"
+			} else {
+				kept[key] = true
+				continue
+			}
+		}
+
+		assignment := dto.AutoAssignmentDTO{
+			ScheduleID:       session.ScheduleID,
+			Date:             session.Date,
+			Activity:         session.Activity,
+			CohortID:         session.CohortID,
+			CurrentTrainerID: session.TrainerID,
+			Status:           assignmentUnresolved,
+			Reason:           reason,
+		}
+
+		var candidates []string
+		for _, trainerId := range c.trainers {
+			if trainerId == session.TrainerID || busy[busyKey(trainerId, date)] > 0 {
+				continue
+			}
+			if c.unavailableReason(trainerId, date) == "" {
+				candidates = append(candidates, trainerId)
+			}
+		}
+		sort.Slice(candidates, func(i, j int) bool {
+			if workload[candidates[i]] != workload[candidates[j]] {
+				return workload[candidates[i]] < workload[candidates[j]]
+			}
+			return candidates[i] < candidates[j]
+		})
+
+		if len(candidates) > 0 {
+			assignment.TrainerID = candidates[0]
+			assignment.Status = assignmentReassign
+			workload[session.TrainerID]--
+			workload[assignment.TrainerID]++
+			busy[busyKey(session.TrainerID, date)]--
+			busy[busyKey(assignment.TrainerID, date)]++
+			kept[busyKey(assignment.TrainerID, date)] = true
+		} else {
+			assignment.Reason += "; no other trainer is available"
+		}
+		result.Assignments = append(result.Assignments, assignment)
+	}
+	result.Workload = workload
+	return result
+}
+
+func (a *availabilityUseCase) calendar(trainerId string, from time.Time) (availabilityCalendar, error) {
+	slots, err := a.repo.ListSlots(trainerId)
+	if err != nil {
+		return availabilityCalendar{}, apperror.Wrap(err, "failed to get availability")
+	}
+	timeOffs, err := a.repo.ListTimeOffs(trainerId, from)
+	if err != nil {
+		return availabilityCalendar{}, apperror.Wrap(err, "failed to get time off")
+	}
+
+	calendar := availabilityCalendar{
+		slots:        map[string][]entity.TrainerAvailability{},
+		timeOffs:     map[string][]entity.TrainerTimeOff{},
+		sessionStart: a.sessionConfig.SessionStartTime,
+		sessionEnd:   a.sessionConfig.SessionEndTime,
+	}
+	if trainerId == "" {
+		if calendar.trainers, err = a.repo.ListTrainerIDs(); err != nil {
+			return availabilityCalendar{}, apperror.Wrap(err, "failed to get trainers")
+		}
+	}
+	for _, slot := range slots {
+		calendar.slots[slot.TrainerID] = append(calendar.slots[slot.TrainerID], slot)
+	}
+	for _, timeOff := range timeOffs {
+		calendar.timeOffs[timeOff.TrainerID] = append(calendar.timeOffs[timeOff.TrainerID], timeOff)
+	}
+	return calendar, nil
+}
+
+// resolveTrainer pins trainers to their own calendar; admins pick any trainer.
+func (a *availabilityUseCase) resolveTrainer(trainerId, userID, role string) (string, error) {
+	if role != "trainer" {
+		return trainerId, nil
+	}
+	trainer, err := a.trainerUseCase.FindTrainerByUserId(userID)
+	if err != nil {
+		return "", apperror.Lookup(err, "trainer not found")
+	}
+	return trainer.ID, nil
+}
+
+func (a *availabilityUseCase) checkOwner(trainerId, userID, role string) error {
+	if role == "admin" {
+		return nil
+	}
+	trainer, err := a.trainerUseCase.FindTrainerByUserId(userID)
+	if err != nil || trainer.ID != trainerId {
+		return apperror.Forbidden("you can only manage your own availability")
+	}
+	return nil
+}
+
+func parseDateRange(start, end string) (time.Time, time.Time, error) {
+	var startDate, endDate time.Time
+	var err error
+	if start != "" {
+		if startDate, err = time.Parse("2006-01-02", start); err != nil {
+			return time.Time{}, time.Time{}, apperror.Invalid("startDate", "invalid startDate format")
+		}
+	}
+	if end != "" {
+		if endDate, err = time.Parse("2006-01-02", end); err != nil {
+			return time.Time{}, time.Time{}, apperror.Invalid("endDate", "invalid endDate format")
+		}
+	}
+	if !startDate.IsZero() && !endDate.IsZero() && endDate.Before(startDate) {
+		return time.Time{}, time.Time{}, apperror.Invalid("endDate", "endDate must not be before startDate")
+	}
+	if startDate.IsZero() && !endDate.IsZero() {
+		return time.Time{}, time.Time{}, apperror.Invalid("startDate", "startDate is required")
+	}
+	return startDate, endDate, nil
+}
+
+func dateOnly(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func busyKey(trainerId string, date time.Time) string {
+	return trainerId + "|" + date.Format("2006-01-02")
+}
+
+func NewAvailabilityUseCase(repo repository.AvailabilityRepository, scheduleRepo repository.ScheduleRepository, trainerUseCase TrainerUsecase, sessionConfig config.SessionConfig) AvailabilityUseCase {
+	return &availabilityUseCase{repo: repo, scheduleRepo: scheduleRepo, trainerUseCase: trainerUseCase, sessionConfig: sessionConfig}
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"instructor-led-app/entity"
+	"instructor-led-app/entity/dto"
+)
+
+func TestUnavailableReason(t *testing.T) {
+	monday := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+	calendar := availabilityCalendar{
+		slots: map[string][]entity.TrainerAvailability{
+			"slots": {{TrainerID: "slots", DayOfWeek: int(time.Monday), StartTime: "08:00", EndTime: "17:00"}},
+		},
+		timeOffs: map[string][]entity.TrainerTimeOff{
+			"away": {{TrainerID: "away", StartDate: monday, EndDate: monday}},
+		},
+		sessionStart: "09:00",
+		sessionEnd:   "12:00",
+	}
+
+	tests := []struct {
+		name      string
+		trainerId string
+		date      time.Time
+		available bool
+	}{
+		{"no slots published", "new", monday, true},
+		{"no slots but on time off", "away", monday, false},
+		{"no slots after the time off", "away", monday.AddDate(0, 0, 1), true},
+		{"slot covers the session", "slots", monday, true},
+		{"slots exclude the day", "slots", monday.AddDate(0, 0, 1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason := calendar.unavailableReason(tt.trainerId, tt.date)
+			if (reason == "") != tt.available {
+				t.Errorf("unavailableReason = %q, want available %v", reason, tt.available)
+			}
+		})
+	}
+}
+
+func TestPlan(t *testing.T) {
+	monday := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+	calendar := availabilityCalendar{
+		trainers: []string{"ana", "budi", "citra"},
+		slots:    map[string][]entity.TrainerAvailability{},
+		timeOffs: map[string][]entity.TrainerTimeOff{
+			"budi": {{TrainerID: "budi", StartDate: monday, EndDate: monday}},
+		},
+	}
+	sessions := []dto.SessionDTO{
+		{ScheduleID: "s1", Date: monday, TrainerID: "ana", CohortID: "c1"},
+		{ScheduleID: "s2", Date: monday, TrainerID: "ana", CohortID: "c2"},
+		{ScheduleID: "s3", Date: monday, TrainerID: "ana", CohortID: "c3"},
+		{ScheduleID: "s4", Date: monday.AddDate(0, 0, 1), TrainerID: "budi", CohortID: "c1"},
+	}
+
+	result := calendar.plan(sessions)
+
+	got := map[string]dto.AutoAssignmentDTO{}
+	for _, assignment := range result.Assignments {
+		got[assignment.ScheduleID] = assignment
+	}
+	if _, ok := got["s1"]; ok {
+		t.Errorf("the first session of the day was moved: %+v", got["s1"])
+	}
+	if a := got["s2"]; a.Status != assignmentReassign || a.TrainerID != "citra" {
+		t.Errorf("double booked s2 = %+v, want reassigned to citra", a)
+	}
+	// budi is on time off and citra took s2, nobody is left for s3
+	if a := got["s3"]; a.Status != assignmentUnresolved || a.TrainerID != "" {
+		t.Errorf("double booked s3 = %+v, want unresolved", a)
+	}
+	if _, ok := got["s4"]; ok {
+		t.Errorf("s4 has no conflict but was planned: %+v", got["s4"])
+	}
+	if want := map[string]int{"ana": 2, "budi": 1, "citra": 1}; !reflect.DeepEqual(result.Workload, want) {
+		t.Errorf("workload = %v, want %v", result.Workload, want)
+	}
+}
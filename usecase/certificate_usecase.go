@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"instructor-led-app/config"
+	"instructor-led-app/entity"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/repository"
+	"instructor-led-app/shared/apperror"
+	"instructor-led-app/shared/service"
+	"strings"
+	"time"
+)
+
+type CertificateUseCase interface {
+	FindEligibility(cohortID string) ([]dto.CertificateEligibilityDTO, error)
+	IssueCertificates(cohortID, issuerUserID string, payload dto.IssueCertificateDTO) ([]entity.Certificate, map[string]string, error)
+	FindCertificates(cohortID string) ([]entity.Certificate, error)
+	FindOwnCertificates(userID string) ([]entity.Certificate, error)
+	RenderCertificate(id, userID, role string) ([]byte, entity.Certificate, error)
+	VerifyCertificate(code string) dto.CertificateVerificationDTO
+}
+
+type certificateUseCase struct {
+	repo               repository.CertificateRepository
+	cohortRepo         repository.CohortRepository
+	participantUseCase ParticipantUseCase
+	renderer           service.CertificateRenderer
+	certificateConfig  config.CertificateConfig
+}
+
+// FindEligibility implements CertificateUseCase. Certificates are only issued once the cohort's
+// last session is over and the participant meets the configured attendance and score thresholds.
+func (c *certificateUseCase) FindEligibility(cohortID string) ([]dto.CertificateEligibilityDTO, error) {
+	if _, err := c.cohortRepo.Get(cohortID); err != nil {
+		return nil, apperror.Lookup(err, "cohort with ID %s not found", cohortID)
+	}
+	_, end, _, err := c.repo.CohortSummary(cohortID)
+	if err != nil {
+		return nil, apperror.Wrap(err, "failed to get cohort sessions")
+	}
+	result, err := c.repo.Eligibility(cohortID)
+	if err != nil {
+		return nil, apperror.Wrap(err, "failed to get eligibility")
+	}
+
+	var cohortUnmet string
+	if end == nil {
+		cohortUnmet = "cohort has no session yet"
+	} else if !dateOnly(*end).Before(dateOnly(time.Now())) {
+		cohortUnmet = fmt.Sprintf("cohort runs until %s", end.Format("2006-01-02"))
+	}
+
+	for i := range result {
+		item := &result[i]
+		if item.SessionsRecorded > 0 {
+			item.AttendanceRate = roundTo(float64(item.SessionsAttended)/float64(item.SessionsRecorded)*100, 2)
+		}
+		if cohortUnmet != "" {
+			item.Unmet = append(item.Unmet, cohortUnmet)
+		}
+		if item.AttendanceRate < c.certificateConfig.MinAttendanceRate {
+			item.Unmet = append(item.Unmet, fmt.Sprintf("attendance rate %.2f%% below %.2f%%", item.AttendanceRate, c.certificateConfig.MinAttendanceRate))
+		}
+		if c.certificateConfig.MinScore > 0 {
+			if item.Score == nil {
+				item.Unmet = append(item.Unmet, "no graded work yet")
+			} else if *item.Score < c.certificateConfig.MinScore {
+				item.Unmet = append(item.Unmet, fmt.Sprintf("score %.2f below %.2f", *item.Score, c.certificateConfig.MinScore))
+			}
+		}
+		if item.Score != nil {
+			score := roundTo(*item.Score, 2)
+			item.Score = &score
+		}
+		item.Eligible = len(item.Unmet) == 0 && !item.AlreadyIssued
+	}
+	return result, nil
+}
+
+// IssueCertificates implements CertificateUseCase. Participants that can't get one are returned with the reason.
+func (c *certificateUseCase) IssueCertificates(cohortID, issuerUserID string, payload dto.IssueCertificateDTO) ([]entity.Certificate, map[string]string, error) {
+	eligibility, err := c.FindEligibility(cohortID)
+	if err != nil {
+		return nil, nil, err
+	}
+	cohort, err := c.cohortRepo.Get(cohortID)
+	if err != nil {
+		return nil, nil, apperror.Lookup(err, "cohort with ID %s not found", cohortID)
+	}
+	start, end, trainers, err := c.repo.CohortSummary(cohortID)
+	if err != nil {
+		return nil, nil, apperror.Wrap(err, "failed to get cohort sessions")
+	}
+
+	wanted := make(map[string]bool, len(payload.ParticipantIDs))
+	for _, id := range payload.ParticipantIDs {
+		wanted[id] = true
+	}
+
+	issued := []entity.Certificate{}
+	skipped := map[string]string{}
+	for _, item := range eligibility {
+		if len(wanted) > 0 && !wanted[item.ParticipantID] {
+			continue
+		}
+		delete(wanted, item.ParticipantID)
+		switch {
+		case item.AlreadyIssued:
+			skipped[item.ParticipantID] = "certificate already issued"
+			continue
+		case !item.Eligible:
+			skipped[item.ParticipantID] = strings.Join(item.Unmet, "; ")
+			continue
+		}
+
+		code, err := newCertificateCode()
+		if err != nil {
+			return issued, skipped, err
+		}
+		track := item.Track
+		if cohort.Track != "" {
+			track = cohort.Track
+		}
+		certificate, err := c.repo.Create(entity.Certificate{
+			Code:            code,
+			ParticipantID:   item.ParticipantID,
+			CohortID:        cohortID,
+			ParticipantName: item.Name,
+			Track:           track,
+			CohortName:      cohort.Name,
+			TrainerNames:    trainers,
+			StartDate:       *start,
+			EndDate:         *end,
+			AttendanceRate:  item.AttendanceRate,
+			Score:           item.Score,
+		}, issuerUserID)
+		if err != nil {
+			skipped[item.ParticipantID] = fmt.Sprintf("failed to issue: %v", err)
+			continue
+		}
+		issued = append(issued, certificate)
+	}
+	for id := range wanted {
+		skipped[id] = "participant is not enrolled in this cohort"
+	}
+	return issued, skipped, nil
+}
+
+// FindCertificates implements CertificateUseCase.
+func (c *certificateUseCase) FindCertificates(cohortID string) ([]entity.Certificate, error) {
+	return c.repo.List(cohortID)
+}
+
+// FindOwnCertificates implements CertificateUseCase.
+func (c *certificateUseCase) FindOwnCertificates(userID string) ([]entity.Certificate, error) {
+	participant, err := c.participantUseCase.GetParticipantByUserId(userID)
+	if err != nil {
+		return nil, apperror.Lookup(err, "participant not found")
+	}
+	return c.repo.ListByParticipant(participant.ID)
+}
+
+// RenderCertificate implements CertificateUseCase. Participants can only download their own certificate.
+func (c *certificateUseCase) RenderCertificate(id, userID, role string) ([]byte, entity.Certificate, error) {
+	certificate, err := c.repo.Get(id)
+	if err != nil {
+		return nil, entity.Certificate{}, apperror.Lookup(err, "certificate not found")
+	}
+	if role == "participant" {
+		participant, err := c.participantUseCase.GetParticipantByUserId(userID)
+		if err != nil || participant.ID != certificate.ParticipantID {
+			return nil, entity.Certificate{}, apperror.Lookup(err, "certificate not found")
+		}
+	}
+
+	doc := service.CertificateDocument{
+		ParticipantName: certificate.ParticipantName,
+		Track:           certificate.Track,
+		CohortName:      certificate.CohortName,
+		Trainer:         certificate.TrainerNames,
+		StartDate:       certificate.StartDate.Format("2 January 2006"),
+		EndDate:         certificate.EndDate.Format("2 January 2006"),
+		IssuedAt:        certificate.IssuedAt.Format("2 January 2006"),
+		Code:            certificate.Code,
+	}
+	if c.certificateConfig.VerifyBaseURL != "" {
+		doc.VerifyURL = c.certificateConfig.VerifyBaseURL + config.APIGroup + strings.Replace(config.CertificateVerify, ":code", certificate.Code, 1)
+	}
+	content, err := c.renderer.Render(doc)
+	if err != nil {
+		return nil, entity.Certificate{}, err
+	}
+	return content, certificate, nil
+}
+
+// VerifyCertificate implements CertificateUseCase. Unknown codes are simply reported as not valid.
+func (c *certificateUseCase) VerifyCertificate(code string) dto.CertificateVerificationDTO {
+	code = normalizeCertificateCode(code)
+	certificate, err := c.repo.GetByCode(code)
+	if err != nil {
+		return dto.CertificateVerificationDTO{Valid: false, Code: code}
+	}
+	return dto.CertificateVerificationDTO{
+		Valid:           true,
+		Code:            certificate.Code,
+		ParticipantName: certificate.ParticipantName,
+		Track:           certificate.Track,
+		CohortName:      certificate.CohortName,
+		TrainerNames:    certificate.TrainerNames,
+		StartDate:       certificate.StartDate.Format("2006-01-02"),
+		EndDate:         certificate.EndDate.Format("2006-01-02"),
+		IssuedAt:        certificate.IssuedAt,
+	}
+}
+
+// newCertificateCode returns 80 random bits as base32 in groups of four, e.g. "K3QF-7ZMA-2XRD-PL4N".
+func newCertificateCode() (string, error) {
+	raw := make([]byte, 10)
+	if _, err := rand.Read(raw); err != nil {
+		return "", apperror.Wrap(err, "failed to generate certificate code")
+	}
+	return normalizeCertificateCode(base32.StdEncoding.EncodeToString(raw)), nil
+}
+
+func normalizeCertificateCode(code string) string {
+	var plain []rune
+	for _, r := range strings.ToUpper(code) {
+		if (r >= 'A' && r <= 'Z') || (r >= '2' && r <= '7') {
+			plain = append(plain, r)
+		}
+	}
+	var groups []string
+	for i := 0; i < len(plain); i += 4 {
+		end := i + 4
+		if end > len(plain) {
+			end = len(plain)
+		}
+		groups = append(groups, string(plain[i:end]))
+	}
+	return strings.Join(groups, "-")
+}
+
+func NewCertificateUseCase(repo repository.CertificateRepository, cohortRepo repository.CohortRepository, participantUseCase ParticipantUseCase, renderer service.CertificateRenderer, certificateConfig config.CertificateConfig) CertificateUseCase {
+	return &certificateUseCase{repo: repo, cohortRepo: cohortRepo, participantUseCase: participantUseCase, renderer: renderer, certificateConfig: certificateConfig}
+}
@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"database/sql"
+	"fmt"
+	"instructor-led-app/entity"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/repository"
+	"instructor-led-app/shared/apperror"
+	"instructor-led-app/shared/model"
+	"instructor-led-app/shared/query"
+	"instructor-led-app/shared/service"
+	"log"
+	"strings"
+	"time"
+)
+
+type CohortUseCase interface {
+	CreateCohort(payload dto.CohortDTO) (entity.Cohort, error)
+	FindCohorts(spec query.Spec) ([]entity.Cohort, model.Paging, error)
+	FindCohortByID(id string) (entity.Cohort, error)
+	UpdateCohort(id string, payload dto.CohortDTO) (entity.Cohort, error)
+	DeleteCohort(id string) error
+	EnrollParticipants(id string, payload dto.CohortEnrollDTO) (entity.CohortRoster, error)
+	UnenrollParticipant(id, participantId string) error
+	Withdraw(id, userID string) error
+	FillSeats(id string) error
+	FindEnrollments(id string) ([]entity.CohortEnrollment, error)
+	FindWaitlist(id string) ([]entity.CohortWaitlistEntry, error)
+	FindOwnCohorts(userID string) ([]entity.ParticipantCohort, error)
+}
+
+type cohortUseCase struct {
+	repo               repository.CohortRepository
+	participantUseCase ParticipantUseCase
+	mailer             service.Mailer
+}
+
+func validateCohort(payload *dto.CohortDTO) error {
+	payload.Name = strings.TrimSpace(payload.Name)
+	if payload.Name == "" {
+		return apperror.Invalid("name", "name is required")
+	}
+	return nil
+}
+
+// notifyPromoted tells the participants who moved up from the waitlist that they now have a seat.
+func (c *cohortUseCase) notifyPromoted(cohort entity.Cohort, participantIds []string) {
+	for _, participantId := range participantIds {
+		name, email, err := c.repo.Contact(participantId)
+		if err != nil {
+			continue
+		}
+		body := fmt.Sprintf("Hi %s,\n\nA seat opened up in %s and you have been moved from the waitlist into the cohort. "+
+			"Your upcoming sessions are now on your schedule.", name, cohort.Name)
+		if err := c.mailer.Send(email, "You have a seat in "+cohort.Name, body); err != nil {
+			log.Println("cohortUseCase.notifyPromoted: failed to notify participant:", err.Error())
+		}
+	}
+}
+
+// CreateCohort implements CohortUseCase.
+func (c *cohortUseCase) CreateCohort(payload dto.CohortDTO) (entity.Cohort, error) {
+	if err := validateCohort(&payload); err != nil {
+		return entity.Cohort{}, err
+	}
+	cohort, err := c.repo.Create(payload)
+	if err != nil {
+		return entity.Cohort{}, apperror.Wrap(err, "failed to create cohort")
+	}
+	return cohort, nil
+}
+
+// FindCohorts implements CohortUseCase.
+func (c *cohortUseCase) FindCohorts(spec query.Spec) ([]entity.Cohort, model.Paging, error) {
+	return c.repo.List(spec)
+}
+
+// FindCohortByID implements CohortUseCase.
+func (c *cohortUseCase) FindCohortByID(id string) (entity.Cohort, error) {
+	cohort, err := c.repo.Get(id)
+	if err != nil {
+		return entity.Cohort{}, apperror.Lookup(err, "cohort with ID %s not found", id)
+	}
+	return cohort, nil
+}
+
+// UpdateCohort implements CohortUseCase. Capacity can't drop below the seats already taken,
+// raising it promotes from the waitlist right away.
+func (c *cohortUseCase) UpdateCohort(id string, payload dto.CohortDTO) (entity.Cohort, error) {
+	cohort, err := c.FindCohortByID(id)
+	if err != nil {
+		return entity.Cohort{}, err
+	}
+	if err := validateCohort(&payload); err != nil {
+		return entity.Cohort{}, err
+	}
+	if payload.Capacity > 0 && payload.Capacity < cohort.Members {
+		return entity.Cohort{}, apperror.Invalid("capacity", "capacity can't be below the %d enrolled participant(s)", cohort.Members)
+	}
+	if err := c.repo.Update(id, payload, time.Now()); err != nil {
+		return entity.Cohort{}, apperror.Wrap(err, "failed to update cohort")
+	}
+	if err := c.FillSeats(id); err != nil {
+		return entity.Cohort{}, err
+	}
+	return c.repo.Get(id)
+}
+
+// DeleteCohort implements CohortUseCase. Cohorts that already have sessions are kept
+// so their attendance history stays intact.
+func (c *cohortUseCase) DeleteCohort(id string) error {
+	if _, err := c.FindCohortByID(id); err != nil {
+		return err
+	}
+	total, err := c.repo.CountSchedules(id)
+	if err != nil {
+		return apperror.Wrap(err, "failed to check cohort schedules")
+	}
+	if total > 0 {
+		return apperror.Conflict("cohort still has %d schedule(s)", total)
+	}
+	return c.repo.Delete(id)
+}
+
+// EnrollParticipants implements CohortUseCase. Once the cohort is full the remaining participants
+// are put on the waitlist in the order they were sent.
+func (c *cohortUseCase) EnrollParticipants(id string, payload dto.CohortEnrollDTO) (entity.CohortRoster, error) {
+	cohort, err := c.FindCohortByID(id)
+	if err != nil {
+		return entity.CohortRoster{}, err
+	}
+	if len(payload.ParticipantIDs) == 0 {
+		return entity.CohortRoster{}, apperror.Invalid("participantIds", "participantIds is required")
+	}
+	for _, participantId := range payload.ParticipantIDs {
+		participant, err := c.participantUseCase.GetParticipantByID(participantId)
+		if err != nil {
+			return entity.CohortRoster{}, apperror.Lookup(err, "participant with ID %s not found", participantId)
+		}
+		if cohort.Track != "" && participant.Role != cohort.Track {
+			return entity.CohortRoster{}, apperror.Invalid("participantIds", "participant %s is on the %s track, cohort is %s", participantId, participant.Role, cohort.Track)
+		}
+	}
+	if _, err := c.repo.Enroll(id, payload.ParticipantIDs); err != nil {
+		return entity.CohortRoster{}, apperror.Wrap(err, "failed to enroll participants")
+	}
+	return c.roster(id)
+}
+
+func (c *cohortUseCase) roster(id string) (entity.CohortRoster, error) {
+	enrollments, err := c.repo.ListEnrollments(id)
+	if err != nil {
+		return entity.CohortRoster{}, err
+	}
+	waitlist, err := c.repo.ListWaitlist(id)
+	if err != nil {
+		return entity.CohortRoster{}, err
+	}
+	return entity.CohortRoster{Enrollments: enrollments, Waitlist: waitlist}, nil
+}
+
+// UnenrollParticipant implements CohortUseCase. The freed seat goes to the head of the waitlist.
+func (c *cohortUseCase) UnenrollParticipant(id, participantId string) error {
+	cohort, err := c.FindCohortByID(id)
+	if err != nil {
+		return err
+	}
+	promoted, err := c.repo.Unenroll(id, participantId)
+	if err == sql.ErrNoRows {
+		return apperror.NotFound("participant %s is not enrolled or waitlisted in cohort %s", participantId, id)
+	}
+	if err != nil {
+		return apperror.Wrap(err, "failed to remove participant")
+	}
+	c.notifyPromoted(cohort, promoted)
+	return nil
+}
+
+// Withdraw implements CohortUseCase. Participants leave a cohort, or its waitlist, themselves.
+func (c *cohortUseCase) Withdraw(id, userID string) error {
+	participant, err := c.participantUseCase.GetParticipantByUserId(userID)
+	if err != nil {
+		return apperror.Lookup(err, "participant not found")
+	}
+	return c.UnenrollParticipant(id, participant.ID)
+}
+
+// FindEnrollments implements CohortUseCase.
+func (c *cohortUseCase) FindEnrollments(id string) ([]entity.CohortEnrollment, error) {
+	if _, err := c.FindCohortByID(id); err != nil {
+		return nil, err
+	}
+	return c.repo.ListEnrollments(id)
+}
+
+// FillSeats implements CohortUseCase. It promotes from the waitlist while the cohort has free seats.
+func (c *cohortUseCase) FillSeats(id string) error {
+	cohort, err := c.FindCohortByID(id)
+	if err != nil {
+		return err
+	}
+	promoted, err := c.repo.FillSeats(id)
+	if err != nil {
+		return apperror.Wrap(err, "failed to promote waitlisted participants")
+	}
+	c.notifyPromoted(cohort, promoted)
+	return nil
+}
+
+// FindWaitlist implements CohortUseCase.
+func (c *cohortUseCase) FindWaitlist(id string) ([]entity.CohortWaitlistEntry, error) {
+	if _, err := c.FindCohortByID(id); err != nil {
+		return nil, err
+	}
+	return c.repo.ListWaitlist(id)
+}
+
+// FindOwnCohorts implements CohortUseCase.
+func (c *cohortUseCase) FindOwnCohorts(userID string) ([]entity.ParticipantCohort, error) {
+	participant, err := c.participantUseCase.GetParticipantByUserId(userID)
+	if err != nil {
+		return nil, apperror.Lookup(err, "participant not found")
+	}
+	return c.repo.ListByParticipant(participant.ID)
+}
+
+func NewCohortUseCase(repo repository.CohortRepository, participantUseCase ParticipantUseCase, mailer service.Mailer) CohortUseCase {
+	return &cohortUseCase{repo: repo, participantUseCase: participantUseCase, mailer: mailer}
+}
@@ -0,0 +1,368 @@
+package usecase
+
+import (
+	"instructor-led-app/entity"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/repository"
+	"instructor-led-app/shared/apperror"
+	"strings"
+	"time"
+)
+
+const (
+	lessonCovered   = "Covered"
+	lessonScheduled = "Scheduled"
+	lessonPending   = "Pending"
+)
+
+type CurriculumUseCase interface {
+	CreateCourse(payload dto.CourseDTO) (entity.Course, error)
+	FindCourses(track string) ([]entity.Course, error)
+	FindCourseByID(id string) (entity.Course, error)
+	UpdateCourse(id string, payload dto.CourseDTO) (entity.Course, error)
+	DeleteCourse(id string) error
+	AddModule(courseId string, payload dto.CourseModuleDTO) (entity.CourseModule, error)
+	UpdateModule(id string, payload dto.CourseModuleDTO) (entity.CourseModule, error)
+	DeleteModule(id string) error
+	ReorderModules(courseId string, payload dto.ReorderDTO) ([]entity.CourseModule, error)
+	AddLesson(moduleId string, payload dto.LessonDTO) (entity.Lesson, error)
+	FindLessonByID(id string) (entity.Lesson, error)
+	UpdateLesson(id string, payload dto.LessonDTO) (entity.Lesson, error)
+	DeleteLesson(id string) error
+	ReorderLessons(moduleId string, payload dto.ReorderDTO) ([]entity.Lesson, error)
+	FindParticipantCoverage(participantId string) ([]dto.CourseCoverageDTO, error)
+	FindOwnCoverage(userID string) ([]dto.CourseCoverageDTO, error)
+}
+
+type curriculumUseCase struct {
+	repo               repository.CurriculumRepository
+	participantUseCase ParticipantUseCase
+}
+
+func validateCourse(payload *dto.CourseDTO) error {
+	payload.Title = strings.TrimSpace(payload.Title)
+	if payload.Title == "" {
+		return apperror.Invalid("title", "title is required")
+	}
+	return nil
+}
+
+func validateLesson(payload *dto.LessonDTO) error {
+	payload.Title = strings.TrimSpace(payload.Title)
+	if payload.Title == "" {
+		return apperror.Invalid("title", "title is required")
+	}
+	objectives := []string{}
+	for _, objective := range payload.Objectives {
+		if objective = strings.TrimSpace(objective); objective != "" {
+			objectives = append(objectives, objective)
+		}
+	}
+	payload.Objectives = objectives
+	return nil
+}
+
+// sameIDs reports whether ids is a permutation of current.
+func sameIDs(ids, current []string) bool {
+	if len(ids) != len(current) {
+		return false
+	}
+	remaining := make(map[string]bool, len(current))
+	for _, id := range current {
+		remaining[id] = true
+	}
+	for _, id := range ids {
+		if !remaining[id] {
+			return false
+		}
+		delete(remaining, id)
+	}
+	return true
+}
+
+// CreateCourse implements CurriculumUseCase.
+func (c *curriculumUseCase) CreateCourse(payload dto.CourseDTO) (entity.Course, error) {
+	if err := validateCourse(&payload); err != nil {
+		return entity.Course{}, err
+	}
+	course, err := c.repo.CreateCourse(payload)
+	if err != nil {
+		return entity.Course{}, apperror.Wrap(err, "failed to create course")
+	}
+	return course, nil
+}
+
+// FindCourses implements CurriculumUseCase.
+func (c *curriculumUseCase) FindCourses(track string) ([]entity.Course, error) {
+	return c.repo.ListCourses(track)
+}
+
+// FindCourseByID implements CurriculumUseCase. The course comes with its modules and lessons in order.
+func (c *curriculumUseCase) FindCourseByID(id string) (entity.Course, error) {
+	course, err := c.repo.GetCourse(id)
+	if err != nil {
+		return entity.Course{}, apperror.Lookup(err, "course with ID %s not found", id)
+	}
+	modules, err := c.repo.ListModules(id)
+	if err != nil {
+		return entity.Course{}, apperror.Wrap(err, "failed to get modules")
+	}
+	lessons, err := c.repo.ListCourseLessons(id)
+	if err != nil {
+		return entity.Course{}, apperror.Wrap(err, "failed to get lessons")
+	}
+
+	byModule := make(map[string][]entity.Lesson)
+	for _, lesson := range lessons {
+		byModule[lesson.ModuleID] = append(byModule[lesson.ModuleID], lesson)
+	}
+	for i := range modules {
+		modules[i].Lessons = byModule[modules[i].ID]
+	}
+	course.Modules = modules
+	return course, nil
+}
+
+// UpdateCourse implements CurriculumUseCase.
+func (c *curriculumUseCase) UpdateCourse(id string, payload dto.CourseDTO) (entity.Course, error) {
+	if _, err := c.repo.GetCourse(id); err != nil {
+		return entity.Course{}, apperror.Lookup(err, "course with ID %s not found", id)
+	}
+	if err := validateCourse(&payload); err != nil {
+		return entity.Course{}, err
+	}
+	if err := c.repo.UpdateCourse(id, payload, time.Now()); err != nil {
+		return entity.Course{}, apperror.Wrap(err, "failed to update course")
+	}
+	return c.FindCourseByID(id)
+}
+
+// DeleteCourse implements CurriculumUseCase. Courses already delivered in a schedule are kept.
+func (c *curriculumUseCase) DeleteCourse(id string) error {
+	if _, err := c.repo.GetCourse(id); err != nil {
+		return apperror.Lookup(err, "course with ID %s not found", id)
+	}
+	if err := c.ensureUnscheduled(id, "", ""); err != nil {
+		return err
+	}
+	if err := c.repo.DeleteCourse(id); err != nil {
+		return apperror.Wrap(err, "failed to delete course")
+	}
+	return nil
+}
+
+// AddModule implements CurriculumUseCase.
+func (c *curriculumUseCase) AddModule(courseId string, payload dto.CourseModuleDTO) (entity.CourseModule, error) {
+	if _, err := c.repo.GetCourse(courseId); err != nil {
+		return entity.CourseModule{}, apperror.Lookup(err, "course with ID %s not found", courseId)
+	}
+	if payload.Title = strings.TrimSpace(payload.Title); payload.Title == "" {
+		return entity.CourseModule{}, apperror.Invalid("title", "title is required")
+	}
+	module, err := c.repo.CreateModule(courseId, payload)
+	if err != nil {
+		return entity.CourseModule{}, apperror.Wrap(err, "failed to create module")
+	}
+	return module, nil
+}
+
+// UpdateModule implements CurriculumUseCase.
+func (c *curriculumUseCase) UpdateModule(id string, payload dto.CourseModuleDTO) (entity.CourseModule, error) {
+	if _, err := c.repo.GetModule(id); err != nil {
+		return entity.CourseModule{}, apperror.Lookup(err, "module with ID %s not found", id)
+	}
+	if payload.Title = strings.TrimSpace(payload.Title); payload.Title == "" {
+		return entity.CourseModule{}, apperror.Invalid("title", "title is required")
+	}
+	if err := c.repo.UpdateModule(id, payload, time.Now()); err != nil {
+		return entity.CourseModule{}, apperror.Wrap(err, "failed to update module")
+	}
+	return c.repo.GetModule(id)
+}
+
+// DeleteModule implements CurriculumUseCase.
+func (c *curriculumUseCase) DeleteModule(id string) error {
+	if _, err := c.repo.GetModule(id); err != nil {
+		return apperror.Lookup(err, "module with ID %s not found", id)
+	}
+	if err := c.ensureUnscheduled("", id, ""); err != nil {
+		return err
+	}
+	if err := c.repo.DeleteModule(id); err != nil {
+		return apperror.Wrap(err, "failed to delete module")
+	}
+	return nil
+}
+
+// ReorderModules implements CurriculumUseCase. Every module of the course has to be listed.
+func (c *curriculumUseCase) ReorderModules(courseId string, payload dto.ReorderDTO) ([]entity.CourseModule, error) {
+	if _, err := c.repo.GetCourse(courseId); err != nil {
+		return nil, apperror.Lookup(err, "course with ID %s not found", courseId)
+	}
+	modules, err := c.repo.ListModules(courseId)
+	if err != nil {
+		return nil, apperror.Wrap(err, "failed to get modules")
+	}
+	var current []string
+	for _, module := range modules {
+		current = append(current, module.ID)
+	}
+	if !sameIDs(payload.IDs, current) {
+		return nil, apperror.Invalid("ids", "ids must list every module of the course exactly once")
+	}
+	if err := c.repo.ReorderModules(courseId, payload.IDs); err != nil {
+		return nil, apperror.Wrap(err, "failed to reorder modules")
+	}
+	return c.repo.ListModules(courseId)
+}
+
+// AddLesson implements CurriculumUseCase.
+func (c *curriculumUseCase) AddLesson(moduleId string, payload dto.LessonDTO) (entity.Lesson, error) {
+	if _, err := c.repo.GetModule(moduleId); err != nil {
+		return entity.Lesson{}, apperror.Lookup(err, "module with ID %s not found", moduleId)
+	}
+	if err := validateLesson(&payload); err != nil {
+		return entity.Lesson{}, err
+	}
+	lesson, err := c.repo.CreateLesson(moduleId, payload)
+	if err != nil {
+		return entity.Lesson{}, apperror.Wrap(err, "failed to create lesson")
+	}
+	return lesson, nil
+}
+
+// FindLessonByID implements CurriculumUseCase.
+func (c *curriculumUseCase) FindLessonByID(id string) (entity.Lesson, error) {
+	lesson, err := c.repo.GetLesson(id)
+	if err != nil {
+		return entity.Lesson{}, apperror.Lookup(err, "lesson with ID %s not found", id)
+	}
+	return lesson, nil
+}
+
+// UpdateLesson implements CurriculumUseCase.
+func (c *curriculumUseCase) UpdateLesson(id string, payload dto.LessonDTO) (entity.Lesson, error) {
+	if _, err := c.FindLessonByID(id); err != nil {
+		return entity.Lesson{}, err
+	}
+	if err := validateLesson(&payload); err != nil {
+		return entity.Lesson{}, err
+	}
+	if err := c.repo.UpdateLesson(id, payload, time.Now()); err != nil {
+		return entity.Lesson{}, apperror.Wrap(err, "failed to update lesson")
+	}
+	return c.repo.GetLesson(id)
+}
+
+// DeleteLesson implements CurriculumUseCase.
+func (c *curriculumUseCase) DeleteLesson(id string) error {
+	if _, err := c.FindLessonByID(id); err != nil {
+		return err
+	}
+	if err := c.ensureUnscheduled("", "", id); err != nil {
+		return err
+	}
+	if err := c.repo.DeleteLesson(id); err != nil {
+		return apperror.Wrap(err, "failed to delete lesson")
+	}
+	return nil
+}
+
+// ReorderLessons implements CurriculumUseCase. Every lesson of the module has to be listed.
+func (c *curriculumUseCase) ReorderLessons(moduleId string, payload dto.ReorderDTO) ([]entity.Lesson, error) {
+	if _, err := c.repo.GetModule(moduleId); err != nil {
+		return nil, apperror.Lookup(err, "module with ID %s not found", moduleId)
+	}
+	lessons, err := c.repo.ListLessons(moduleId)
+	if err != nil {
+		return nil, apperror.Wrap(err, "failed to get lessons")
+	}
+	var current []string
+	for _, lesson := range lessons {
+		current = append(current, lesson.ID)
+	}
+	if !sameIDs(payload.IDs, current) {
+		return nil, apperror.Invalid("ids", "ids must list every lesson of the module exactly once")
+	}
+	if err := c.repo.ReorderLessons(moduleId, payload.IDs); err != nil {
+		return nil, apperror.Wrap(err, "failed to reorder lessons")
+	}
+	return c.repo.ListLessons(moduleId)
+}
+
+// FindParticipantCoverage implements CurriculumUseCase.
+func (c *curriculumUseCase) FindParticipantCoverage(participantId string) ([]dto.CourseCoverageDTO, error) {
+	participant, err := c.participantUseCase.GetParticipantByID(participantId)
+	if err != nil {
+		return nil, apperror.Lookup(err, "participant with ID %s not found", participantId)
+	}
+	return c.coverage(participant.ID, participant.Role)
+}
+
+// FindOwnCoverage implements CurriculumUseCase.
+func (c *curriculumUseCase) FindOwnCoverage(userID string) ([]dto.CourseCoverageDTO, error) {
+	participant, err := c.participantUseCase.GetParticipantByUserId(userID)
+	if err != nil {
+		return nil, apperror.Lookup(err, "participant not found")
+	}
+	return c.coverage(participant.ID, participant.Role)
+}
+
+// coverage groups the lesson rows into courses and modules and sums up the progress per course.
+func (c *curriculumUseCase) coverage(participantId, track string) ([]dto.CourseCoverageDTO, error) {
+	rows, err := c.repo.Coverage(participantId, track)
+	if err != nil {
+		return nil, apperror.Wrap(err, "failed to get curriculum coverage")
+	}
+
+	courses := []dto.CourseCoverageDTO{}
+	for _, row := range rows {
+		if len(courses) == 0 || courses[len(courses)-1].CourseID != row.CourseID {
+			courses = append(courses, dto.CourseCoverageDTO{CourseID: row.CourseID, Title: row.CourseTitle, Modules: []dto.ModuleCoverageDTO{}})
+		}
+		course := &courses[len(courses)-1]
+		if len(course.Modules) == 0 || course.Modules[len(course.Modules)-1].ModuleID != row.ModuleID {
+			course.Modules = append(course.Modules, dto.ModuleCoverageDTO{ModuleID: row.ModuleID, Title: row.ModuleTitle, Position: row.ModulePos})
+		}
+		module := &course.Modules[len(course.Modules)-1]
+
+		lesson := row.Lesson
+		switch {
+		case lesson.CoveredOn != nil:
+			lesson.Status = lessonCovered
+			course.CoveredLessons++
+			course.CoveredMinutes += lesson.DurationMinutes
+		case lesson.NextSessionOn != nil:
+			lesson.Status = lessonScheduled
+			course.PendingLessons++
+		default:
+			lesson.Status = lessonPending
+			course.PendingLessons++
+		}
+		course.TotalLessons++
+		course.TotalMinutes += lesson.DurationMinutes
+		module.Lessons = append(module.Lessons, lesson)
+	}
+
+	for i := range courses {
+		if courses[i].TotalLessons > 0 {
+			courses[i].Progress = roundTo(float64(courses[i].CoveredLessons)/float64(courses[i].TotalLessons)*100, 2)
+		}
+	}
+	return courses, nil
+}
+
+func (c *curriculumUseCase) ensureUnscheduled(courseId, moduleId, lessonId string) error {
+	total, err := c.repo.CountSchedules(courseId, moduleId, lessonId)
+	if err != nil {
+		return apperror.Wrap(err, "failed to check schedules")
+	}
+	if total > 0 {
+		return apperror.Conflict("curriculum is used by %d schedule(s), unlink them first", total)
+	}
+	return nil
+}
+
+func NewCurriculumUseCase(repo repository.CurriculumRepository, participantUseCase ParticipantUseCase) CurriculumUseCase {
+	return &curriculumUseCase{repo: repo, participantUseCase: participantUseCase}
+}
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"instructor-led-app/config"
+	"instructor-led-app/entity"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/repository"
+	"instructor-led-app/shared/apperror"
+	"instructor-led-app/shared/model"
+	"instructor-led-app/shared/query"
+	"strings"
+	"time"
+)
+
+type FeedbackUseCase interface {
+	SubmitFeedback(userID string, payload dto.FeedbackDTO) (entity.SessionFeedback, error)
+	FindTrainerFeedback(userID string, spec query.Spec) ([]dto.AnonymousFeedbackDTO, model.Paging, error)
+	TrainerRatings(filter dto.RatingSummaryFilterDTO) ([]dto.TrainerRatingDTO, error)
+}
+
+type feedbackUseCase struct {
+	repo               repository.FeedbackRepository
+	scheduleUseCase    ScheduleUseCase
+	participantUseCase ParticipantUseCase
+	trainerUseCase     TrainerUsecase
+	sessionConfig      config.SessionConfig
+}
+
+// SubmitFeedback implements FeedbackUseCase.
+// Participants rate a schedule of theirs once, after its session has ended.
+func (f *feedbackUseCase) SubmitFeedback(userID string, payload dto.FeedbackDTO) (entity.SessionFeedback, error) {
+	payload.Comment = strings.TrimSpace(payload.Comment)
+
+	participant, err := f.participantUseCase.GetParticipantByUserId(userID)
+	if err != nil {
+		return entity.SessionFeedback{}, apperror.Lookup(err, "participant not found")
+	}
+	schedule, err := f.scheduleUseCase.FindScheduleForUser(payload.ScheduleID, userID, "participant")
+	if err != nil {
+		return entity.SessionFeedback{}, err
+	}
+
+	_, end, err := f.sessionConfig.Window(schedule.Date)
+	if err != nil {
+		return entity.SessionFeedback{}, err
+	}
+	if time.Now().Before(end) {
+		return entity.SessionFeedback{}, apperror.BadRequest("feedback can be submitted after the session ends at %s", end.Format("2006-01-02 15:04"))
+	}
+
+	exists, err := f.repo.Exists(schedule.ID, participant.ID)
+	if err != nil {
+		return entity.SessionFeedback{}, apperror.Wrap(err, "failed to check feedback")
+	}
+	if exists {
+		return entity.SessionFeedback{}, apperror.Conflict("you already gave feedback for this schedule")
+	}
+
+	feedback, err := f.repo.Create(entity.SessionFeedback{
+		ScheduleID:    schedule.ID,
+		TrainerID:     schedule.TrainerID,
+		ParticipantID: participant.ID,
+		Rating:        payload.Rating,
+		Comment:       payload.Comment,
+	})
+	if err != nil {
+		return entity.SessionFeedback{}, apperror.Wrap(err, "failed to save feedback")
+	}
+	return feedback, nil
+}
+
+// FindTrainerFeedback implements FeedbackUseCase.
+func (f *feedbackUseCase) FindTrainerFeedback(userID string, spec query.Spec) ([]dto.AnonymousFeedbackDTO, model.Paging, error) {
+	trainer, err := f.trainerUseCase.FindTrainerByUserId(userID)
+	if err != nil {
+		return nil, model.Paging{}, apperror.Lookup(err, "trainer not found")
+	}
+	return f.repo.ListByTrainer(trainer.ID, spec)
+}
+
+// TrainerRatings implements FeedbackUseCase.
+// Ratings are averaged per trainer and per week, month (default) or year.
+func (f *feedbackUseCase) TrainerRatings(filter dto.RatingSummaryFilterDTO) ([]dto.TrainerRatingDTO, error) {
+	if filter.Interval == "" {
+		filter.Interval = "month"
+	}
+
+	startDate, endDate, err := parseDateRange(filter.StartDate, filter.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	if endDate.IsZero() {
+		endDate = dateOnly(time.Now())
+	}
+	if startDate.IsZero() {
+		startDate = endDate.AddDate(-1, 0, 0)
+	}
+
+	return f.repo.RatingSummary(filter.Interval, startDate, endDate, filter.TrainerID)
+}
+
+func NewFeedbackUseCase(repo repository.FeedbackRepository, scheduleUseCase ScheduleUseCase, participantUseCase ParticipantUseCase, trainerUseCase TrainerUsecase, sessionConfig config.SessionConfig) FeedbackUseCase {
+	return &feedbackUseCase{repo: repo, scheduleUseCase: scheduleUseCase, participantUseCase: participantUseCase, trainerUseCase: trainerUseCase, sessionConfig: sessionConfig}
+}
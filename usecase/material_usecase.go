@@ -0,0 +1,292 @@
+package usecase
+
+import (
+	"bytes"
+	"instructor-led-app/entity"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/repository"
+	"instructor-led-app/shared/apperror"
+	"instructor-led-app/shared/service"
+	"io"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	materialPDF    = "pdf"
+	materialSlides = "slides"
+	materialLink   = "link"
+)
+
+type MaterialUseCase interface {
+	CreateMaterial(userID, role string, payload dto.MaterialDTO, file *dto.UploadFileDTO) (entity.Material, error)
+	AddVersion(id, userID, role string, payload dto.MaterialDTO, file *dto.UploadFileDTO) (entity.Material, error)
+	FindMaterials(scheduleID, lessonID, userID, role string) ([]entity.Material, error)
+	FindMaterial(id, userID, role string) (entity.Material, error)
+	UpdateMaterial(id, userID, role string, payload dto.MaterialUpdateDTO) (entity.Material, error)
+	DeleteMaterial(id, userID, role string) error
+	OpenMaterial(id string, version int, userID, role string) (io.ReadCloser, entity.MaterialVersion, error)
+	FindDownloads(id, userID, role string) (dto.MaterialDownloadsDTO, error)
+	FindOwnMaterials(userID, when string) ([]entity.SessionMaterials, error)
+}
+
+type materialUseCase struct {
+	repo               repository.MaterialRepository
+	scheduleUseCase    ScheduleUseCase
+	curriculumUseCase  CurriculumUseCase
+	participantUseCase ParticipantUseCase
+	storage            service.FileStorage
+}
+
+// canViewTarget tells whether the user may read the materials of a schedule or a lesson. Participants
+// need to be in the session, or in a session delivering the lesson; lessons are open to every trainer.
+func (m *materialUseCase) canViewTarget(scheduleID, lessonID, userID, role string) bool {
+	if role == "admin" {
+		return true
+	}
+	if scheduleID != "" {
+		_, err := m.scheduleUseCase.FindScheduleForUser(scheduleID, userID, role)
+		return err == nil
+	}
+	switch role {
+	case "trainer":
+		return true
+	case "participant":
+		participant, err := m.participantUseCase.GetParticipantByUserId(userID)
+		if err != nil {
+			return false
+		}
+		ok, _ := m.repo.HasLessonSession(lessonID, participant.ID)
+		return ok
+	}
+	return false
+}
+
+// manageable returns the material when the user is an admin, the uploader or the trainer of its schedule.
+func (m *materialUseCase) manageable(id, userID, role string) (entity.Material, error) {
+	material, err := m.repo.Get(id)
+	if err != nil {
+		return entity.Material{}, apperror.Lookup(err, "material not found")
+	}
+	if role == "admin" || material.UploadedBy == userID {
+		return material, nil
+	}
+	if role == "trainer" && material.ScheduleID != "" {
+		if _, err := m.scheduleUseCase.FindScheduleForUser(material.ScheduleID, userID, role); err == nil {
+			return material, nil
+		}
+	}
+	return entity.Material{}, apperror.Forbidden("you don't have access to material %s", id)
+}
+
+// newVersion stores the uploaded file, or checks the link, of a material version.
+func (m *materialUseCase) newVersion(userID string, payload dto.MaterialDTO, file *dto.UploadFileDTO) (entity.MaterialVersion, error) {
+	payload.URL = strings.TrimSpace(payload.URL)
+	if (payload.URL == "") == (file == nil) {
+		return entity.MaterialVersion{}, apperror.BadRequest("either a file or a url is required")
+	}
+	version := entity.MaterialVersion{Note: strings.TrimSpace(payload.Note), UploadedBy: userID}
+
+	if file == nil {
+		link, err := url.Parse(payload.URL)
+		if err != nil || (link.Scheme != "http" && link.Scheme != "https") || link.Host == "" {
+			return entity.MaterialVersion{}, apperror.Invalid("url", "url must be an http or https link")
+		}
+		version.Kind, version.URL = materialLink, payload.URL
+		return version, nil
+	}
+
+	ext := strings.ToLower(filepath.Ext(file.FileName))
+	version.Kind = materialSlides
+	if ext == ".pdf" {
+		version.Kind = materialPDF
+	}
+	key, checksum := service.ContentKey("materials", file.Content, ext)
+	if err := m.storage.Put(key, bytes.NewReader(file.Content), int64(len(file.Content)), file.ContentType); err != nil {
+		return entity.MaterialVersion{}, apperror.Wrap(err, "failed to store file")
+	}
+	version.FileName, version.StorageKey, version.Checksum = file.FileName, key, checksum
+	version.Size, version.MimeType = int64(len(file.Content)), file.ContentType
+	return version, nil
+}
+
+// CreateMaterial implements MaterialUseCase. Trainers can add materials to their own sessions and to any lesson.
+func (m *materialUseCase) CreateMaterial(userID, role string, payload dto.MaterialDTO, file *dto.UploadFileDTO) (entity.Material, error) {
+	if (payload.ScheduleID == "") == (payload.LessonID == "") {
+		return entity.Material{}, apperror.BadRequest("either scheduleId or lessonId is required")
+	}
+	if payload.LessonID != "" {
+		if _, err := m.curriculumUseCase.FindLessonByID(payload.LessonID); err != nil {
+			return entity.Material{}, err
+		}
+	} else if !m.canViewTarget(payload.ScheduleID, "", userID, role) {
+		return entity.Material{}, apperror.Forbidden("you don't have access to schedule %s", payload.ScheduleID)
+	}
+
+	payload.Title = strings.TrimSpace(payload.Title)
+	if payload.Title == "" && file != nil {
+		payload.Title = file.FileName
+	}
+	if payload.Title == "" {
+		return entity.Material{}, apperror.Invalid("title", "title is required")
+	}
+
+	version, err := m.newVersion(userID, payload, file)
+	if err != nil {
+		return entity.Material{}, err
+	}
+	material, err := m.repo.Create(entity.Material{
+		ScheduleID:  payload.ScheduleID,
+		LessonID:    payload.LessonID,
+		Title:       payload.Title,
+		Description: strings.TrimSpace(payload.Description),
+		Kind:        version.Kind,
+		UploadedBy:  userID,
+	}, version)
+	if err != nil {
+		return entity.Material{}, apperror.Wrap(err, "failed to save material")
+	}
+	return material, nil
+}
+
+// AddVersion implements MaterialUseCase.
+func (m *materialUseCase) AddVersion(id, userID, role string, payload dto.MaterialDTO, file *dto.UploadFileDTO) (entity.Material, error) {
+	if _, err := m.manageable(id, userID, role); err != nil {
+		return entity.Material{}, err
+	}
+	version, err := m.newVersion(userID, payload, file)
+	if err != nil {
+		return entity.Material{}, err
+	}
+	version.MaterialID = id
+	if _, err := m.repo.AddVersion(version); err != nil {
+		return entity.Material{}, apperror.Wrap(err, "failed to save version")
+	}
+	return m.FindMaterial(id, userID, role)
+}
+
+// FindMaterials implements MaterialUseCase.
+func (m *materialUseCase) FindMaterials(scheduleID, lessonID, userID, role string) ([]entity.Material, error) {
+	if role != "admin" {
+		if (scheduleID == "") == (lessonID == "") {
+			return nil, apperror.BadRequest("either scheduleId or lessonId is required")
+		}
+		if !m.canViewTarget(scheduleID, lessonID, userID, role) {
+			return nil, apperror.Forbidden("you don't have access to these materials")
+		}
+	}
+	return m.repo.List(scheduleID, lessonID)
+}
+
+// FindMaterial implements MaterialUseCase. The material comes with all its versions, newest first.
+func (m *materialUseCase) FindMaterial(id, userID, role string) (entity.Material, error) {
+	material, err := m.repo.Get(id)
+	if err != nil {
+		return entity.Material{}, apperror.Lookup(err, "material not found")
+	}
+	if !m.canViewTarget(material.ScheduleID, material.LessonID, userID, role) {
+		return entity.Material{}, apperror.Forbidden("you don't have access to material %s", id)
+	}
+	if material.Versions, err = m.repo.ListVersions(id); err != nil {
+		return entity.Material{}, apperror.Wrap(err, "failed to get versions")
+	}
+	return material, nil
+}
+
+// UpdateMaterial implements MaterialUseCase.
+func (m *materialUseCase) UpdateMaterial(id, userID, role string, payload dto.MaterialUpdateDTO) (entity.Material, error) {
+	if _, err := m.manageable(id, userID, role); err != nil {
+		return entity.Material{}, err
+	}
+	if payload.Title = strings.TrimSpace(payload.Title); payload.Title == "" {
+		return entity.Material{}, apperror.Invalid("title", "title is required")
+	}
+	payload.Description = strings.TrimSpace(payload.Description)
+	if err := m.repo.Update(id, payload, time.Now()); err != nil {
+		return entity.Material{}, apperror.Wrap(err, "failed to update material")
+	}
+	return m.repo.Get(id)
+}
+
+// DeleteMaterial implements MaterialUseCase.
+func (m *materialUseCase) DeleteMaterial(id, userID, role string) error {
+	if _, err := m.manageable(id, userID, role); err != nil {
+		return err
+	}
+	if err := m.repo.Delete(id); err != nil {
+		return apperror.Wrap(err, "failed to delete material")
+	}
+	return nil
+}
+
+// OpenMaterial implements MaterialUseCase. Version 0 means the current one. Every call is counted as a
+// download; for links the body is nil and the caller redirects to the version URL.
+func (m *materialUseCase) OpenMaterial(id string, version int, userID, role string) (io.ReadCloser, entity.MaterialVersion, error) {
+	material, err := m.repo.Get(id)
+	if err != nil {
+		return nil, entity.MaterialVersion{}, apperror.Lookup(err, "material not found")
+	}
+	if !m.canViewTarget(material.ScheduleID, material.LessonID, userID, role) {
+		return nil, entity.MaterialVersion{}, apperror.Forbidden("you don't have access to material %s", id)
+	}
+	if version <= 0 {
+		version = material.CurrentVersion
+	}
+	selected, err := m.repo.GetVersion(id, version)
+	if err != nil {
+		return nil, entity.MaterialVersion{}, apperror.Lookup(err, "version %d of material %s not found", version, id)
+	}
+
+	var body io.ReadCloser
+	if selected.StorageKey != "" {
+		if body, err = m.storage.Get(selected.StorageKey); err != nil {
+			return nil, entity.MaterialVersion{}, apperror.Lookup(err, "file not found")
+		}
+	}
+	if err := m.repo.RecordDownload(id, selected.ID, userID); err != nil {
+		if body != nil {
+			body.Close()
+		}
+		return nil, entity.MaterialVersion{}, apperror.Wrap(err, "failed to record download")
+	}
+	return body, selected, nil
+}
+
+// FindDownloads implements MaterialUseCase.
+func (m *materialUseCase) FindDownloads(id, userID, role string) (dto.MaterialDownloadsDTO, error) {
+	if _, err := m.manageable(id, userID, role); err != nil {
+		return dto.MaterialDownloadsDTO{}, err
+	}
+	return m.repo.Downloads(id)
+}
+
+// FindOwnMaterials implements MaterialUseCase. Upcoming sessions are listed soonest first, past ones latest first.
+func (m *materialUseCase) FindOwnMaterials(userID, when string) ([]entity.SessionMaterials, error) {
+	if when != "" && when != "upcoming" && when != "past" {
+		return nil, apperror.Invalid("when", "when must be upcoming or past")
+	}
+	participant, err := m.participantUseCase.GetParticipantByUserId(userID)
+	if err != nil {
+		return nil, apperror.Lookup(err, "participant not found")
+	}
+	sessions, err := m.repo.ListForParticipant(participant.ID, when)
+	if err != nil {
+		return nil, apperror.Wrap(err, "failed to get materials")
+	}
+
+	today := dateOnly(time.Now())
+	for i := range sessions {
+		sessions[i].Upcoming = !dateOnly(sessions[i].Date).Before(today)
+	}
+	if when == "past" {
+		sort.SliceStable(sessions, func(i, j int) bool { return sessions[i].Date.After(sessions[j].Date) })
+	}
+	return sessions, nil
+}
+
+func NewMaterialUseCase(repo repository.MaterialRepository, scheduleUseCase ScheduleUseCase, curriculumUseCase CurriculumUseCase, participantUseCase ParticipantUseCase, storage service.FileStorage) MaterialUseCase {
+	return &materialUseCase{repo: repo, scheduleUseCase: scheduleUseCase, curriculumUseCase: curriculumUseCase, participantUseCase: participantUseCase, storage: storage}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"instructor-led-app/entity/dto"
 	"instructor-led-app/repository"
+	"instructor-led-app/shared/apperror"
 	"instructor-led-app/shared/model"
 	"reflect"
 	"strings"
@@ -27,9 +28,6 @@ type participantUseCase struct {
 
 // UpdateParticipantByRole implements ParticipantUseCase.
 func (p *participantUseCase) UpdateParticipantByRole(role, id string) error {
-	if role == "" {
-		return fmt.Errorf("role can't empty")
-	}
 	return p.participantRepository.UpdateByRole(role, id)
 }
 
@@ -43,17 +41,17 @@ func (u *participantUseCase) FindScheduleWithParticipantId(userId string) ([]dto
 	participant, err := u.participantRepository.GetParticipantByUserId(userId)
 	fmt.Println(participant)
 	if err != nil {
-		return []dto.ParticipantDTO{}, fmt.Errorf("failed to get participant: %v", err)
+		return []dto.ParticipantDTO{}, apperror.Wrap(err, "failed to get participant")
 	}
 
 	schedul, err := u.participantRepository.GetScheduleById(participant.ID)
 	// fmt.Println(schedul)
 	if err != nil {
-		return []dto.ParticipantDTO{}, fmt.Errorf("failed to get participant id: %v", err)
+		return []dto.ParticipantDTO{}, apperror.Wrap(err, "failed to get schedules")
 	}
 
 	if len(schedul) == 0 {
-		return []dto.ParticipantDTO{}, fmt.Errorf("schedul not found: %v", err)
+		return []dto.ParticipantDTO{}, apperror.NotFound("schedule not found")
 	}
 	return schedul, nil
 }
@@ -75,7 +73,7 @@ func (u *participantUseCase) CreateNewParticipant(participant dto.ParticipantDTO
 func (u *participantUseCase) parsedDate(value string) (string, error) {
 	parsedDate, err := time.Parse("2006-01-02", value)
 	if err != nil {
-		return "", err
+		return "", apperror.Invalid("dateOfBirth", "date must be in YYYY-MM-DD format")
 	}
 
 	return parsedDate.Format("2006-01-02"), nil
@@ -0,0 +1,243 @@
+package usecase
+
+import (
+	"fmt"
+	"instructor-led-app/entity"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/repository"
+	"instructor-led-app/shared/apperror"
+	"instructor-led-app/shared/model"
+	"instructor-led-app/shared/query"
+	"log"
+	"strings"
+	"time"
+)
+
+const (
+	trackBasic   = "Basic"
+	trackAdvance = "Advance"
+)
+
+type PromotionUseCase interface {
+	FindCriteria() (entity.PromotionCriteria, error)
+	UpdateCriteria(payload entity.PromotionCriteria) (entity.PromotionCriteria, error)
+	FindProgress(participantId string) ([]dto.ParticipantProgressDTO, error)
+	FindOwnProgress(userID string) (dto.ParticipantProgressDTO, error)
+	EvaluateAll() ([]entity.PromotionRequest, error)
+	EvaluateParticipant(participantId string) (*entity.PromotionRequest, error)
+	FindRequests(spec query.Spec) ([]entity.PromotionRequest, model.Paging, error)
+	ReviewRequest(id, reviewerUserID string, payload dto.PromotionReviewDTO) (entity.PromotionRequest, error)
+	FindHistory(participantId string) ([]entity.TrackHistory, error)
+}
+
+type promotionUseCase struct {
+	repo               repository.PromotionRepository
+	cohortRepo         repository.CohortRepository
+	cohortUseCase      CohortUseCase
+	participantUseCase ParticipantUseCase
+}
+
+// FindCriteria implements PromotionUseCase.
+func (p *promotionUseCase) FindCriteria() (entity.PromotionCriteria, error) {
+	return p.repo.GetCriteria()
+}
+
+// UpdateCriteria implements PromotionUseCase. A zero minimum switches that rule off.
+func (p *promotionUseCase) UpdateCriteria(payload entity.PromotionCriteria) (entity.PromotionCriteria, error) {
+	payload.UpdatedAt = time.Now()
+	return p.repo.UpdateCriteria(payload)
+}
+
+// judge fills in the attendance rate and whether the criteria are met.
+func judge(progress *dto.ParticipantProgressDTO, criteria entity.PromotionCriteria) {
+	if progress.SessionsRecorded > 0 {
+		progress.AttendanceRate = roundTo(float64(progress.SessionsCompleted)/float64(progress.SessionsRecorded)*100, 2)
+	}
+
+	progress.Unmet = nil
+	if progress.SessionsCompleted < criteria.MinSessionsCompleted {
+		progress.Unmet = append(progress.Unmet, fmt.Sprintf("sessions completed %d of %d", progress.SessionsCompleted, criteria.MinSessionsCompleted))
+	}
+	if progress.AttendanceRate < criteria.MinAttendanceRate {
+		progress.Unmet = append(progress.Unmet, fmt.Sprintf("attendance rate %.2f%% below %.2f%%", progress.AttendanceRate, criteria.MinAttendanceRate))
+	}
+	if criteria.MinAssessmentScore > 0 {
+		if progress.AssessmentScore == nil {
+			progress.Unmet = append(progress.Unmet, "no graded assessment yet")
+		} else if *progress.AssessmentScore < criteria.MinAssessmentScore {
+			progress.Unmet = append(progress.Unmet, fmt.Sprintf("assessment score %.2f below %.2f", *progress.AssessmentScore, criteria.MinAssessmentScore))
+		}
+	}
+	progress.Eligible = progress.Track == trackBasic && len(progress.Unmet) == 0
+}
+
+func (p *promotionUseCase) progressOf(participantId, track string) ([]dto.ParticipantProgressDTO, error) {
+	criteria, err := p.repo.GetCriteria()
+	if err != nil {
+		return nil, apperror.Wrap(err, "failed to get promotion criteria")
+	}
+	progress, err := p.repo.ListProgress(participantId, track)
+	if err != nil {
+		return nil, apperror.Wrap(err, "failed to get participant progress")
+	}
+	for i := range progress {
+		judge(&progress[i], criteria)
+	}
+	return progress, nil
+}
+
+// FindProgress implements PromotionUseCase. Without a participant every Basic participant is listed.
+func (p *promotionUseCase) FindProgress(participantId string) ([]dto.ParticipantProgressDTO, error) {
+	if participantId != "" {
+		return p.progressOf(participantId, "")
+	}
+	return p.progressOf("", trackBasic)
+}
+
+// FindOwnProgress implements PromotionUseCase.
+func (p *promotionUseCase) FindOwnProgress(userID string) (dto.ParticipantProgressDTO, error) {
+	participant, err := p.participantUseCase.GetParticipantByUserId(userID)
+	if err != nil {
+		return dto.ParticipantProgressDTO{}, apperror.Lookup(err, "participant not found")
+	}
+	progress, err := p.progressOf(participant.ID, "")
+	if err != nil {
+		return dto.ParticipantProgressDTO{}, err
+	}
+	if len(progress) == 0 {
+		return dto.ParticipantProgressDTO{}, apperror.NotFound("participant not found")
+	}
+	return progress[0], nil
+}
+
+// queue puts an eligible participant in the approval queue unless they already wait there.
+func (p *promotionUseCase) queue(progress dto.ParticipantProgressDTO) (*entity.PromotionRequest, error) {
+	if !progress.Eligible {
+		return nil, nil
+	}
+	open, err := p.repo.HasOpenRequest(progress.ParticipantID, progress.SessionsCompleted)
+	if err != nil || open {
+		return nil, err
+	}
+	request, err := p.repo.CreateRequest(entity.PromotionRequest{
+		ParticipantID:     progress.ParticipantID,
+		ParticipantName:   progress.Name,
+		FromTrack:         progress.Track,
+		ToTrack:           trackAdvance,
+		AttendanceRate:    progress.AttendanceRate,
+		AssessmentScore:   progress.AssessmentScore,
+		SessionsCompleted: progress.SessionsCompleted,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// EvaluateAll implements PromotionUseCase. It returns only the newly queued requests.
+func (p *promotionUseCase) EvaluateAll() ([]entity.PromotionRequest, error) {
+	progress, err := p.progressOf("", trackBasic)
+	if err != nil {
+		return nil, err
+	}
+	queued := []entity.PromotionRequest{}
+	for _, item := range progress {
+		request, err := p.queue(item)
+		if err != nil {
+			return queued, apperror.Wrap(err, "failed to queue %s", item.Name)
+		}
+		if request != nil {
+			queued = append(queued, *request)
+		}
+	}
+	return queued, nil
+}
+
+// EvaluateParticipant implements PromotionUseCase. It runs whenever attendance is taken.
+func (p *promotionUseCase) EvaluateParticipant(participantId string) (*entity.PromotionRequest, error) {
+	progress, err := p.progressOf(participantId, trackBasic)
+	if err != nil || len(progress) == 0 {
+		return nil, err
+	}
+	request, err := p.queue(progress[0])
+	if err != nil {
+		log.Println("promotionUseCase.EvaluateParticipant:", err.Error())
+		return nil, err
+	}
+	return request, nil
+}
+
+// FindRequests implements PromotionUseCase.
+func (p *promotionUseCase) FindRequests(spec query.Spec) ([]entity.PromotionRequest, model.Paging, error) {
+	return p.repo.ListRequests(spec)
+}
+
+// ReviewRequest implements PromotionUseCase. Rejections must carry a reason; an approval may
+// name the cohort of the new track that takes over the participant's upcoming sessions, or
+// whose waitlist they join when it is full.
+func (p *promotionUseCase) ReviewRequest(id, reviewerUserID string, payload dto.PromotionReviewDTO) (entity.PromotionRequest, error) {
+	payload.Reason = strings.TrimSpace(payload.Reason)
+	if payload.Status == "Rejected" && payload.Reason == "" {
+		return entity.PromotionRequest{}, apperror.Invalid("reason", "reason is required when rejecting a promotion")
+	}
+
+	request, err := p.repo.GetRequest(id)
+	if err != nil {
+		return entity.PromotionRequest{}, apperror.Lookup(err, "promotion request not found")
+	}
+	if request.Status != "Pending" {
+		return entity.PromotionRequest{}, apperror.Conflict("promotion request was already %s", strings.ToLower(request.Status))
+	}
+
+	waitlisted := false
+	if payload.Status == "Rejected" {
+		err = p.repo.Reject(id, payload.Reason, reviewerUserID, time.Now())
+	} else {
+		if payload.CohortID != "" {
+			cohort, err := p.cohortRepo.Get(payload.CohortID)
+			if err != nil {
+				return entity.PromotionRequest{}, apperror.Lookup(err, "cohort with ID %s not found", payload.CohortID)
+			}
+			if cohort.Track != "" && cohort.Track != request.ToTrack {
+				return entity.PromotionRequest{}, apperror.Invalid("cohortId", "cohort %s is for the %s track", cohort.Name, cohort.Track)
+			}
+		}
+		// the participant leaves their old track's cohorts, whose seats then go to the waitlist
+		var left []string
+		if cohorts, err := p.cohortRepo.ListByParticipant(request.ParticipantID); err == nil {
+			for _, cohort := range cohorts {
+				if cohort.Status == "Enrolled" && cohort.Track == request.FromTrack {
+					left = append(left, cohort.CohortID)
+				}
+			}
+		}
+		if waitlisted, err = p.repo.Approve(id, payload.Reason, reviewerUserID, payload.CohortID, time.Now()); err == nil {
+			for _, cohortId := range left {
+				if err := p.cohortUseCase.FillSeats(cohortId); err != nil {
+					log.Println("promotionUseCase.ReviewRequest:", err.Error())
+				}
+			}
+		}
+	}
+	if err != nil {
+		return entity.PromotionRequest{}, apperror.Wrap(err, "failed to review promotion")
+	}
+	reviewed, err := p.repo.GetRequest(id)
+	if err != nil {
+		return entity.PromotionRequest{}, apperror.Wrap(err, "failed to get promotion request")
+	}
+	reviewed.Waitlisted = waitlisted
+	return reviewed, nil
+}
+
+// FindHistory implements PromotionUseCase.
+func (p *promotionUseCase) FindHistory(participantId string) ([]entity.TrackHistory, error) {
+	if _, err := p.participantUseCase.GetParticipantByID(participantId); err != nil {
+		return nil, apperror.Lookup(err, "participant with ID %s not found", participantId)
+	}
+	return p.repo.ListHistory(participantId)
+}
+
+func NewPromotionUseCase(repo repository.PromotionRepository, cohortRepo repository.CohortRepository, cohortUseCase CohortUseCase, participantUseCase ParticipantUseCase) PromotionUseCase {
+	return &promotionUseCase{repo: repo, cohortRepo: cohortRepo, cohortUseCase: cohortUseCase, participantUseCase: participantUseCase}
+}
@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"bytes"
+	"instructor-led-app/entity"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/repository"
+	"instructor-led-app/shared/apperror"
+	"instructor-led-app/shared/service"
+	"io"
+	"path/filepath"
+)
+
+type QuestionAttachmentUseCase interface {
+	CheckUploadAccess(questionId, userID, kind string) (entity.Question, error)
+	AddAttachment(payload entity.QuestionAttachment, file dto.UploadFileDTO) (entity.QuestionAttachment, error)
+	FindAttachments(questionId, userID, role string) ([]entity.QuestionAttachment, error)
+	OpenAttachment(id, userID, role string) (io.ReadCloser, entity.QuestionAttachment, error)
+}
+
+type questionAttachmentUseCase struct {
+	repo               repository.QuestionAttachmentRepository
+	questionRepo       repository.QuestionRepository
+	scheduleRepo       repository.ScheduleRepository
+	trainerUseCase     TrainerUsecase
+	participantUseCase ParticipantUseCase
+	storage            service.FileStorage
+}
+
+// CheckUploadAccess implements QuestionAttachmentUseCase.
+// Only the asker attaches to a question and only the schedule's trainer attaches to its answer.
+func (q *questionAttachmentUseCase) CheckUploadAccess(questionId, userID, kind string) (entity.Question, error) {
+	question, err := q.questionRepo.Get(questionId)
+	if err != nil {
+		return entity.Question{}, apperror.Lookup(err, "question with ID %s not found", questionId)
+	}
+
+	switch kind {
+	case "question":
+		if q.isAsker(question, userID) {
+			return question, nil
+		}
+	case "answer":
+		if q.isScheduleTrainer(question, userID) {
+			return question, nil
+		}
+	default:
+		return entity.Question{}, apperror.BadRequest("unknown attachment kind %s", kind)
+	}
+	return entity.Question{}, apperror.Forbidden("you can't attach files to this %s", kind)
+}
+
+// AddAttachment implements QuestionAttachmentUseCase.
+func (q *questionAttachmentUseCase) AddAttachment(payload entity.QuestionAttachment, file dto.UploadFileDTO) (entity.QuestionAttachment, error) {
+	if _, err := q.CheckUploadAccess(payload.QuestionID, payload.UploadedBy, payload.Kind); err != nil {
+		return entity.QuestionAttachment{}, err
+	}
+	key, _ := service.ContentKey("attachments", file.Content, filepath.Ext(file.FileName))
+	if err := q.storage.Put(key, bytes.NewReader(file.Content), int64(len(file.Content)), file.ContentType); err != nil {
+		return entity.QuestionAttachment{}, apperror.Wrap(err, "failed to store file")
+	}
+	payload.OriginalName, payload.StorageKey = file.FileName, key
+	payload.Size, payload.MimeType = int64(len(file.Content)), file.ContentType
+	attachment, err := q.repo.Create(payload)
+	if err != nil {
+		return entity.QuestionAttachment{}, apperror.Wrap(err, "failed to save attachment")
+	}
+	return attachment, nil
+}
+
+// FindAttachments implements QuestionAttachmentUseCase.
+func (q *questionAttachmentUseCase) FindAttachments(questionId, userID, role string) ([]entity.QuestionAttachment, error) {
+	question, err := q.questionRepo.Get(questionId)
+	if err != nil {
+		return nil, apperror.Lookup(err, "question with ID %s not found", questionId)
+	}
+	if !q.canAccess(question, userID, role) {
+		return nil, apperror.Forbidden("you don't have access to this question")
+	}
+	return q.repo.ListByQuestionID(questionId)
+}
+
+// OpenAttachment implements QuestionAttachmentUseCase.
+func (q *questionAttachmentUseCase) OpenAttachment(id, userID, role string) (io.ReadCloser, entity.QuestionAttachment, error) {
+	attachment, err := q.repo.Get(id)
+	if err != nil {
+		return nil, entity.QuestionAttachment{}, apperror.Lookup(err, "attachment with ID %s not found", id)
+	}
+	question, err := q.questionRepo.Get(attachment.QuestionID)
+	if err != nil {
+		return nil, entity.QuestionAttachment{}, apperror.Lookup(err, "question with ID %s not found", attachment.QuestionID)
+	}
+	if !q.canAccess(question, userID, role) {
+		return nil, entity.QuestionAttachment{}, apperror.Forbidden("you don't have access to this attachment")
+	}
+	body, err := q.storage.Get(attachment.StorageKey)
+	if err != nil {
+		return nil, entity.QuestionAttachment{}, apperror.Lookup(err, "file not found")
+	}
+	return body, attachment, nil
+}
+
+// canAccess allows the asker, the schedule's trainer and admins.
+func (q *questionAttachmentUseCase) canAccess(question entity.Question, userID, role string) bool {
+	switch role {
+	case "admin":
+		return true
+	case "trainer":
+		return q.isScheduleTrainer(question, userID)
+	case "participant":
+		return q.isAsker(question, userID)
+	}
+	return false
+}
+
+func (q *questionAttachmentUseCase) isAsker(question entity.Question, userID string) bool {
+	participant, err := q.participantUseCase.GetParticipantByUserId(userID)
+	return err == nil && participant.ID == question.ParticipantID
+}
+
+func (q *questionAttachmentUseCase) isScheduleTrainer(question entity.Question, userID string) bool {
+	trainer, err := q.trainerUseCase.FindTrainerByUserId(userID)
+	if err != nil {
+		return false
+	}
+	schedule, err := q.scheduleRepo.Get(question.ScheduleID)
+	return err == nil && schedule.TrainerID == trainer.ID
+}
+
+func NewQuestionAttachmentUseCase(repo repository.QuestionAttachmentRepository, questionRepo repository.QuestionRepository, scheduleRepo repository.ScheduleRepository, trainerUseCase TrainerUsecase, participantUseCase ParticipantUseCase, storage service.FileStorage) QuestionAttachmentUseCase {
+	return &questionAttachmentUseCase{repo: repo, questionRepo: questionRepo, scheduleRepo: scheduleRepo, trainerUseCase: trainerUseCase, participantUseCase: participantUseCase, storage: storage}
+}
@@ -5,22 +5,28 @@ import (
 	"instructor-led-app/entity"
 	"instructor-led-app/entity/dto"
 	"instructor-led-app/repository"
+	"instructor-led-app/shared/apperror"
 	"instructor-led-app/shared/model"
+	"instructor-led-app/shared/query"
+	"instructor-led-app/shared/service"
 	"time"
 
 	"github.com/sirupsen/logrus"
 )
 
 type QuestionUseCase interface {
-	FindById(id string) (entity.Question, error)
-	FindAllQuestion(page, size int) ([]entity.Question, model.Paging, error)
+	FindById(id, userID, role string) (entity.Question, error)
+	FindAllQuestion(spec query.Spec, userID, role string) ([]entity.Question, model.Paging, error)
 	DeleteQuestion(id string) error
 	CreateNewQuestion(payload entity.Question) (entity.Question, error)
-	UpdateQuestion(id string, payload entity.Question) (entity.Question, error)
+	UpdateQuestion(id, userID, role string, payload dto.QuestionStatusDTO) (entity.Question, error)
 	FindQuestionByTrainerId(userID string, page, size int) ([]entity.Question, model.Paging, error)
 	CreateQuestionByTrainer(trainerId, participantId string, payload dto.QuestionDTO) (dto.QuestionDTO, error)
 	UpdatadStatusQuestionByTrainer(trainerId, participantId string, payload dto.QuestionDTO) (dto.QuestionDTO, error)
 	CreateQuestionByParticipant(participantId string, payload dto.QuestionDto) (dto.QuestionDto, error)
+	VoteQuestion(questionId, userID string) (dto.QuestionVoteDTO, error)
+	UnvoteQuestion(questionId, userID string) (dto.QuestionVoteDTO, error)
+	FindSessionQuestions(scheduleId, userID, role, sortBy string) ([]entity.Question, error)
 }
 
 type questionUseCase struct {
@@ -31,6 +37,93 @@ type questionUseCase struct {
 	trainerRepo     repository.TrainerRepository
 	participantUC   ParticipantUseCase
 	trainerUseCase  TrainerUsecase
+	scheduleUC      ScheduleUseCase
+	eventBroker     service.EventBroker
+}
+
+// VoteQuestion implements QuestionUseCase.
+func (q *questionUseCase) VoteQuestion(questionId, userID string) (dto.QuestionVoteDTO, error) {
+	question, participant, err := q.votableQuestion(questionId, userID)
+	if err != nil {
+		return dto.QuestionVoteDTO{}, err
+	}
+
+	votes, err := q.repo.Vote(question.ID, participant.ID)
+	if err != nil {
+		return dto.QuestionVoteDTO{}, apperror.Wrap(err, "failed to vote question")
+	}
+	vote := dto.QuestionVoteDTO{QuestionID: question.ID, Votes: votes}
+	q.eventBroker.Publish(question.ScheduleID, service.EventQuestionVoted, vote)
+	return vote, nil
+}
+
+// UnvoteQuestion implements QuestionUseCase.
+func (q *questionUseCase) UnvoteQuestion(questionId, userID string) (dto.QuestionVoteDTO, error) {
+	question, participant, err := q.votableQuestion(questionId, userID)
+	if err != nil {
+		return dto.QuestionVoteDTO{}, err
+	}
+
+	votes, err := q.repo.Unvote(question.ID, participant.ID)
+	if err != nil {
+		return dto.QuestionVoteDTO{}, apperror.Wrap(err, "failed to remove vote")
+	}
+	vote := dto.QuestionVoteDTO{QuestionID: question.ID, Votes: votes}
+	q.eventBroker.Publish(question.ScheduleID, service.EventQuestionVoted, vote)
+	return vote, nil
+}
+
+// votableQuestion checks the voter attends the question's session and isn't the asker.
+func (q *questionUseCase) votableQuestion(questionId, userID string) (entity.Question, dto.ParticipantDTO, error) {
+	participant, err := q.participantUC.GetParticipantByUserId(userID)
+	if err != nil {
+		return entity.Question{}, dto.ParticipantDTO{}, apperror.Wrap(err, "failed to get participant")
+	}
+	question, err := q.repo.Get(questionId)
+	if err != nil {
+		return entity.Question{}, dto.ParticipantDTO{}, apperror.Lookup(err, "question with ID %s not found", questionId)
+	}
+	if question.ParticipantID == participant.ID {
+		return entity.Question{}, dto.ParticipantDTO{}, apperror.Forbidden("you can't vote your own question")
+	}
+	inSession, err := q.scheduleRepo.IsParticipantInSession(question.ScheduleID, participant.ID)
+	if err != nil || !inSession {
+		return entity.Question{}, dto.ParticipantDTO{}, apperror.Forbidden("you can only vote questions in your own session")
+	}
+	return question, participant, nil
+}
+
+// FindSessionQuestions implements QuestionUseCase.
+// sortBy "votes" puts the most voted questions first, anything else keeps asking order.
+func (q *questionUseCase) FindSessionQuestions(scheduleId, userID, role, sortBy string) ([]entity.Question, error) {
+	if _, err := q.scheduleUC.FindScheduleForUser(scheduleId, userID, role); err != nil {
+		return nil, err
+	}
+
+	questions, err := q.repo.ListBySession(scheduleId, sortBy == "votes")
+	if err != nil {
+		return nil, apperror.Wrap(err, "failed to get session questions")
+	}
+	return q.hideAnonymousAsker(questions, userID, role), nil
+}
+
+// hideAnonymousAsker blanks the asker of anonymous questions for everyone but admins and the asker.
+func (q *questionUseCase) hideAnonymousAsker(questions []entity.Question, userID, role string) []entity.Question {
+	if role == "admin" {
+		return questions
+	}
+	viewerId := ""
+	if role == "participant" {
+		if participant, err := q.participantUC.GetParticipantByUserId(userID); err == nil {
+			viewerId = participant.ID
+		}
+	}
+	for i := range questions {
+		if questions[i].IsAnonymous && questions[i].ParticipantID != viewerId {
+			questions[i].ParticipantID = ""
+		}
+	}
+	return questions
 }
 
 // CreateQuestionByParticipant implements QuestionUseCase.
@@ -45,13 +138,13 @@ func (q *questionUseCase) UpdatadStatusQuestionByTrainer(trainerId string, parti
 	var day time.Time
 	scheduleIDs, err := q.scheduleRepo.GetScheduleByTrainerIdWithoutPagination(trainerId)
 	if err != nil {
-		return dto.QuestionDTO{}, fmt.Errorf("Field can't be empty")
+		return dto.QuestionDTO{}, apperror.Wrap(err, "failed to get trainer schedules")
 	}
 	for _, scheduleId := range scheduleIDs {
 		date, err := q.scheduleRepo.GetDateByScheduleId(scheduleId)
 		if err != nil {
 			fmt.Println("error tidak dapat menemukan tanggal")
-			return dto.QuestionDTO{}, fmt.Errorf("Field can't be empty")
+			return dto.QuestionDTO{}, apperror.Wrap(err, "failed to get schedule date")
 		}
 		dates = append(dates, date)
 	}
@@ -64,11 +157,6 @@ func (q *questionUseCase) UpdatadStatusQuestionByTrainer(trainerId string, parti
 			fmt.Println("Anda memiliki jadwal hari ini")
 		}
 	}
-	//validasi input payload
-	if payload.Answer == "" || payload.Status == "" {
-		return dto.QuestionDTO{}, fmt.Errorf("oops, Required field is empty")
-	}
-
 	schedule, _ := q.scheduleRepo.GetScheduleIdByDate(day)
 	payload.ScheduleID = schedule
 	payload.TrainerID = trainerId
@@ -76,7 +164,7 @@ func (q *questionUseCase) UpdatadStatusQuestionByTrainer(trainerId string, parti
 	//ngecek apakah nama yang bertanya sudah berstatus answered atau belum
 	questionData, err := q.repo.GetQuestionByScheduleIdandParticipantId(schedule, participantId)
 	if err != nil {
-		return dto.QuestionDTO{}, fmt.Errorf("gagal mengambil data")
+		return dto.QuestionDTO{}, apperror.Wrap(err, "failed to get question")
 	}
 	if questionData.Answer != "" && questionData.Status != "" {
 		return dto.QuestionDTO{}, nil
@@ -86,8 +174,15 @@ func (q *questionUseCase) UpdatadStatusQuestionByTrainer(trainerId string, parti
 	logger.Infoln(questionData.ScheduleID)
 	data, err := q.repo.UpdateQuestionStatusByTrainer(participantId, payload)
 	if err != nil {
-		return dto.QuestionDTO{}, fmt.Errorf("gagal update")
+		return dto.QuestionDTO{}, apperror.Wrap(err, "failed to update question")
+	}
+	event := data
+	if question, err := q.repo.Get(data.ID); err != nil || question.IsAnonymous {
+		// the answer goes to everyone in the session, so an asker we can't vouch for stays hidden
+		event.ParticipantID = ""
+		event.ParticipantName = ""
 	}
+	q.eventBroker.Publish(schedule, service.EventQuestionAnswered, event)
 	return data, nil
 }
 
@@ -98,14 +193,14 @@ func (q *questionUseCase) CreateQuestionByTrainer(trainerId string, participantI
 	var day time.Time
 	scheduleIDs, err := q.scheduleRepo.GetScheduleByTrainerIdWithoutPagination(trainerId)
 	if err != nil {
-		return dto.QuestionDTO{}, fmt.Errorf("Field can't be empty")
+		return dto.QuestionDTO{}, apperror.Wrap(err, "failed to get trainer schedules")
 	}
 	fmt.Println(scheduleIDs)
 	for _, scheduleId := range scheduleIDs {
 		date, err := q.scheduleRepo.GetDateByScheduleId(scheduleId)
 		if err != nil {
 			fmt.Println("error tidak dapat menemukan tanggal")
-			return dto.QuestionDTO{}, fmt.Errorf("Field can't be empty")
+			return dto.QuestionDTO{}, apperror.Wrap(err, "failed to get schedule date")
 		}
 		dates = append(dates, date)
 	}
@@ -117,14 +212,17 @@ func (q *questionUseCase) CreateQuestionByTrainer(trainerId string, participantI
 			day = scheduleDate
 		}
 	}
-	//validasi input payload
-	if payload.Question == "" || payload.Answer == "" || payload.Status == "" {
-		return dto.QuestionDTO{}, fmt.Errorf("oops, Required field is empty")
+	if payload.Question == "" {
+		return dto.QuestionDTO{}, apperror.Invalid("question", "question is required")
 	}
 	schedule, _ := q.scheduleRepo.GetScheduleIdByDate(day)
 	payload.ScheduleID = schedule
 	payload.TrainerID = trainerId
 	data, err := q.repo.CreateQuestionByTrainer(participantId, payload)
+	if err != nil {
+		return dto.QuestionDTO{}, apperror.Wrap(err, "failed to create question")
+	}
+	q.eventBroker.Publish(schedule, service.EventQuestionCreated, data)
 	return data, nil
 
 }
@@ -135,16 +233,16 @@ func (q *questionUseCase) CreateQuestionByParticipant(participantId string, payl
 	var day time.Time
 	scheduleIDs, err := q.scheduleRepo.GetScheduleWithParticipantId(participantId)
 	if err != nil {
-		return dto.QuestionDto{}, fmt.Errorf("field can't be empty")
+		return dto.QuestionDto{}, apperror.Wrap(err, "failed to get participant schedules")
 	}
 	if scheduleIDs == nil {
-		return dto.QuestionDto{}, fmt.Errorf("received nil schedule IDs")
+		return dto.QuestionDto{}, apperror.NotFound("no schedule found for the participant")
 	}
 	for _, scheduleId := range scheduleIDs {
 		date, err := q.scheduleRepo.GetDateByScheduleId(scheduleId.ID)
 		if err != nil {
 			fmt.Println("error tidak dapat menemukan tanggal")
-			return dto.QuestionDto{}, fmt.Errorf("field can't be empty")
+			return dto.QuestionDto{}, apperror.Wrap(err, "failed to get schedule date")
 		}
 		dates = append(dates, date)
 	}
@@ -156,21 +254,22 @@ func (q *questionUseCase) CreateQuestionByParticipant(participantId string, payl
 			day = scheduleDate
 		}
 	}
-	//validasi input payload
-	if payload.Question == "" {
-		return dto.QuestionDto{}, fmt.Errorf("oops, Required field is empty")
-	}
 	schedule, err := q.scheduleRepo.GetScheduleIdByDate(day)
 	if err != nil {
-		return dto.QuestionDto{}, fmt.Errorf("Failed to get schedule ID for the current date: %v", err)
+		return dto.QuestionDto{}, apperror.Wrap(err, "failed to get schedule ID for the current date")
 	}
 	payload.ScheduleID = schedule
 
 	// Create question
 	data, err := q.repo.CreateQuestionByParticipant(participantId, payload)
 	if err != nil {
-		return dto.QuestionDto{}, fmt.Errorf("Failed to create question")
+		return dto.QuestionDto{}, apperror.Wrap(err, "failed to create question")
 	}
+	event := data
+	if event.IsAnonymous {
+		event.ParticipantID = ""
+	}
+	q.eventBroker.Publish(schedule, service.EventQuestionCreated, event)
 
 	return data, nil
 }
@@ -180,55 +279,63 @@ func (q *questionUseCase) FindQuestionByTrainerId(id string, page, size int) ([]
 	question, paging, err := q.repo.GetQuestionByTrainerId(id, page, size)
 
 	if err != nil {
-		return []entity.Question{}, model.Paging{}, fmt.Errorf("failed to get question's : %s", err.Error())
+		return []entity.Question{}, model.Paging{}, apperror.Wrap(err, "failed to get questions")
 
 	}
 
 	if len(question) == 0 {
-		return []entity.Question{}, model.Paging{}, fmt.Errorf("no question found for the trainer")
+		return []entity.Question{}, model.Paging{}, apperror.NotFound("no question found for the trainer")
 	}
 
-	return question, paging, nil
+	return q.hideAnonymousAsker(question, "", "trainer"), paging, nil
 }
 
-func (q *questionUseCase) FindById(id string) (entity.Question, error) {
-	return q.repo.Get(id)
+func (q *questionUseCase) FindById(id, userID, role string) (entity.Question, error) {
+	question, err := q.repo.Get(id)
+	if err != nil {
+		return entity.Question{}, err
+	}
+	return q.hideAnonymousAsker([]entity.Question{question}, userID, role)[0], nil
 }
 
-func (q *questionUseCase) FindAllQuestion(page, size int) ([]entity.Question, model.Paging, error) {
-	return q.repo.List(page, size)
+func (q *questionUseCase) FindAllQuestion(spec query.Spec, userID, role string) ([]entity.Question, model.Paging, error) {
+	questions, paging, err := q.repo.List(spec)
+	if err != nil {
+		return nil, model.Paging{}, err
+	}
+	return q.hideAnonymousAsker(questions, userID, role), paging, nil
 }
 
 func (q *questionUseCase) DeleteQuestion(id string) error {
 	return q.repo.Delete(id)
 }
 
-func (q *questionUseCase) UpdateQuestion(id string, payload entity.Question) (entity.Question, error) {
-	payload.UpdatedAt = time.Now()
-	question, err := q.repo.Update(id, payload)
-	if err != nil {
-		return entity.Question{}, fmt.Errorf("failed to update question : %s", err.Error())
+func (q *questionUseCase) UpdateQuestion(id, userID, role string, payload dto.QuestionStatusDTO) (entity.Question, error) {
+	if err := q.repo.Update(id, payload.Status, time.Now()); err != nil {
+		return entity.Question{}, apperror.Wrap(err, "failed to update question")
 	}
-	return question, nil
+	return q.FindById(id, userID, role)
 }
 
 func (q *questionUseCase) CreateNewQuestion(payload entity.Question) (entity.Question, error) {
 	_, err := q.participantUC.GetParticipantByID(payload.ParticipantID)
 	if err != nil {
-		return entity.Question{}, fmt.Errorf("participant with ID %s not found", payload.ParticipantID)
-	}
-	if payload.Question == "" {
-		return entity.Question{}, fmt.Errorf("question can't be empty")
+		return entity.Question{}, apperror.Lookup(err, "participant with ID %s not found", payload.ParticipantID)
 	}
 	payload.UpdatedAt = time.Now()
 	question, err := q.repo.Create(payload)
 	if err != nil {
-		return entity.Question{}, fmt.Errorf("failed to create new question: %s", err.Error())
+		return entity.Question{}, apperror.Wrap(err, "failed to create new question")
 
 	}
+	event := question
+	if event.IsAnonymous {
+		event.ParticipantID = ""
+	}
+	q.eventBroker.Publish(question.ScheduleID, service.EventQuestionCreated, event)
 	return question, nil
 }
 
-func NewQuestionUseCase(repo repository.QuestionRepository, participantRepo repository.ParticipantRepository, scheduleRepo repository.ScheduleRepository, userRepo repository.UserRepository, trainerRepo repository.TrainerRepository, participantUC ParticipantUseCase, trainerUC TrainerUsecase) QuestionUseCase {
-	return &questionUseCase{repo: repo, participantRepo: participantRepo, scheduleRepo: scheduleRepo, userRepo: userRepo, trainerRepo: trainerRepo, participantUC: participantUC, trainerUseCase: trainerUC}
+func NewQuestionUseCase(repo repository.QuestionRepository, participantRepo repository.ParticipantRepository, scheduleRepo repository.ScheduleRepository, userRepo repository.UserRepository, trainerRepo repository.TrainerRepository, participantUC ParticipantUseCase, trainerUC TrainerUsecase, scheduleUC ScheduleUseCase, eventBroker service.EventBroker) QuestionUseCase {
+	return &questionUseCase{repo: repo, participantRepo: participantRepo, scheduleRepo: scheduleRepo, userRepo: userRepo, trainerRepo: trainerRepo, participantUC: participantUC, trainerUseCase: trainerUC, scheduleUC: scheduleUC, eventBroker: eventBroker}
 }
@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"instructor-led-app/config"
+	"instructor-led-app/entity"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/repository"
+	"instructor-led-app/shared/apperror"
+	"instructor-led-app/shared/model"
+	"instructor-led-app/shared/query"
+	"instructor-led-app/shared/service"
+	"log"
+	"strings"
+	"time"
+)
+
+type RegistrationUseCase interface {
+	Register(payload dto.RegistrationDTO) (entity.Registration, error)
+	ResendVerification(email string) error
+	VerifyEmail(token string) (entity.Registration, error)
+	FindRegistrations(spec query.Spec) ([]entity.Registration, model.Paging, error)
+	ReviewRegistration(id, reviewerUserID string, payload dto.RegistrationReviewDTO) (entity.Registration, error)
+}
+
+type registrationUseCase struct {
+	repo               repository.RegistrationRepository
+	mailer             service.Mailer
+	registrationConfig config.RegistrationConfig
+}
+
+// Register implements RegistrationUseCase. Only participants can sign up themselves; the account
+// stays unverified until the emailed token comes back.
+func (r *registrationUseCase) Register(payload dto.RegistrationDTO) (entity.Registration, error) {
+	payload.Name = strings.TrimSpace(payload.Name)
+	payload.Email = strings.ToLower(strings.TrimSpace(payload.Email))
+	payload.Username = strings.TrimSpace(payload.Username)
+	if payload.Name == "" || payload.Email == "" || payload.Username == "" || payload.Password == "" {
+		return entity.Registration{}, apperror.BadRequest("name, email, username and password are required")
+	}
+
+	taken, err := r.repo.IdentityTaken(payload.Email, payload.Username)
+	if err != nil {
+		return entity.Registration{}, apperror.Wrap(err, "failed to check registration")
+	}
+	if taken {
+		return entity.Registration{}, apperror.Conflict("email or username is already registered")
+	}
+
+	token, tokenHash, err := newVerificationToken()
+	if err != nil {
+		return entity.Registration{}, err
+	}
+	registration, err := r.repo.Create(entity.Registration{
+		Name:          payload.Name,
+		Email:         payload.Email,
+		Username:      payload.Username,
+		Address:       strings.TrimSpace(payload.Address),
+		DateOfBirth:   payload.DateOfBirth,
+		PlaceOfBirth:  strings.TrimSpace(payload.PlaceOfBirth),
+		LastEducation: strings.TrimSpace(payload.LastEducation),
+	}, payload.Password, tokenHash, time.Now().Add(r.registrationConfig.TokenTTL))
+	if err != nil {
+		return entity.Registration{}, apperror.Wrap(err, "failed to save registration")
+	}
+
+	if err := r.sendVerification(registration, token); err != nil {
+		return entity.Registration{}, err
+	}
+	return registration, nil
+}
+
+// ResendVerification implements RegistrationUseCase. Unknown addresses are ignored so the
+// endpoint can't be used to find out who registered.
+func (r *registrationUseCase) ResendVerification(email string) error {
+	registration, err := r.repo.GetUnverified(strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return apperror.Wrap(err, "failed to get registration")
+	}
+
+	token, tokenHash, err := newVerificationToken()
+	if err != nil {
+		return err
+	}
+	if err := r.repo.RefreshToken(registration.ID, tokenHash, time.Now().Add(r.registrationConfig.TokenTTL)); err != nil {
+		return apperror.Wrap(err, "failed to refresh verification")
+	}
+	return r.sendVerification(registration, token)
+}
+
+// VerifyEmail implements RegistrationUseCase. A verified registration waits for an admin in the approval queue.
+func (r *registrationUseCase) VerifyEmail(token string) (entity.Registration, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return entity.Registration{}, apperror.Invalid("token", "token is required")
+	}
+	id, err := r.repo.Verify(service.Checksum([]byte(token)))
+	if err != nil {
+		return entity.Registration{}, apperror.BadRequest("verification token is invalid or has expired")
+	}
+	return r.repo.Get(id)
+}
+
+// FindRegistrations implements RegistrationUseCase.
+func (r *registrationUseCase) FindRegistrations(spec query.Spec) ([]entity.Registration, model.Paging, error) {
+	return r.repo.List(spec)
+}
+
+// ReviewRegistration implements RegistrationUseCase. Approving needs the track the participant starts
+// in; rejecting needs a reason. Either way the registrant is told by email.
+func (r *registrationUseCase) ReviewRegistration(id, reviewerUserID string, payload dto.RegistrationReviewDTO) (entity.Registration, error) {
+	payload.Reason = strings.TrimSpace(payload.Reason)
+	if payload.Status == "Rejected" && payload.Reason == "" {
+		return entity.Registration{}, apperror.Invalid("reason", "reason is required when rejecting a registration")
+	}
+
+	registration, err := r.repo.Get(id)
+	if err != nil {
+		return entity.Registration{}, apperror.Lookup(err, "registration not found")
+	}
+	if registration.Status != "Pending" {
+		if registration.Status == "Unverified" {
+			return entity.Registration{}, apperror.Conflict("registration email is not verified yet")
+		}
+		return entity.Registration{}, apperror.Conflict("registration was already %s", strings.ToLower(registration.Status))
+	}
+
+	if payload.Status == "Approved" {
+		err = r.repo.Approve(id, payload.Track, payload.Reason, reviewerUserID, time.Now())
+	} else {
+		err = r.repo.Reject(id, payload.Reason, reviewerUserID, time.Now())
+	}
+	if err != nil {
+		return entity.Registration{}, apperror.Wrap(err, "failed to review registration")
+	}
+
+	subject, body := "Your registration was approved",
+		fmt.Sprintf("Hi %s,\n\nYour registration was approved for the %s track. You can now log in as %s.", registration.Name, payload.Track, registration.Username)
+	if payload.Status == "Rejected" {
+		subject, body = "Your registration was not approved",
+			fmt.Sprintf("Hi %s,\n\nYour registration was not approved: %s", registration.Name, payload.Reason)
+	}
+	if err := r.mailer.Send(registration.Email, subject, body); err != nil {
+		log.Println("registrationUseCase.ReviewRegistration: failed to notify registrant:", err.Error())
+	}
+	return r.repo.Get(id)
+}
+
+func (r *registrationUseCase) sendVerification(registration entity.Registration, token string) error {
+	link := token
+	if r.registrationConfig.VerifyURL != "" {
+		separator := "?"
+		if strings.Contains(r.registrationConfig.VerifyURL, "?") {
+			separator = "&"
+		}
+		link = r.registrationConfig.VerifyURL + separator + "token=" + token
+	}
+	body := fmt.Sprintf("Hi %s,\n\nPlease verify your email to finish registering: %s\n\nThis link expires in %s.",
+		registration.Name, link, r.registrationConfig.TokenTTL)
+	if err := r.mailer.Send(registration.Email, "Verify your email", body); err != nil {
+		return apperror.Wrap(err, "failed to send verification email")
+	}
+	return nil
+}
+
+// newVerificationToken returns the token to mail and the hash to store, so a leaked table can't verify anyone.
+func newVerificationToken() (string, string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", apperror.Wrap(err, "failed to generate verification token")
+	}
+	token := hex.EncodeToString(raw)
+	return token, service.Checksum([]byte(token)), nil
+}
+
+func NewRegistrationUseCase(repo repository.RegistrationRepository, mailer service.Mailer, registrationConfig config.RegistrationConfig) RegistrationUseCase {
+	return &registrationUseCase{repo: repo, mailer: mailer, registrationConfig: registrationConfig}
+}
@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"encoding/csv"
+	"instructor-led-app/config"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/repository"
+	"instructor-led-app/shared/apperror"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+type ReportUseCase interface {
+	TrainerWorkload(filter dto.TrainerWorkloadFilterDTO) (dto.TrainerWorkloadReportDTO, error)
+	WriteTrainerWorkloadCSV(report dto.TrainerWorkloadReportDTO, w io.Writer) error
+	FindTrainerRates() ([]dto.TrainerRateDTO, error)
+	SaveTrainerRate(trainerId string, payload dto.TrainerRateDTO) (dto.TrainerRateDTO, error)
+	DeleteTrainerRate(trainerId string) error
+}
+
+type reportUseCase struct {
+	repo             repository.ReportRepository
+	trainerUseCase   TrainerUsecase
+	sessionConfig    config.SessionConfig
+	honorariumConfig config.HonorariumConfig
+}
+
+// TrainerWorkload implements ReportUseCase.
+// Only sessions with an approved proof image count as delivered and are paid,
+// at the trainer's own rate or the configured default one.
+func (r *reportUseCase) TrainerWorkload(filter dto.TrainerWorkloadFilterDTO) (dto.TrainerWorkloadReportDTO, error) {
+	startDate, endDate, err := parseDateRange(filter.StartDate, filter.EndDate)
+	if err != nil {
+		return dto.TrainerWorkloadReportDTO{}, err
+	}
+	if startDate.IsZero() {
+		now := time.Now()
+		startDate = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	if endDate.IsZero() {
+		endDate = startDate.AddDate(0, 1, -1)
+	}
+
+	start, end, err := r.sessionConfig.Window(startDate)
+	if err != nil {
+		return dto.TrainerWorkloadReportDTO{}, err
+	}
+	sessionHours := end.Sub(start).Hours()
+
+	workloads, err := r.repo.TrainerWorkload(startDate, endDate, filter.TrainerID)
+	if err != nil {
+		return dto.TrainerWorkloadReportDTO{}, apperror.Wrap(err, "failed to get trainer workload")
+	}
+
+	report := dto.TrainerWorkloadReportDTO{StartDate: startDate, EndDate: endDate, Trainers: []dto.TrainerWorkloadDTO{}}
+	for _, workload := range workloads {
+		if !workload.CustomRate {
+			workload.RatePerSession = r.honorariumConfig.SessionRate
+		}
+		workload.TeachingHours = roundTo(float64(workload.SessionsDelivered)*sessionHours, 2)
+		workload.PayableAmount = roundTo(float64(workload.SessionsDelivered)*workload.RatePerSession, 2)
+		report.Total += workload.PayableAmount
+		report.Trainers = append(report.Trainers, workload)
+	}
+	report.Total = roundTo(report.Total, 2)
+	return report, nil
+}
+
+// WriteTrainerWorkloadCSV implements ReportUseCase.
+func (r *reportUseCase) WriteTrainerWorkloadCSV(report dto.TrainerWorkloadReportDTO, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	header := []string{"Trainer ID", "Trainer Name", "Period Start", "Period End", "Sessions Scheduled", "Sessions Delivered",
+		"Attendance Sessions", "Attendance Records", "Questions Answered", "Teaching Hours", "Rate Per Session", "Payable Amount"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, t := range report.Trainers {
+		record := []string{
+			t.TrainerID,
+			t.TrainerName,
+			report.StartDate.Format("2006-01-02"),
+			report.EndDate.Format("2006-01-02"),
+			strconv.Itoa(t.SessionsScheduled),
+			strconv.Itoa(t.SessionsDelivered),
+			strconv.Itoa(t.AttendanceSessions),
+			strconv.Itoa(t.AttendanceRecords),
+			strconv.Itoa(t.QuestionsAnswered),
+			strconv.FormatFloat(t.TeachingHours, 'f', 2, 64),
+			strconv.FormatFloat(t.RatePerSession, 'f', 2, 64),
+			strconv.FormatFloat(t.PayableAmount, 'f', 2, 64),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// FindTrainerRates implements ReportUseCase.
+func (r *reportUseCase) FindTrainerRates() ([]dto.TrainerRateDTO, error) {
+	return r.repo.ListTrainerRates()
+}
+
+// SaveTrainerRate implements ReportUseCase.
+func (r *reportUseCase) SaveTrainerRate(trainerId string, payload dto.TrainerRateDTO) (dto.TrainerRateDTO, error) {
+	if trainers, err := r.trainerUseCase.FindTrainerById(trainerId); err != nil || len(trainers) == 0 {
+		return dto.TrainerRateDTO{}, apperror.Lookup(err, "trainer with ID %s not found", trainerId)
+	}
+
+	now := time.Now()
+	if err := r.repo.SaveTrainerRate(trainerId, payload.RatePerSession, now); err != nil {
+		return dto.TrainerRateDTO{}, apperror.Wrap(err, "failed to save rate")
+	}
+	return dto.TrainerRateDTO{TrainerID: trainerId, RatePerSession: payload.RatePerSession, UpdatedAt: now}, nil
+}
+
+// DeleteTrainerRate implements ReportUseCase. The trainer falls back to the default rate.
+func (r *reportUseCase) DeleteTrainerRate(trainerId string) error {
+	return r.repo.DeleteTrainerRate(trainerId)
+}
+
+func roundTo(value float64, places int) float64 {
+	shift := math.Pow(10, float64(places))
+	return math.Round(value*shift) / shift
+}
+
+func NewReportUseCase(repo repository.ReportRepository, trainerUseCase TrainerUsecase, sessionConfig config.SessionConfig, honorariumConfig config.HonorariumConfig) ReportUseCase {
+	return &reportUseCase{repo: repo, trainerUseCase: trainerUseCase, sessionConfig: sessionConfig, honorariumConfig: honorariumConfig}
+}
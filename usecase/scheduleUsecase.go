@@ -1,27 +1,65 @@
 package usecase
 
 import (
-	"fmt"
 	"instructor-led-app/entity"
 	"instructor-led-app/entity/dto"
 	"instructor-led-app/repository"
+	"instructor-led-app/shared/apperror"
 	"instructor-led-app/shared/model"
+	"instructor-led-app/shared/query"
 	"time"
 )
 
 type ScheduleUseCase interface {
 	InsertNewSchedule(payload dto.ScheduleDto) (dto.ScheduleDto, error)
-	FindAllSchedule(startDate, endDate time.Time, page, size int) ([]entity.Schedule, model.Paging, error)
+	FindAllSchedule(spec query.Spec) ([]entity.Schedule, model.Paging, error)
 	GetScheduleByParticipantID(id string, page, size int) ([]entity.Schedule, model.Paging, error)
 	GetScheduleWithParticipantId(id string) ([]string, error)
 	GetScheduleByTrainerID(userID string, page, size int) ([]entity.Schedule, model.Paging, error)
 	UpdateScheduleByAdmin(trainerId string, code int) ([]entity.Schedule, error)
 	DeleteScheduleByDate(date string) error
+	FindScheduleForUser(id, userID, role string) (entity.Schedule, error)
+	AssignLesson(id string, payload dto.ScheduleLessonDTO) (entity.Schedule, error)
 }
 
 type scheduleUseCase struct {
-	repo           repository.ScheduleRepository
-	trainerUseCase TrainerUsecase
+	repo               repository.ScheduleRepository
+	trainerUseCase     TrainerUsecase
+	participantUseCase ParticipantUseCase
+	availabilityUC     AvailabilityUseCase
+	cohortRepo         repository.CohortRepository
+	curriculumRepo     repository.CurriculumRepository
+}
+
+// FindScheduleForUser implements ScheduleUseCase.
+// Admins see every schedule, trainers only their own and participants the sessions they attend.
+func (s *scheduleUseCase) FindScheduleForUser(id, userID, role string) (entity.Schedule, error) {
+	schedule, err := s.repo.Get(id)
+	if err != nil {
+		return entity.Schedule{}, apperror.Lookup(err, "schedule with ID %s not found", id)
+	}
+
+	switch role {
+	case "admin":
+		return schedule, nil
+	case "trainer":
+		trainer, err := s.trainerUseCase.FindTrainerByUserId(userID)
+		if err == nil && trainer.ID == schedule.TrainerID {
+			return schedule, nil
+		}
+	case "participant":
+		participant, err := s.participantUseCase.GetParticipantByUserId(userID)
+		if err != nil {
+			break
+		}
+		if participant.ID == schedule.ParticipantID {
+			return schedule, nil
+		}
+		if ok, _ := s.repo.IsParticipantInSession(schedule.ID, participant.ID); ok {
+			return schedule, nil
+		}
+	}
+	return entity.Schedule{}, apperror.Forbidden("you don't have access to schedule %s", id)
 }
 
 // GetScheduleWithParticipantId implements ScheduleUseCase.
@@ -38,24 +76,31 @@ func (s *scheduleUseCase) DeleteScheduleByDate(date string) error {
 func (s *scheduleUseCase) UpdateScheduleByAdmin(trainerId string, code int) ([]entity.Schedule, error) {
 	date, err := s.repo.GetDateByDay(code)
 	if err != nil {
-		return []entity.Schedule{}, fmt.Errorf("failed to get date: %v", err.Error())
+		return []entity.Schedule{}, apperror.Wrap(err, "failed to get date")
+	}
+
+	today := dateOnly(time.Now())
+	var upcoming []time.Time
+	for _, d := range date {
+		if !dateOnly(d).Before(today) {
+			upcoming = append(upcoming, d)
+		}
+	}
+	if err := s.availabilityUC.CheckTrainerAvailable(trainerId, upcoming); err != nil {
+		return []entity.Schedule{}, err
 	}
 
 	update, err := s.repo.UpdateScheduleByAdmin(trainerId, date)
 	if err != nil {
-		return []entity.Schedule{}, fmt.Errorf("failed to update :%v", err.Error())
+		return []entity.Schedule{}, apperror.Wrap(err, "failed to update schedule")
 	}
 
 	return update, nil
 }
 
 // FindAllSchedule implements ScheduleUseCase.
-func (s *scheduleUseCase) FindAllSchedule(startDate time.Time, endDate time.Time, page int, size int) ([]entity.Schedule, model.Paging, error) {
-	if !startDate.IsZero() && !endDate.IsZero() {
-		return s.repo.ListByDate(startDate, endDate, page, size)
-	} else {
-		return s.repo.List(page, size)
-	}
+func (s *scheduleUseCase) FindAllSchedule(spec query.Spec) ([]entity.Schedule, model.Paging, error) {
+	return s.repo.List(spec)
 }
 
 // GetScheduleByParticipantID implements ScheduleUseCase.
@@ -67,34 +112,88 @@ func (s *scheduleUseCase) GetScheduleByParticipantID(id string, page int, size i
 func (s *scheduleUseCase) GetScheduleByTrainerID(userID string, page int, size int) ([]entity.Schedule, model.Paging, error) {
 	trainer, err := s.trainerUseCase.FindTrainerByUserId(userID)
 	if err != nil {
-		return []entity.Schedule{}, model.Paging{}, fmt.Errorf("failed to get trainer: %v", err)
+		return []entity.Schedule{}, model.Paging{}, apperror.Wrap(err, "failed to get trainer")
 	}
 
 	schedule, paging, err := s.repo.GetScheduleByTrainerId(trainer.ID, page, size)
 	if err != nil {
-		return []entity.Schedule{}, model.Paging{}, fmt.Errorf("failed to get schedule's : %s", err.Error())
+		return []entity.Schedule{}, model.Paging{}, apperror.Wrap(err, "failed to get schedules")
 
 	}
 
 	if len(schedule) == 0 {
-		return []entity.Schedule{}, model.Paging{}, fmt.Errorf("no schedule found for the trainer")
+		return []entity.Schedule{}, model.Paging{}, apperror.NotFound("no schedule found for the trainer")
 	}
 	return schedule, paging, nil
 }
 
 // InsertNewSchedule implements ScheduleUseCase.
+// A schedule for a cohort is a single session row; every enrolled participant gets
+// an attendance row for it. Without a cohort the legacy per-participant row is kept.
+// A schedule that delivers a lesson takes the lesson title as activity when none is given.
 func (s *scheduleUseCase) InsertNewSchedule(payload dto.ScheduleDto) (dto.ScheduleDto, error) {
-	if payload.Activity == "" || payload.ParticipantID == "" || payload.TrainerID == "" {
-		return dto.ScheduleDto{}, fmt.Errorf("oops, Required field is empty")
+	if payload.LessonID != "" {
+		lesson, err := s.curriculumRepo.GetLesson(payload.LessonID)
+		if err != nil {
+			return dto.ScheduleDto{}, apperror.Lookup(err, "lesson with ID %s not found", payload.LessonID)
+		}
+		if payload.Activity == "" {
+			payload.Activity = lessonActivity(lesson.Title)
+		}
+	}
+	if payload.Activity == "" || payload.TrainerID == "" || (payload.ParticipantID == "" && payload.CohortID == "") {
+		return dto.ScheduleDto{}, apperror.BadRequest("activity, trainerId and participantId or cohortId are required")
+	}
+	if payload.Date == "" {
+		payload.Date = time.Now().Format("2006-01-02")
+	} else if _, err := time.Parse("2006-01-02", payload.Date); err != nil {
+		return dto.ScheduleDto{}, apperror.Invalid("date", "date must use the format YYYY-MM-DD")
 	}
-	payload.Date = time.Now().Format("2006-01-02")
+
+	if payload.CohortID != "" {
+		payload.ParticipantID = ""
+		if _, err := s.cohortRepo.Get(payload.CohortID); err != nil {
+			return dto.ScheduleDto{}, apperror.Lookup(err, "cohort with ID %s not found", payload.CohortID)
+		}
+	}
+
 	schedule, err := s.repo.Create(payload)
 	if err != nil {
-		return dto.ScheduleDto{}, fmt.Errorf("oppps, failed to save data absence :%v", err.Error())
+		return dto.ScheduleDto{}, apperror.Wrap(err, "failed to save schedule")
+	}
+	if schedule.CohortID != "" {
+		if err := s.cohortRepo.SeedSessionAttendance(schedule.ID); err != nil {
+			return dto.ScheduleDto{}, apperror.Wrap(err, "failed to prepare attendance")
+		}
 	}
 	return schedule, nil
 }
 
-func NewScheduleUseCase(repo repository.ScheduleRepository, trainerUsecae TrainerUsecase) ScheduleUseCase {
-	return &scheduleUseCase{repo: repo, trainerUseCase: trainerUsecae}
+// AssignLesson implements ScheduleUseCase. An empty lessonId unlinks the schedule from the curriculum.
+func (s *scheduleUseCase) AssignLesson(id string, payload dto.ScheduleLessonDTO) (entity.Schedule, error) {
+	if _, err := s.repo.Get(id); err != nil {
+		return entity.Schedule{}, apperror.Lookup(err, "schedule with ID %s not found", id)
+	}
+	if payload.LessonID != "" {
+		if _, err := s.curriculumRepo.GetLesson(payload.LessonID); err != nil {
+			return entity.Schedule{}, apperror.Lookup(err, "lesson with ID %s not found", payload.LessonID)
+		}
+	}
+	if err := s.curriculumRepo.AssignScheduleLesson(id, payload.LessonID, time.Now()); err != nil {
+		return entity.Schedule{}, apperror.Wrap(err, "failed to assign lesson")
+	}
+	return s.repo.Get(id)
+}
+
+// lessonActivity fits a lesson title into the activity column.
+func lessonActivity(title string) string {
+	runes := []rune(title)
+	if len(runes) > 45 {
+		runes = runes[:45]
+	}
+	return string(runes)
+}
+
+func NewScheduleUseCase(repo repository.ScheduleRepository, trainerUsecae TrainerUsecase, participantUseCase ParticipantUseCase, availabilityUC AvailabilityUseCase, cohortRepo repository.CohortRepository, curriculumRepo repository.CurriculumRepository) ScheduleUseCase {
+	return &scheduleUseCase{repo: repo, trainerUseCase: trainerUsecae, participantUseCase: participantUseCase, availabilityUC: availabilityUC, cohortRepo: cohortRepo, curriculumRepo: curriculumRepo}
 }
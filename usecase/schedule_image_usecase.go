@@ -1,76 +1,259 @@
 package usecase
 
 import (
+	"bytes"
 	"fmt"
+	"instructor-led-app/config"
 	"instructor-led-app/entity"
 	"instructor-led-app/entity/dto"
 	"instructor-led-app/repository"
-	"sort"
+	"instructor-led-app/shared/apperror"
+	"instructor-led-app/shared/model"
+	"instructor-led-app/shared/query"
+	"instructor-led-app/shared/service"
+	"io"
+	"log"
+	"math"
+	"strings"
 	"time"
 )
 
+const (
+	verificationMatch      = "match"
+	verificationMismatch   = "mismatch"
+	verificationNoMetadata = "no_metadata"
+)
+
 type ScheduleImageUseCase interface {
-	UploadImageActivity(userID, filename, startTimeString, endTimeString string) (dto.ScheduleImagesDTO, error)
+	UploadImageActivity(userID, scheduleID, caption string, file dto.UploadFileDTO) (dto.ScheduleImagesDTO, error)
+	FindActivityProofs(spec query.Spec) ([]dto.ScheduleImagesDTO, model.Paging, error)
+	FindTrainerActivityProofs(userID string, spec query.Spec) ([]dto.ScheduleImagesDTO, model.Paging, error)
+	FindActivityProof(id, userID, role string) (dto.ScheduleImagesDTO, error)
+	OpenActivityProofImage(id, userID, role string, thumbnail bool) (io.ReadCloser, dto.ScheduleImagesDTO, error)
+	ReviewActivityProof(id, reviewerUserID string, payload dto.ActivityProofReviewDTO) (dto.ScheduleImagesDTO, error)
+	DeleteActivityProof(id string) error
+	FindSessionsWithoutProof(spec query.Spec) ([]entity.Schedule, model.Paging, error)
 }
 
 type scheduleImageUseCase struct {
 	scheduleImageRepository repository.ScheduleImageRepository
 	scheduleUseCase         ScheduleUseCase
+	trainerUseCase          TrainerUsecase
+	storage                 service.FileStorage
+	imageProcessor          service.ImageProcessor
+	sessionConfig           config.SessionConfig
 }
 
-func (u *scheduleImageUseCase) UploadImageActivity(userID, filename, startTimeString, endTimeString string) (dto.ScheduleImagesDTO, error) {
+// UploadImageActivity stores a proof photo for one of the trainer's schedules, only while that session runs.
+func (u *scheduleImageUseCase) UploadImageActivity(userID, scheduleID, caption string, file dto.UploadFileDTO) (dto.ScheduleImagesDTO, error) {
+	if scheduleID == "" {
+		return dto.ScheduleImagesDTO{}, apperror.Invalid("scheduleId", "scheduleId is required")
+	}
 
-	schedules, _, err := u.scheduleUseCase.GetScheduleByTrainerID(userID, 1, 3)
+	schedule, err := u.scheduleUseCase.FindScheduleForUser(scheduleID, userID, "trainer")
 	if err != nil {
-		return dto.ScheduleImagesDTO{}, fmt.Errorf("failed to get trainer's schedule: %v", err)
+		return dto.ScheduleImagesDTO{}, err
+	}
+
+	start, end, err := u.sessionConfig.Window(schedule.Date)
+	if err != nil {
+		return dto.ScheduleImagesDTO{}, err
+	}
+	now := time.Now()
+	if now.Before(start) || now.After(end) {
+		return dto.ScheduleImagesDTO{}, apperror.BadRequest("proof images for this session can only be uploaded on %s between %s and %s",
+			start.Format("2006-01-02"), start.Format("15:04"), end.Format("15:04"))
+	}
+
+	sourceChecksum := service.Checksum(file.Content)
+	duplicate, err := u.scheduleImageRepository.ExistsBySourceChecksum(sourceChecksum)
+	if err != nil {
+		return dto.ScheduleImagesDTO{}, apperror.Wrap(err, "failed to check image")
+	}
+	if duplicate {
+		return dto.ScheduleImagesDTO{}, apperror.Conflict("this image has already been uploaded")
+	}
+
+	processed, err := u.imageProcessor.Process(file.Content)
+	if err != nil {
+		return dto.ScheduleImagesDTO{}, err
+	}
+
+	key, checksum := service.ContentKey("images", processed.Content, processed.Extension)
+	if err := u.storage.Put(key, bytes.NewReader(processed.Content), int64(len(processed.Content)), processed.MimeType); err != nil {
+		return dto.ScheduleImagesDTO{}, apperror.Wrap(err, "failed to store image")
 	}
+	thumbnailKey, _ := service.ContentKey("thumbnails", processed.Thumbnail, ".jpg")
+	if err := u.storage.Put(thumbnailKey, bytes.NewReader(processed.Thumbnail), int64(len(processed.Thumbnail)), "image/jpeg"); err != nil {
+		return dto.ScheduleImagesDTO{}, apperror.Wrap(err, "failed to store thumbnail")
+	}
+
+	verification, verificationNote := u.verifyMetadata(processed.Metadata, start, end)
 
-	sort.Slice(schedules, func(i, j int) bool {
-		return schedules[i].Date.Before(schedules[j].Date)
+	imageDTO, err := u.scheduleImageRepository.Insert(dto.ScheduleImagesDTO{
+		ScheduleID:       schedule.ID,
+		FileName:         file.FileName,
+		StorageKey:       key,
+		Size:             int64(len(processed.Content)),
+		MimeType:         processed.MimeType,
+		Checksum:         checksum,
+		SourceChecksum:   sourceChecksum,
+		ThumbnailKey:     thumbnailKey,
+		Caption:          caption,
+		CapturedAt:       processed.Metadata.CapturedAt,
+		Latitude:         processed.Metadata.Latitude,
+		Longitude:        processed.Metadata.Longitude,
+		Verification:     verification,
+		VerificationNote: verificationNote,
 	})
+	if err != nil {
+		return dto.ScheduleImagesDTO{}, apperror.Wrap(err, "failed to save image")
+	}
 
-	today := time.Now()
-	var filteredSchedules []entity.Schedule
+	return imageDTO, nil
+}
 
-	for _, schedule := range schedules {
-		if schedule.Date.Before(today) {
-			filteredSchedules = append(filteredSchedules, schedule)
-		}
+// FindActivityProofs returns the admin review queue, optionally narrowed by status, schedule or trainer.
+func (u *scheduleImageUseCase) FindActivityProofs(spec query.Spec) ([]dto.ScheduleImagesDTO, model.Paging, error) {
+	return u.scheduleImageRepository.List(spec)
+}
+
+// FindTrainerActivityProofs lists the trainer's own uploads together with their review status.
+func (u *scheduleImageUseCase) FindTrainerActivityProofs(userID string, spec query.Spec) ([]dto.ScheduleImagesDTO, model.Paging, error) {
+	trainer, err := u.trainerUseCase.FindTrainerByUserId(userID)
+	if err != nil {
+		return nil, model.Paging{}, apperror.Lookup(err, "trainer not found")
 	}
+	spec.Where("trainerId", query.Eq, trainer.ID)
+	return u.scheduleImageRepository.List(spec)
+}
 
-	schedule := filteredSchedules[0]
+// FindActivityProof returns a single upload; trainers may only see their own.
+func (u *scheduleImageUseCase) FindActivityProof(id, userID, role string) (dto.ScheduleImagesDTO, error) {
+	image, err := u.scheduleImageRepository.Get(id)
+	if err != nil {
+		return dto.ScheduleImagesDTO{}, apperror.Lookup(err, "activity proof not found")
+	}
+	if role == "admin" {
+		return image, nil
+	}
 
-	if startTimeString == "" && endTimeString == "" {
-		startTimeString = "19:30"
-		endTimeString = "20:30"
+	trainer, err := u.trainerUseCase.FindTrainerByUserId(userID)
+	if err != nil || trainer.ID != image.TrainerID {
+		return dto.ScheduleImagesDTO{}, apperror.Forbidden("you are not allowed to access this activity proof")
 	}
+	return image, nil
+}
 
-	now := time.Now()
-	startTime, _ := time.Parse("15:04", startTimeString)
-	endTime, _ := time.Parse("15:04", endTimeString)
+// OpenActivityProofImage streams the stored image or its thumbnail after the access check.
+func (u *scheduleImageUseCase) OpenActivityProofImage(id, userID, role string, thumbnail bool) (io.ReadCloser, dto.ScheduleImagesDTO, error) {
+	image, err := u.FindActivityProof(id, userID, role)
+	if err != nil {
+		return nil, dto.ScheduleImagesDTO{}, err
+	}
 
-	hour := now.Hour()
-	minute := now.Minute()
-	startTimeHour := startTime.Hour()
-	startTimeMinute := startTime.Minute()
-	endTimeHour := endTime.Hour()
-	endTimeMinute := endTime.Minute()
+	key := image.StorageKey
+	if thumbnail {
+		key = image.ThumbnailKey
+		image.MimeType = "image/jpeg"
+	}
+	if key == "" {
+		return nil, dto.ScheduleImagesDTO{}, apperror.NotFound("image file is not available")
+	}
 
-	if (hour < startTimeHour && minute < startTimeMinute) || (hour > endTimeHour && minute > endTimeMinute) {
-		return dto.ScheduleImagesDTO{}, fmt.Errorf("cannot upload image outside the schedule time")
+	body, err := u.storage.Get(key)
+	if err != nil {
+		return nil, dto.ScheduleImagesDTO{}, apperror.Wrap(err, "failed to open image")
 	}
+	return body, image, nil
+}
 
-	imageDTO, err := u.scheduleImageRepository.Insert(dto.ScheduleImagesDTO{
-		ScheduleID: schedule.ID,
-		FileName:   filename,
-	})
+// ReviewActivityProof approves or rejects an upload; rejections must carry a reason.
+func (u *scheduleImageUseCase) ReviewActivityProof(id, reviewerUserID string, payload dto.ActivityProofReviewDTO) (dto.ScheduleImagesDTO, error) {
+	payload.Reason = strings.TrimSpace(payload.Reason)
+	if payload.Status == "Rejected" && payload.Reason == "" {
+		return dto.ScheduleImagesDTO{}, apperror.Invalid("reason", "reason is required when rejecting an activity proof")
+	}
+
+	if _, err := u.scheduleImageRepository.Get(id); err != nil {
+		return dto.ScheduleImagesDTO{}, apperror.Lookup(err, "activity proof not found")
+	}
+	if err := u.scheduleImageRepository.Review(id, reviewerUserID, payload, time.Now()); err != nil {
+		return dto.ScheduleImagesDTO{}, apperror.Wrap(err, "failed to review activity proof")
+	}
+	return u.scheduleImageRepository.Get(id)
+}
+
+// DeleteActivityProof removes the record and its stored files.
+func (u *scheduleImageUseCase) DeleteActivityProof(id string) error {
+	image, err := u.scheduleImageRepository.Get(id)
 	if err != nil {
-		return dto.ScheduleImagesDTO{}, fmt.Errorf("failed to save image: %v", err)
+		return apperror.Lookup(err, "activity proof not found")
+	}
+	if err := u.scheduleImageRepository.Delete(id); err != nil {
+		return apperror.Wrap(err, "failed to delete activity proof")
 	}
 
-	return imageDTO, nil
+	for _, key := range []string{image.StorageKey, image.ThumbnailKey} {
+		if key == "" {
+			continue
+		}
+		if err := u.storage.Delete(key); err != nil {
+			log.Println("scheduleImageUseCase.DeleteActivityProof:", err.Error())
+		}
+	}
+	return nil
+}
+
+// FindSessionsWithoutProof lists past sessions for which the trainer never uploaded a proof image.
+func (u *scheduleImageUseCase) FindSessionsWithoutProof(spec query.Spec) ([]entity.Schedule, model.Paging, error) {
+	return u.scheduleImageRepository.ListSessionsWithoutProof(spec)
+}
+
+// verifyMetadata compares the photo's capture time with the session window and its
+// GPS position with the venue. Whatever the photo does carry must agree, otherwise
+// the verdict is a mismatch; a photo with nothing to compare has no metadata.
+func (u *scheduleImageUseCase) verifyMetadata(metadata service.ImageMetadata, start, end time.Time) (string, string) {
+	var checked int
+	var problems []string
+
+	if metadata.CapturedAt != nil {
+		checked++
+		if metadata.CapturedAt.Before(start) || metadata.CapturedAt.After(end) {
+			problems = append(problems, fmt.Sprintf("photo taken at %s, outside the session window %s - %s",
+				metadata.CapturedAt.Format("2006-01-02 15:04"), start.Format("2006-01-02 15:04"), end.Format("15:04")))
+		}
+	}
+
+	if metadata.HasLocation() && u.sessionConfig.VenueConfigured {
+		checked++
+		distance := distanceInMeters(*metadata.Latitude, *metadata.Longitude, u.sessionConfig.VenueLatitude, u.sessionConfig.VenueLongitude)
+		if distance > u.sessionConfig.VenueRadius {
+			problems = append(problems, fmt.Sprintf("photo taken %.0fm from the venue, allowed radius is %.0fm", distance, u.sessionConfig.VenueRadius))
+		}
+	}
+
+	switch {
+	case checked == 0:
+		return verificationNoMetadata, "photo has no capture time or location to compare"
+	case len(problems) > 0:
+		return verificationMismatch, strings.Join(problems, "; ")
+	}
+	return verificationMatch, ""
+}
+
+// distanceInMeters is the haversine great-circle distance between two coordinates.
+func distanceInMeters(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadius = 6371000
+	toRadians := func(degree float64) float64 { return degree * math.Pi / 180 }
+
+	dLat := toRadians(lat2 - lat1)
+	dLon := toRadians(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return earthRadius * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
 }
 
-func NewScheduleImageUseCase(scheduleImageRepository repository.ScheduleImageRepository, scheduleUseCase ScheduleUseCase) ScheduleImageUseCase {
-	return &scheduleImageUseCase{scheduleImageRepository, scheduleUseCase}
+func NewScheduleImageUseCase(scheduleImageRepository repository.ScheduleImageRepository, scheduleUseCase ScheduleUseCase, trainerUseCase TrainerUsecase, storage service.FileStorage, imageProcessor service.ImageProcessor, sessionConfig config.SessionConfig) ScheduleImageUseCase {
+	return &scheduleImageUseCase{scheduleImageRepository, scheduleUseCase, trainerUseCase, storage, imageProcessor, sessionConfig}
 }
@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"instructor-led-app/config"
+	"instructor-led-app/shared/service"
+)
+
+func TestVerifyMetadata(t *testing.T) {
+	start := time.Date(2024, time.March, 5, 9, 0, 0, 0, time.Local)
+	end := start.Add(3 * time.Hour)
+	during, before := start.Add(time.Hour), start.Add(-time.Hour)
+	venueLat, venueLon := -6.2, 106.8
+	nearLat, farLat := -6.2005, -6.3
+
+	venue := config.SessionConfig{VenueLatitude: venueLat, VenueLongitude: venueLon, VenueRadius: 200, VenueConfigured: true}
+
+	tests := []struct {
+		name     string
+		session  config.SessionConfig
+		metadata service.ImageMetadata
+		want     string
+	}{
+		{"time and place agree", venue, service.ImageMetadata{CapturedAt: &during, Latitude: &nearLat, Longitude: &venueLon}, verificationMatch},
+		{"time only", venue, service.ImageMetadata{CapturedAt: &during}, verificationMatch},
+		{"place only", venue, service.ImageMetadata{Latitude: &nearLat, Longitude: &venueLon}, verificationMatch},
+		{"taken before the session", venue, service.ImageMetadata{CapturedAt: &before, Latitude: &nearLat, Longitude: &venueLon}, verificationMismatch},
+		{"taken away from the venue", venue, service.ImageMetadata{CapturedAt: &during, Latitude: &farLat, Longitude: &venueLon}, verificationMismatch},
+		{"nothing to compare", venue, service.ImageMetadata{}, verificationNoMetadata},
+		{"location without a venue", config.SessionConfig{}, service.ImageMetadata{Latitude: &farLat, Longitude: &venueLon}, verificationNoMetadata},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &scheduleImageUseCase{sessionConfig: tt.session}
+			got, note := u.verifyMetadata(tt.metadata, start, end)
+			if got != tt.want {
+				t.Errorf("verdict = %s (%s), want %s", got, note, tt.want)
+			}
+			if (got == verificationMatch) != (note == "") {
+				t.Errorf("note = %q for verdict %s", note, got)
+			}
+		})
+	}
+}
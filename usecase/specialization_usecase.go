@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"instructor-led-app/entity"
+	"instructor-led-app/entity/dto"
+	"instructor-led-app/repository"
+	"instructor-led-app/shared/apperror"
+	"strings"
+)
+
+type SpecializationUseCase interface {
+	FindSpecializations(trainerId, userID, role string) ([]entity.Specialization, error)
+	AddSpecialization(payload dto.SpecializationDTO, userID, role string) (entity.Specialization, error)
+	UpdateSpecialization(id string, payload dto.SpecializationDTO, userID, role string) (entity.Specialization, error)
+	DeleteSpecialization(id, userID, role string) error
+	SuggestTrainers(activity, scheduleID string) ([]dto.TrainerSuggestionDTO, error)
+}
+
+type specializationUseCase struct {
+	repo            repository.SpecializationRepository
+	trainerUseCase  TrainerUsecase
+	scheduleUseCase ScheduleUseCase
+}
+
+// FindSpecializations implements SpecializationUseCase.
+// Trainers always get their own list, admins may narrow it by trainer.
+func (s *specializationUseCase) FindSpecializations(trainerId, userID, role string) ([]entity.Specialization, error) {
+	if role == "trainer" {
+		trainer, err := s.trainerUseCase.FindTrainerByUserId(userID)
+		if err != nil {
+			return nil, apperror.Lookup(err, "trainer not found")
+		}
+		trainerId = trainer.ID
+	}
+	return s.repo.List(trainerId)
+}
+
+// AddSpecialization implements SpecializationUseCase.
+func (s *specializationUseCase) AddSpecialization(payload dto.SpecializationDTO, userID, role string) (entity.Specialization, error) {
+	payload.Name = strings.TrimSpace(payload.Name)
+	if payload.Name == "" {
+		return entity.Specialization{}, apperror.Invalid("name", "name is required")
+	}
+
+	if role == "trainer" {
+		trainer, err := s.trainerUseCase.FindTrainerByUserId(userID)
+		if err != nil {
+			return entity.Specialization{}, apperror.Lookup(err, "trainer not found")
+		}
+		payload.TrainerID = trainer.ID
+	}
+	if payload.TrainerID == "" {
+		return entity.Specialization{}, apperror.Invalid("trainerId", "trainerId is required")
+	}
+	if trainers, err := s.trainerUseCase.FindTrainerById(payload.TrainerID); err != nil || len(trainers) == 0 {
+		return entity.Specialization{}, apperror.Lookup(err, "trainer with ID %s not found", payload.TrainerID)
+	}
+
+	if exists, err := s.repo.ExistsForTrainer(payload.TrainerID, payload.Name, ""); err != nil {
+		return entity.Specialization{}, apperror.Wrap(err, "failed to check specialization")
+	} else if exists {
+		return entity.Specialization{}, apperror.Conflict("trainer already has specialization %s", payload.Name)
+	}
+
+	specialization, err := s.repo.Create(payload)
+	if err != nil {
+		return entity.Specialization{}, apperror.Wrap(err, "failed to save specialization")
+	}
+	return specialization, nil
+}
+
+// UpdateSpecialization implements SpecializationUseCase.
+func (s *specializationUseCase) UpdateSpecialization(id string, payload dto.SpecializationDTO, userID, role string) (entity.Specialization, error) {
+	payload.Name = strings.TrimSpace(payload.Name)
+	if payload.Name == "" {
+		return entity.Specialization{}, apperror.Invalid("name", "name is required")
+	}
+
+	specialization, err := s.findOwned(id, userID, role)
+	if err != nil {
+		return entity.Specialization{}, err
+	}
+
+	if exists, err := s.repo.ExistsForTrainer(specialization.TrainerID, payload.Name, id); err != nil {
+		return entity.Specialization{}, apperror.Wrap(err, "failed to check specialization")
+	} else if exists {
+		return entity.Specialization{}, apperror.Conflict("trainer already has specialization %s", payload.Name)
+	}
+
+	updated, err := s.repo.Update(id, payload.Name)
+	if err != nil {
+		return entity.Specialization{}, apperror.Wrap(err, "failed to update specialization")
+	}
+	return updated, nil
+}
+
+// DeleteSpecialization implements SpecializationUseCase.
+func (s *specializationUseCase) DeleteSpecialization(id, userID, role string) error {
+	if _, err := s.findOwned(id, userID, role); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(id); err != nil {
+		return apperror.Wrap(err, "failed to delete specialization")
+	}
+	return nil
+}
+
+// SuggestTrainers implements SpecializationUseCase.
+// The activity comes from the request or, when reassigning, from the schedule itself.
+func (s *specializationUseCase) SuggestTrainers(activity, scheduleID string) ([]dto.TrainerSuggestionDTO, error) {
+	if scheduleID != "" {
+		schedule, err := s.scheduleUseCase.FindScheduleForUser(scheduleID, "", "admin")
+		if err != nil {
+			return nil, err
+		}
+		activity = schedule.Activity
+	}
+
+	activity = strings.TrimSpace(activity)
+	if activity == "" {
+		return nil, apperror.BadRequest("activity or scheduleId is required")
+	}
+	return s.repo.SuggestTrainers(activity)
+}
+
+func (s *specializationUseCase) findOwned(id, userID, role string) (entity.Specialization, error) {
+	specialization, err := s.repo.Get(id)
+	if err != nil {
+		return entity.Specialization{}, apperror.Lookup(err, "specialization with ID %s not found", id)
+	}
+	if role == "admin" {
+		return specialization, nil
+	}
+
+	trainer, err := s.trainerUseCase.FindTrainerByUserId(userID)
+	if err != nil || trainer.ID != specialization.TrainerID {
+		return entity.Specialization{}, apperror.Forbidden("you can only manage your own specializations")
+	}
+	return specialization, nil
+}
+
+func NewSpecializationUseCase(repo repository.SpecializationRepository, trainerUseCase TrainerUsecase, scheduleUseCase ScheduleUseCase) SpecializationUseCase {
+	return &specializationUseCase{repo: repo, trainerUseCase: trainerUseCase, scheduleUseCase: scheduleUseCase}
+}
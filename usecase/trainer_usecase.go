@@ -1,17 +1,18 @@
 package usecase
 
 import (
-	"fmt"
 	"instructor-led-app/entity"
 	"instructor-led-app/entity/dto"
 	"instructor-led-app/repository"
+	"instructor-led-app/shared/apperror"
 	"instructor-led-app/shared/model"
+	"instructor-led-app/shared/query"
 	"log"
 	"time"
 )
 
 type TrainerUsecase interface {
-	FindAllTrainer(page, size int) ([]entity.Trainer, model.Paging, error)
+	FindAllTrainer(spec query.Spec) ([]entity.Trainer, model.Paging, error)
 	FindTrainerById(trainerId string) ([]entity.Trainer, error)
 	FindTrainerByUserIDs(userID string) (dto.TrainerDTO, error)
 	TrainerUpdated(trainer dto.TrainerDTO) (dto.TrainerDTO, error)
@@ -31,7 +32,7 @@ func (t *trainerUseCase) FindTrainerByUserId(userId string) (entity.Trainer, err
 // TrainerUpdated implements TrainerUsecase.
 func (t *trainerUseCase) TrainerUpdated(trainer dto.TrainerDTO) (dto.TrainerDTO, error) {
 	if trainer.ID == "" && trainer.PhoneNumber == "" && trainer.UserID == "" {
-		return dto.TrainerDTO{}, fmt.Errorf("field can't be empty")
+		return dto.TrainerDTO{}, apperror.BadRequest("id, phoneNumber or userId is required")
 	}
 	return t.repo.UpdateTrainer(trainer, time.Now())
 }
@@ -55,8 +56,8 @@ func (t *trainerUseCase) FindTrainerByUserIDs(userID string) (dto.TrainerDTO, er
 }
 
 // FindAllTrainer implements TrainerUsecase.
-func (t *trainerUseCase) FindAllTrainer(page, size int) ([]entity.Trainer, model.Paging, error) {
-	return t.repo.List(page, size)
+func (t *trainerUseCase) FindAllTrainer(spec query.Spec) ([]entity.Trainer, model.Paging, error) {
+	return t.repo.List(spec)
 }
 
 func NewTrainerUseCase(repo repository.TrainerRepository) TrainerUsecase {
@@ -1,12 +1,12 @@
 package usecase
 
 import (
-	"errors"
-	"fmt"
 	"instructor-led-app/entity"
 	"instructor-led-app/entity/dto"
 	"instructor-led-app/repository"
+	"instructor-led-app/shared/apperror"
 	"instructor-led-app/shared/model"
+	"instructor-led-app/shared/query"
 	"log"
 	"time"
 
@@ -15,7 +15,7 @@ import (
 
 type UserUsecase interface {
 	FindById(id string) (entity.User, error)
-	FindAllUser(page, size int) ([]entity.User, model.Paging, error)
+	FindAllUser(spec query.Spec) ([]entity.User, model.Paging, error)
 	CreatedUser(data entity.User) (entity.User, error)
 	CreatedUserByCsv(filePath string) ([]entity.User, error)
 	UpdatedUser(id string, data entity.User) (entity.User, error)
@@ -35,21 +35,21 @@ func (u *userUsecase) FindUserIDByName(name string) (dto.UserId, error) {
 
 // AuthUser implements UserUsecase.
 func (t *userUsecase) AuthUser(email string, hashPassword string) (entity.User, error) {
-	user, err := t.repo.GetUser(email)
-	if err != nil {
-		return entity.User{}, err
-	}
-
 	if hashPassword == "" {
 		log.Println("userUsecase.AuthUser: Empty password provided")
-		return entity.User{}, errors.New("password required")
+		return entity.User{}, apperror.Invalid("password", "password required")
+	}
+
+	user, err := t.repo.GetUser(email)
+	if err != nil {
+		return entity.User{}, apperror.Unauthorized("invalid email or password")
 	}
 
 	// Verify the entered password with the stored hashed password
 	err = bcrypt.CompareHashAndPassword([]byte(user.Hashpassword), []byte(hashPassword))
 	if err != nil {
 		log.Println("userUsecase.AuthUser: Password verification failed")
-		return entity.User{}, errors.New("password verification failed")
+		return entity.User{}, apperror.Unauthorized("invalid email or password")
 
 	}
 
@@ -58,8 +58,8 @@ func (t *userUsecase) AuthUser(email string, hashPassword string) (entity.User,
 }
 
 // FindAllUser implements UserUsecase.
-func (t *userUsecase) FindAllUser(page int, size int) ([]entity.User, model.Paging, error) {
-	return t.repo.List(page, size)
+func (t *userUsecase) FindAllUser(spec query.Spec) ([]entity.User, model.Paging, error) {
+	return t.repo.List(spec)
 
 }
 
@@ -78,14 +78,10 @@ func (t *userUsecase) CreatedUserByCsv(filePath string) ([]entity.User, error) {
 
 // CreatedUser implements UserUsecase.
 func (t *userUsecase) CreatedUser(data entity.User) (entity.User, error) {
-
-	if data.Name == "" || data.Email == "" || data.Username == "" || data.Address == "" || data.Hashpassword == "" || data.Role == "" {
-		return entity.User{}, fmt.Errorf("oppps, required fields")
-	}
 	data.UpdatedAt = time.Now()
 	user, err := t.repo.Created(data)
 	if err != nil {
-		return entity.User{}, fmt.Errorf("oppps, failed to save data user :%v", err.Error())
+		return entity.User{}, apperror.Wrap(err, "failed to save user")
 	}
 	return user, nil
 
@@ -107,7 +103,7 @@ func (t *userUsecase) UpdatedUser(id string, data entity.User) (entity.User, err
 	data.UpdatedAt = time.Now()
 	data, err := t.repo.Updated(id, data)
 	if err != nil {
-		return entity.User{}, fmt.Errorf("oppps, failed to update data user :%v", err.Error())
+		return entity.User{}, apperror.Wrap(err, "failed to update user")
 	}
 	return data, nil
 